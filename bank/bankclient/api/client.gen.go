@@ -0,0 +1,3869 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for AuthorizationEventType.
+const (
+	Capture   AuthorizationEventType = "capture"
+	Increment AuthorizationEventType = "increment"
+	Reversal  AuthorizationEventType = "reversal"
+	Void      AuthorizationEventType = "void"
+)
+
+// Defines values for AuthorizationResponseStatus.
+const (
+	Active                AuthorizationResponseStatus = "active"
+	Approved              AuthorizationResponseStatus = "approved"
+	AuthorizationCaptured AuthorizationResponseStatus = "captured"
+	AuthorizationVoided   AuthorizationResponseStatus = "voided"
+	Expired               AuthorizationResponseStatus = "expired"
+)
+
+// Defines values for CaptureResponseStatus.
+const (
+	Captured CaptureResponseStatus = "captured"
+)
+
+// Defines values for ErrorCode.
+const (
+	ErrorCodeAlreadyCaptured              ErrorCode = "already_captured"
+	ErrorCodeAlreadyRefunded              ErrorCode = "already_refunded"
+	ErrorCodeAlreadyVoided                ErrorCode = "already_voided"
+	ErrorCodeAmountMismatch               ErrorCode = "amount_mismatch"
+	ErrorCodeAuthorizationAlreadyUsed     ErrorCode = "authorization_already_used"
+	ErrorCodeAuthorizationExpired         ErrorCode = "authorization_expired"
+	ErrorCodeAuthorizationNotFound        ErrorCode = "authorization_not_found"
+	ErrorCodeCaptureExceedsAuthorization  ErrorCode = "capture_exceeds_authorization"
+	ErrorCodeCaptureNotFound              ErrorCode = "capture_not_found"
+	ErrorCodeCardExpired                  ErrorCode = "card_expired"
+	ErrorCodeIdempotencyKeyReused         ErrorCode = "idempotency_key_reused"
+	ErrorCodeInsufficientFunds            ErrorCode = "insufficient_funds"
+	ErrorCodeInternalError                ErrorCode = "internal_error"
+	ErrorCodeInvalidAmount                ErrorCode = "invalid_amount"
+	ErrorCodeInvalidCard                  ErrorCode = "invalid_card"
+	ErrorCodeInvalidChaosHeader           ErrorCode = "invalid_chaos_header"
+	ErrorCodeInvalidChaosSettings         ErrorCode = "invalid_chaos_settings"
+	ErrorCodeInvalidCvv                   ErrorCode = "invalid_cvv"
+	ErrorCodeInvalidExpiry                ErrorCode = "invalid_expiry"
+	ErrorCodeInvalidMetadata              ErrorCode = "invalid_metadata"
+	ErrorCodeInvalidQuery                 ErrorCode = "invalid_query"
+	ErrorCodeInvalidRateLimitSettings     ErrorCode = "invalid_rate_limit_settings"
+	ErrorCodeMaintenance                  ErrorCode = "maintenance"
+	ErrorCodeMissingIdempotencyKey        ErrorCode = "missing_idempotency_key"
+	ErrorCodeNotFound                     ErrorCode = "not_found"
+	ErrorCodeRateLimited                  ErrorCode = "rate_limited"
+	ErrorCodeRefundExceedsCapture         ErrorCode = "refund_exceeds_capture"
+	ErrorCodeRefundNotFound               ErrorCode = "refund_not_found"
+	ErrorCodeRequestInProgress            ErrorCode = "request_in_progress"
+	ErrorCodeReversalExceedsAuthorization ErrorCode = "reversal_exceeds_authorization"
+	ErrorCodeVoidNotFound                 ErrorCode = "void_not_found"
+)
+
+// Defines values for HealthResponseStatus.
+const (
+	HealthResponseStatusDegraded    HealthResponseStatus = "degraded"
+	HealthResponseStatusHealthy     HealthResponseStatus = "healthy"
+	HealthResponseStatusMaintenance HealthResponseStatus = "maintenance"
+	HealthResponseStatusUnhealthy   HealthResponseStatus = "unhealthy"
+)
+
+// Defines values for IncrementResponseStatus.
+const (
+	Incremented IncrementResponseStatus = "incremented"
+)
+
+// Defines values for RefundResponseStatus.
+const (
+	Refunded RefundResponseStatus = "refunded"
+)
+
+// Defines values for ReversalResponseStatus.
+const (
+	Reversed ReversalResponseStatus = "reversed"
+)
+
+// Defines values for TransactionStatus.
+const (
+	TransactionStatusActive    TransactionStatus = "active"
+	TransactionStatusCompleted TransactionStatus = "completed"
+	TransactionStatusExpired   TransactionStatus = "expired"
+)
+
+// Defines values for TransactionType.
+const (
+	TransactionTypeAuthorization TransactionType = "authorization"
+	TransactionTypeCapture       TransactionType = "capture"
+	TransactionTypeIncrement     TransactionType = "increment"
+	TransactionTypeRefund        TransactionType = "refund"
+	TransactionTypeReversal      TransactionType = "reversal"
+	TransactionTypeVoid          TransactionType = "void"
+)
+
+// Defines values for VoidResponseStatus.
+const (
+	Voided VoidResponseStatus = "voided"
+)
+
+// AuthorizationEvent defines model for AuthorizationEvent.
+type AuthorizationEvent struct {
+	Amount    int64                  `json:"amount"`
+	CreatedAt time.Time              `json:"created_at"`
+	Id        string                 `json:"id"`
+	Type      AuthorizationEventType `json:"type"`
+}
+
+// AuthorizationEventType defines model for AuthorizationEvent.Type.
+type AuthorizationEventType string
+
+// AuthorizationResponse defines model for AuthorizationResponse.
+type AuthorizationResponse struct {
+	// Amount Authorized amount in cents
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+
+	// CaptureIds IDs of the captures taken against this authorization
+	CaptureIds []string `json:"capture_ids"`
+
+	// CapturedAmount Total captured so far in cents
+	CapturedAmount int64     `json:"captured_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+	Currency       string    `json:"currency"`
+	ExpiresAt      time.Time `json:"expires_at"`
+
+	// History Ledger entries recorded against this authorization, oldest first
+	History []AuthorizationEvent `json:"history"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+
+	// RemainingAmount Amount in cents still held and available to capture
+	RemainingAmount int64 `json:"remaining_amount"`
+
+	// ReversedAmount Total released so far by partial reversals in cents
+	ReversedAmount int64 `json:"reversed_amount"`
+
+	// Status `approved` is returned when the authorization is created. Lookups report the
+	// current lifecycle state: `active` (still capturable), `captured`, `voided`
+	// (released without capture) or `expired`.
+	Status AuthorizationResponseStatus `json:"status"`
+
+	// VoidId ID of the void, if the authorization was voided
+	VoidId string `json:"void_id,omitempty,omitzero"`
+}
+
+// AuthorizationResponseStatus `approved` is returned when the authorization is created. Lookups report the
+// current lifecycle state: `active` (still capturable), `captured`, `voided`
+// (released without capture) or `expired`.
+type AuthorizationResponseStatus string
+
+// CaptureResponse defines model for CaptureResponse.
+type CaptureResponse struct {
+	Amount          int64     `json:"amount"`
+	AuthorizationId string    `json:"authorization_id"`
+	CaptureId       string    `json:"capture_id"`
+	CapturedAt      time.Time `json:"captured_at"`
+	Currency        string    `json:"currency"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+
+	// RefundableAmount Amount in cents still available to refund
+	RefundableAmount int64 `json:"refundable_amount"`
+
+	// RefundedAmount Total refunded so far in cents
+	RefundedAmount int64                 `json:"refunded_amount"`
+	Status         CaptureResponseStatus `json:"status"`
+}
+
+// CaptureResponseStatus defines model for CaptureResponse.Status.
+type CaptureResponseStatus string
+
+// ChaosProfile defines model for ChaosProfile.
+type ChaosProfile struct {
+	// FaultRates Fraction of requests (0 to 1) that get each fault kind: error, error_after_commit, hang,
+	// reset, truncated_body, malformed_body, bad_gateway, unavailable, gateway_timeout, and the
+	// three proxy errors with a _retry_after suffix. The rates may add up to at most 1.
+	FaultRates   map[string]float64 `json:"fault_rates"`
+	MaxLatencyMs int                `json:"max_latency_ms"`
+	MinLatencyMs int                `json:"min_latency_ms"`
+}
+
+// ChaosRoute defines model for ChaosRoute.
+type ChaosRoute struct {
+	// FaultRates Fault rates for matching requests, as in ChaosProfile
+	FaultRates   map[string]float64 `json:"fault_rates"`
+	MaxLatencyMs int                `json:"max_latency_ms"`
+
+	// Method HTTP method to match; any method if omitted
+	Method       string `json:"method,omitempty,omitzero"`
+	MinLatencyMs int    `json:"min_latency_ms"`
+
+	// Path Path to match; `*` stands for one path segment, e.g. /api/v1/authorizations/*/increments
+	Path string `json:"path"`
+}
+
+// ChaosScriptStep defines model for ChaosScriptStep.
+type ChaosScriptStep struct {
+	// Fault Fault kind, as in ChaosProfile fault_rates
+	Fault string `json:"fault"`
+
+	// Method HTTP method to count; any method if omitted
+	Method string `json:"method,omitempty,omitzero"`
+
+	// Path Path pattern to count, as in ChaosRoute; any path if omitted
+	Path string `json:"path,omitempty,omitzero"`
+
+	// Request Which matching request fails, counting from 1
+	Request int `json:"request"`
+}
+
+// ChaosSettings defines model for ChaosSettings.
+type ChaosSettings struct {
+	Default ChaosProfile `json:"default"`
+
+	// Routes Per-route profiles, tried in order; the first match replaces the default
+	Routes []ChaosRoute `json:"routes"`
+
+	// Script Scripted faults that replace the random ones: only the requests listed here fail, and
+	// latency still follows the profiles. Requests replayed from an idempotency key are not counted.
+	Script []ChaosScriptStep `json:"script,omitempty,omitzero"`
+
+	// Seed Seed for the random latency and faults. The same seed and order of requests give the
+	// same chaos. Omit or send 0 for a random seed; responses always show the seed in use.
+	Seed uint64 `json:"seed,omitempty,omitzero"`
+}
+
+// CreateAuthorizationRequest defines model for CreateAuthorizationRequest.
+type CreateAuthorizationRequest struct {
+	// Amount Amount in cents
+	Amount int64 `json:"amount"`
+
+	// CardNumber Card number (Luhn validated)
+	CardNumber string `json:"card_number"`
+
+	// Cvv Card verification value
+	Cvv string `json:"cvv"`
+
+	// ExpiryMonth Must match the card on file, otherwise the request fails with invalid_expiry
+	ExpiryMonth int `json:"expiry_month"`
+
+	// ExpiryYear Must match the card on file, otherwise the request fails with invalid_expiry
+	ExpiryYear int `json:"expiry_year"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+}
+
+// CreateCaptureRequest defines model for CreateCaptureRequest.
+type CreateCaptureRequest struct {
+	// Amount Amount in cents (up to the remaining authorized amount)
+	Amount int64 `json:"amount"`
+
+	// AuthorizationId Authorization ID to capture
+	AuthorizationId string `json:"authorization_id"`
+
+	// FinalCapture Release any hold left on the authorization after this capture
+	FinalCapture bool `json:"final_capture,omitempty,omitzero"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+}
+
+// CreateIncrementRequest defines model for CreateIncrementRequest.
+type CreateIncrementRequest struct {
+	// Amount Amount in cents to add to the hold
+	Amount int64 `json:"amount"`
+}
+
+// CreateRefundRequest defines model for CreateRefundRequest.
+type CreateRefundRequest struct {
+	// Amount Amount in cents (up to the remaining refundable amount)
+	Amount int64 `json:"amount"`
+
+	// CaptureId Capture ID to refund
+	CaptureId string `json:"capture_id"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+}
+
+// CreateReversalRequest defines model for CreateReversalRequest.
+type CreateReversalRequest struct {
+	// Amount Amount in cents to release (up to the remaining authorized amount)
+	Amount int64 `json:"amount"`
+}
+
+// CreateVoidRequest defines model for CreateVoidRequest.
+type CreateVoidRequest struct {
+	// AuthorizationId Authorization ID to void
+	AuthorizationId string `json:"authorization_id"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+}
+
+// ErrorCode defines model for ErrorCode.
+type ErrorCode string
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error   ErrorCode `json:"error"`
+	Message string    `json:"message"`
+}
+
+// HealthResponse defines model for HealthResponse.
+type HealthResponse struct {
+	Status HealthResponseStatus `json:"status"`
+
+	// Until When the maintenance or degraded window ends
+	Until time.Time `json:"until,omitempty,omitzero"`
+}
+
+// HealthResponseStatus defines model for HealthResponse.Status.
+type HealthResponseStatus string
+
+// IdempotencyKeyListResponse defines model for IdempotencyKeyListResponse.
+type IdempotencyKeyListResponse struct {
+	Data []IdempotencyKeyRecord `json:"data"`
+}
+
+// IdempotencyKeyRecord defines model for IdempotencyKeyRecord.
+type IdempotencyKeyRecord struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// InProgress The original request is still being processed and has no stored response yet
+	InProgress bool   `json:"in_progress"`
+	Key        string `json:"key"`
+
+	// RequestHash SHA-256 of the canonical request body; empty for keys stored before fingerprinting
+	RequestHash string `json:"request_hash"`
+	RequestPath string `json:"request_path"`
+
+	// ResponseBody Response body replayed for this key
+	ResponseBody string `json:"response_body"`
+
+	// ResponseStatus HTTP status replayed for this key
+	ResponseStatus int `json:"response_status"`
+}
+
+// IncrementResponse defines model for IncrementResponse.
+type IncrementResponse struct {
+	// Amount Amount in cents added by this increment
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+
+	// AuthorizedAmount New total held by the authorization in cents
+	AuthorizedAmount int64     `json:"authorized_amount"`
+	CreatedAt        time.Time `json:"created_at"`
+	Currency         string    `json:"currency"`
+
+	// ExpiresAt Unchanged expiry of the authorization
+	ExpiresAt   time.Time `json:"expires_at"`
+	IncrementId string    `json:"increment_id"`
+
+	// RemainingAmount Amount in cents still held and available to capture
+	RemainingAmount int64                   `json:"remaining_amount"`
+	Status          IncrementResponseStatus `json:"status"`
+}
+
+// IncrementResponseStatus defines model for IncrementResponse.Status.
+type IncrementResponseStatus string
+
+// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+type Metadata map[string]string
+
+// RateLimit defines model for RateLimit.
+type RateLimit struct {
+	// Burst Requests the bucket holds when full, i.e. how many can be sent at once
+	Burst int `json:"burst"`
+
+	// RequestsPerSecond Rate the client's bucket refills at; 0 means no limit
+	RequestsPerSecond float64 `json:"requests_per_second"`
+}
+
+// RateLimitRoute defines model for RateLimitRoute.
+type RateLimitRoute struct {
+	Burst int `json:"burst"`
+
+	// Method HTTP method to match; any method if omitted
+	Method string `json:"method,omitempty,omitzero"`
+
+	// Path Path to match; `*` stands for one path segment, e.g. /api/v1/authorizations/*/increments
+	Path              string  `json:"path"`
+	RequestsPerSecond float64 `json:"requests_per_second"`
+}
+
+// RateLimitSettings defines model for RateLimitSettings.
+type RateLimitSettings struct {
+	Default RateLimit `json:"default"`
+
+	// Routes Per-route limits, tried in order; each has its own bucket per client
+	Routes []RateLimitRoute `json:"routes"`
+}
+
+// RefundResponse defines model for RefundResponse.
+type RefundResponse struct {
+	Amount    int64  `json:"amount"`
+	CaptureId string `json:"capture_id"`
+	Currency  string `json:"currency"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata   Metadata             `json:"metadata,omitempty,omitzero"`
+	RefundId   string               `json:"refund_id"`
+	RefundedAt time.Time            `json:"refunded_at"`
+	Status     RefundResponseStatus `json:"status"`
+}
+
+// RefundResponseStatus defines model for RefundResponse.Status.
+type RefundResponseStatus string
+
+// ReversalResponse defines model for ReversalResponse.
+type ReversalResponse struct {
+	// Amount Amount in cents released by this reversal
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+	Currency        string `json:"currency"`
+
+	// RemainingAmount Amount in cents still held and available to capture
+	RemainingAmount int64                  `json:"remaining_amount"`
+	ReversalId      string                 `json:"reversal_id"`
+	ReversedAt      time.Time              `json:"reversed_at"`
+	Status          ReversalResponseStatus `json:"status"`
+}
+
+// ReversalResponseStatus defines model for ReversalResponse.Status.
+type ReversalResponseStatus string
+
+// TransactionListResponse defines model for TransactionListResponse.
+type TransactionListResponse struct {
+	Data    []TransactionResponse `json:"data"`
+	HasMore bool                  `json:"has_more"`
+
+	// NextCursor Pass as `cursor` to get the next page; absent on the last page
+	NextCursor string `json:"next_cursor,omitempty,omitzero"`
+}
+
+// TransactionResponse defines model for TransactionResponse.
+type TransactionResponse struct {
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+	Currency  string    `json:"currency"`
+	Id        string    `json:"id"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+
+	// ReferenceId Authorization or capture this transaction belongs to
+	ReferenceId string            `json:"reference_id,omitempty,omitzero"`
+	Status      TransactionStatus `json:"status"`
+	Type        TransactionType   `json:"type"`
+}
+
+// TransactionStatus defines model for TransactionStatus.
+type TransactionStatus string
+
+// TransactionType defines model for TransactionType.
+type TransactionType string
+
+// VoidResponse defines model for VoidResponse.
+type VoidResponse struct {
+	// Amount Amount in cents released back to the account by the void
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+	Currency        string `json:"currency"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata           `json:"metadata,omitempty,omitzero"`
+	Status   VoidResponseStatus `json:"status"`
+	VoidId   string             `json:"void_id"`
+	VoidedAt time.Time          `json:"voided_at"`
+}
+
+// VoidResponseStatus defines model for VoidResponse.Status.
+type VoidResponseStatus string
+
+// AuthorizationId defines model for AuthorizationId.
+type AuthorizationId = string
+
+// CaptureId defines model for CaptureId.
+type CaptureId = string
+
+// IdempotencyKeyPath defines model for IdempotencyKeyPath.
+type IdempotencyKeyPath = string
+
+// IdempotencyKeyRequired defines model for IdempotencyKeyRequired.
+type IdempotencyKeyRequired = string
+
+// RefundId defines model for RefundId.
+type RefundId = string
+
+// VoidId defines model for VoidId.
+type VoidId = string
+
+// BadRequest defines model for BadRequest.
+type BadRequest = ErrorResponse
+
+// IdempotencyKeyReused defines model for IdempotencyKeyReused.
+type IdempotencyKeyReused = ErrorResponse
+
+// InternalError defines model for InternalError.
+type InternalError = ErrorResponse
+
+// Maintenance defines model for Maintenance.
+type Maintenance = ErrorResponse
+
+// NotFound defines model for NotFound.
+type NotFound = ErrorResponse
+
+// PaymentRequired defines model for PaymentRequired.
+type PaymentRequired = ErrorResponse
+
+// RequestInProgress defines model for RequestInProgress.
+type RequestInProgress = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// DeleteIdempotencyKeyParams defines parameters for DeleteIdempotencyKey.
+type DeleteIdempotencyKeyParams struct {
+	// RequestPath Only purge the entry for this request path; all paths if omitted
+	RequestPath string `form:"request_path,omitempty" json:"request_path,omitempty,omitzero"`
+}
+
+// CreateAuthorizationParams defines parameters for CreateAuthorization.
+type CreateAuthorizationParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// CreateAuthorizationIncrementParams defines parameters for CreateAuthorizationIncrement.
+type CreateAuthorizationIncrementParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// CreateAuthorizationReversalParams defines parameters for CreateAuthorizationReversal.
+type CreateAuthorizationReversalParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// CreateCaptureParams defines parameters for CreateCapture.
+type CreateCaptureParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// CreateRefundParams defines parameters for CreateRefund.
+type CreateRefundParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// ListTransactionsParams defines parameters for ListTransactions.
+type ListTransactionsParams struct {
+	// AccountNumber Only transactions on this account (card number)
+	AccountNumber string `form:"account_number,omitempty" json:"account_number,omitempty,omitzero"`
+
+	// Type Only transactions of this type
+	Type TransactionType `form:"type,omitempty" json:"type,omitempty,omitzero"`
+
+	// Status Only transactions in this status
+	Status TransactionStatus `form:"status,omitempty" json:"status,omitempty,omitzero"`
+
+	// ReferenceId Only transactions that reference this ID, e.g. the captures of an authorization
+	ReferenceId string `form:"reference_id,omitempty" json:"reference_id,omitempty,omitzero"`
+
+	// MetadataKey Only transactions whose metadata has this key, e.g. order_id
+	MetadataKey string `form:"metadata_key,omitempty" json:"metadata_key,omitempty,omitzero"`
+
+	// MetadataValue Only transactions whose metadata_key has this value; requires metadata_key
+	MetadataValue string `form:"metadata_value,omitempty" json:"metadata_value,omitempty,omitzero"`
+
+	// CreatedFrom Only transactions created at or after this time
+	CreatedFrom time.Time `form:"created_from,omitempty" json:"created_from,omitempty,omitzero"`
+
+	// CreatedTo Only transactions created before this time
+	CreatedTo time.Time `form:"created_to,omitempty" json:"created_to,omitempty,omitzero"`
+
+	// Cursor Cursor returned as `next_cursor` by the previous page
+	Cursor string `form:"cursor,omitempty" json:"cursor,omitempty,omitzero"`
+
+	// Limit Page size
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
+// CreateVoidParams defines parameters for CreateVoid.
+type CreateVoidParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// UpdateChaosSettingsJSONRequestBody defines body for UpdateChaosSettings for application/json ContentType.
+type UpdateChaosSettingsJSONRequestBody = ChaosSettings
+
+// UpdateRateLimitsJSONRequestBody defines body for UpdateRateLimits for application/json ContentType.
+type UpdateRateLimitsJSONRequestBody = RateLimitSettings
+
+// CreateAuthorizationJSONRequestBody defines body for CreateAuthorization for application/json ContentType.
+type CreateAuthorizationJSONRequestBody = CreateAuthorizationRequest
+
+// CreateAuthorizationIncrementJSONRequestBody defines body for CreateAuthorizationIncrement for application/json ContentType.
+type CreateAuthorizationIncrementJSONRequestBody = CreateIncrementRequest
+
+// CreateAuthorizationReversalJSONRequestBody defines body for CreateAuthorizationReversal for application/json ContentType.
+type CreateAuthorizationReversalJSONRequestBody = CreateReversalRequest
+
+// CreateCaptureJSONRequestBody defines body for CreateCapture for application/json ContentType.
+type CreateCaptureJSONRequestBody = CreateCaptureRequest
+
+// CreateRefundJSONRequestBody defines body for CreateRefund for application/json ContentType.
+type CreateRefundJSONRequestBody = CreateRefundRequest
+
+// CreateVoidJSONRequestBody defines body for CreateVoid for application/json ContentType.
+type CreateVoidJSONRequestBody = CreateVoidRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// ResetChaosSettings request
+	ResetChaosSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetChaosSettings request
+	GetChaosSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateChaosSettingsWithBody request with any body
+	UpdateChaosSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateChaosSettings(ctx context.Context, body UpdateChaosSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteIdempotencyKey request
+	DeleteIdempotencyKey(ctx context.Context, idempotencyKey IdempotencyKeyPath, params *DeleteIdempotencyKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetIdempotencyKey request
+	GetIdempotencyKey(ctx context.Context, idempotencyKey IdempotencyKeyPath, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResetRateLimits request
+	ResetRateLimits(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRateLimits request
+	GetRateLimits(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UpdateRateLimitsWithBody request with any body
+	UpdateRateLimitsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateRateLimits(ctx context.Context, body UpdateRateLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAuthorizationWithBody request with any body
+	CreateAuthorizationWithBody(ctx context.Context, params *CreateAuthorizationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAuthorization(ctx context.Context, params *CreateAuthorizationParams, body CreateAuthorizationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAuthorization request
+	GetAuthorization(ctx context.Context, authorizationId AuthorizationId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAuthorizationIncrementWithBody request with any body
+	CreateAuthorizationIncrementWithBody(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAuthorizationIncrement(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, body CreateAuthorizationIncrementJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateAuthorizationReversalWithBody request with any body
+	CreateAuthorizationReversalWithBody(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateAuthorizationReversal(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, body CreateAuthorizationReversalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateCaptureWithBody request with any body
+	CreateCaptureWithBody(ctx context.Context, params *CreateCaptureParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateCapture(ctx context.Context, params *CreateCaptureParams, body CreateCaptureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCapture request
+	GetCapture(ctx context.Context, captureId CaptureId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateRefundWithBody request with any body
+	CreateRefundWithBody(ctx context.Context, params *CreateRefundParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateRefund(ctx context.Context, params *CreateRefundParams, body CreateRefundJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRefund request
+	GetRefund(ctx context.Context, refundId RefundId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListTransactions request
+	ListTransactions(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateVoidWithBody request with any body
+	CreateVoidWithBody(ctx context.Context, params *CreateVoidParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateVoid(ctx context.Context, params *CreateVoidParams, body CreateVoidJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetVoid request
+	GetVoid(ctx context.Context, voidId VoidId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) ResetChaosSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResetChaosSettingsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetChaosSettings(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetChaosSettingsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChaosSettingsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChaosSettingsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateChaosSettings(ctx context.Context, body UpdateChaosSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateChaosSettingsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteIdempotencyKey(ctx context.Context, idempotencyKey IdempotencyKeyPath, params *DeleteIdempotencyKeyParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteIdempotencyKeyRequest(c.Server, idempotencyKey, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetIdempotencyKey(ctx context.Context, idempotencyKey IdempotencyKeyPath, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetIdempotencyKeyRequest(c.Server, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResetRateLimits(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResetRateLimitsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRateLimits(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRateLimitsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateRateLimitsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateRateLimitsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateRateLimits(ctx context.Context, body UpdateRateLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateRateLimitsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAuthorizationWithBody(ctx context.Context, params *CreateAuthorizationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAuthorizationRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAuthorization(ctx context.Context, params *CreateAuthorizationParams, body CreateAuthorizationJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAuthorizationRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAuthorization(ctx context.Context, authorizationId AuthorizationId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAuthorizationRequest(c.Server, authorizationId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAuthorizationIncrementWithBody(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAuthorizationIncrementRequestWithBody(c.Server, authorizationId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAuthorizationIncrement(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, body CreateAuthorizationIncrementJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAuthorizationIncrementRequest(c.Server, authorizationId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAuthorizationReversalWithBody(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAuthorizationReversalRequestWithBody(c.Server, authorizationId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateAuthorizationReversal(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, body CreateAuthorizationReversalJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateAuthorizationReversalRequest(c.Server, authorizationId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateCaptureWithBody(ctx context.Context, params *CreateCaptureParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCaptureRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateCapture(ctx context.Context, params *CreateCaptureParams, body CreateCaptureJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateCaptureRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCapture(ctx context.Context, captureId CaptureId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCaptureRequest(c.Server, captureId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateRefundWithBody(ctx context.Context, params *CreateRefundParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateRefundRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateRefund(ctx context.Context, params *CreateRefundParams, body CreateRefundJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateRefundRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRefund(ctx context.Context, refundId RefundId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRefundRequest(c.Server, refundId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListTransactions(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListTransactionsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateVoidWithBody(ctx context.Context, params *CreateVoidParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateVoidRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateVoid(ctx context.Context, params *CreateVoidParams, body CreateVoidJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateVoidRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetVoid(ctx context.Context, voidId VoidId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVoidRequest(c.Server, voidId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewResetChaosSettingsRequest generates requests for ResetChaosSettings
+func NewResetChaosSettingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/chaos")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetChaosSettingsRequest generates requests for GetChaosSettings
+func NewGetChaosSettingsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/chaos")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateChaosSettingsRequest calls the generic UpdateChaosSettings builder with application/json body
+func NewUpdateChaosSettingsRequest(server string, body UpdateChaosSettingsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateChaosSettingsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUpdateChaosSettingsRequestWithBody generates requests for UpdateChaosSettings with any type of body
+func NewUpdateChaosSettingsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/chaos")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteIdempotencyKeyRequest generates requests for DeleteIdempotencyKey
+func NewDeleteIdempotencyKeyRequest(server string, idempotencyKey IdempotencyKeyPath, params *DeleteIdempotencyKeyParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "idempotencyKey", runtime.ParamLocationPath, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/idempotency-keys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "request_path", runtime.ParamLocationQuery, params.RequestPath); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetIdempotencyKeyRequest generates requests for GetIdempotencyKey
+func NewGetIdempotencyKeyRequest(server string, idempotencyKey IdempotencyKeyPath) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "idempotencyKey", runtime.ParamLocationPath, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/idempotency-keys/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResetRateLimitsRequest generates requests for ResetRateLimits
+func NewResetRateLimitsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/rate-limits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRateLimitsRequest generates requests for GetRateLimits
+func NewGetRateLimitsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/rate-limits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUpdateRateLimitsRequest calls the generic UpdateRateLimits builder with application/json body
+func NewUpdateRateLimitsRequest(server string, body UpdateRateLimitsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateRateLimitsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewUpdateRateLimitsRequestWithBody generates requests for UpdateRateLimits with any type of body
+func NewUpdateRateLimitsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/rate-limits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCreateAuthorizationRequest calls the generic CreateAuthorization builder with application/json body
+func NewCreateAuthorizationRequest(server string, params *CreateAuthorizationParams, body CreateAuthorizationJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAuthorizationRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateAuthorizationRequestWithBody generates requests for CreateAuthorization with any type of body
+func NewCreateAuthorizationRequestWithBody(server string, params *CreateAuthorizationParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/authorizations")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Idempotency-Key", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetAuthorizationRequest generates requests for GetAuthorization
+func NewGetAuthorizationRequest(server string, authorizationId AuthorizationId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "authorizationId", runtime.ParamLocationPath, authorizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/authorizations/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateAuthorizationIncrementRequest calls the generic CreateAuthorizationIncrement builder with application/json body
+func NewCreateAuthorizationIncrementRequest(server string, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, body CreateAuthorizationIncrementJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAuthorizationIncrementRequestWithBody(server, authorizationId, params, "application/json", bodyReader)
+}
+
+// NewCreateAuthorizationIncrementRequestWithBody generates requests for CreateAuthorizationIncrement with any type of body
+func NewCreateAuthorizationIncrementRequestWithBody(server string, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "authorizationId", runtime.ParamLocationPath, authorizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/authorizations/%s/increments", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Idempotency-Key", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewCreateAuthorizationReversalRequest calls the generic CreateAuthorizationReversal builder with application/json body
+func NewCreateAuthorizationReversalRequest(server string, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, body CreateAuthorizationReversalJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateAuthorizationReversalRequestWithBody(server, authorizationId, params, "application/json", bodyReader)
+}
+
+// NewCreateAuthorizationReversalRequestWithBody generates requests for CreateAuthorizationReversal with any type of body
+func NewCreateAuthorizationReversalRequestWithBody(server string, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "authorizationId", runtime.ParamLocationPath, authorizationId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/authorizations/%s/reversals", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Idempotency-Key", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewCreateCaptureRequest calls the generic CreateCapture builder with application/json body
+func NewCreateCaptureRequest(server string, params *CreateCaptureParams, body CreateCaptureJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateCaptureRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateCaptureRequestWithBody generates requests for CreateCapture with any type of body
+func NewCreateCaptureRequestWithBody(server string, params *CreateCaptureParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/captures")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Idempotency-Key", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetCaptureRequest generates requests for GetCapture
+func NewGetCaptureRequest(server string, captureId CaptureId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "captureId", runtime.ParamLocationPath, captureId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/captures/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateRefundRequest calls the generic CreateRefund builder with application/json body
+func NewCreateRefundRequest(server string, params *CreateRefundParams, body CreateRefundJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateRefundRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateRefundRequestWithBody generates requests for CreateRefund with any type of body
+func NewCreateRefundRequestWithBody(server string, params *CreateRefundParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/refunds")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Idempotency-Key", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetRefundRequest generates requests for GetRefund
+func NewGetRefundRequest(server string, refundId RefundId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "refundId", runtime.ParamLocationPath, refundId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/refunds/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListTransactionsRequest generates requests for ListTransactions
+func NewListTransactionsRequest(server string, params *ListTransactionsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/transactions")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "account_number", runtime.ParamLocationQuery, params.AccountNumber); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, params.Type); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, params.Status); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "reference_id", runtime.ParamLocationQuery, params.ReferenceId); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "metadata_key", runtime.ParamLocationQuery, params.MetadataKey); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "metadata_value", runtime.ParamLocationQuery, params.MetadataValue); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_from", runtime.ParamLocationQuery, params.CreatedFrom); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "created_to", runtime.ParamLocationQuery, params.CreatedTo); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, params.Cursor); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, params.Limit); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateVoidRequest calls the generic CreateVoid builder with application/json body
+func NewCreateVoidRequest(server string, params *CreateVoidParams, body CreateVoidJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateVoidRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewCreateVoidRequestWithBody generates requests for CreateVoid with any type of body
+func NewCreateVoidRequestWithBody(server string, params *CreateVoidParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/voids")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		var headerParam0 string
+
+		headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, params.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Idempotency-Key", headerParam0)
+
+	}
+
+	return req, nil
+}
+
+// NewGetVoidRequest generates requests for GetVoid
+func NewGetVoidRequest(server string, voidId VoidId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "voidId", runtime.ParamLocationPath, voidId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/voids/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ResetChaosSettingsWithResponse request
+	ResetChaosSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResetChaosSettingsResponse, error)
+
+	// GetChaosSettingsWithResponse request
+	GetChaosSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetChaosSettingsResponse, error)
+
+	// UpdateChaosSettingsWithBodyWithResponse request with any body
+	UpdateChaosSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChaosSettingsResponse, error)
+
+	UpdateChaosSettingsWithResponse(ctx context.Context, body UpdateChaosSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChaosSettingsResponse, error)
+
+	// DeleteIdempotencyKeyWithResponse request
+	DeleteIdempotencyKeyWithResponse(ctx context.Context, idempotencyKey IdempotencyKeyPath, params *DeleteIdempotencyKeyParams, reqEditors ...RequestEditorFn) (*DeleteIdempotencyKeyResponse, error)
+
+	// GetIdempotencyKeyWithResponse request
+	GetIdempotencyKeyWithResponse(ctx context.Context, idempotencyKey IdempotencyKeyPath, reqEditors ...RequestEditorFn) (*GetIdempotencyKeyResponse, error)
+
+	// ResetRateLimitsWithResponse request
+	ResetRateLimitsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResetRateLimitsResponse, error)
+
+	// GetRateLimitsWithResponse request
+	GetRateLimitsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRateLimitsResponse, error)
+
+	// UpdateRateLimitsWithBodyWithResponse request with any body
+	UpdateRateLimitsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateRateLimitsResponse, error)
+
+	UpdateRateLimitsWithResponse(ctx context.Context, body UpdateRateLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateRateLimitsResponse, error)
+
+	// CreateAuthorizationWithBodyWithResponse request with any body
+	CreateAuthorizationWithBodyWithResponse(ctx context.Context, params *CreateAuthorizationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAuthorizationResponse, error)
+
+	CreateAuthorizationWithResponse(ctx context.Context, params *CreateAuthorizationParams, body CreateAuthorizationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAuthorizationResponse, error)
+
+	// GetAuthorizationWithResponse request
+	GetAuthorizationWithResponse(ctx context.Context, authorizationId AuthorizationId, reqEditors ...RequestEditorFn) (*GetAuthorizationResponse, error)
+
+	// CreateAuthorizationIncrementWithBodyWithResponse request with any body
+	CreateAuthorizationIncrementWithBodyWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAuthorizationIncrementResponse, error)
+
+	CreateAuthorizationIncrementWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, body CreateAuthorizationIncrementJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAuthorizationIncrementResponse, error)
+
+	// CreateAuthorizationReversalWithBodyWithResponse request with any body
+	CreateAuthorizationReversalWithBodyWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAuthorizationReversalResponse, error)
+
+	CreateAuthorizationReversalWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, body CreateAuthorizationReversalJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAuthorizationReversalResponse, error)
+
+	// CreateCaptureWithBodyWithResponse request with any body
+	CreateCaptureWithBodyWithResponse(ctx context.Context, params *CreateCaptureParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCaptureResponse, error)
+
+	CreateCaptureWithResponse(ctx context.Context, params *CreateCaptureParams, body CreateCaptureJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCaptureResponse, error)
+
+	// GetCaptureWithResponse request
+	GetCaptureWithResponse(ctx context.Context, captureId CaptureId, reqEditors ...RequestEditorFn) (*GetCaptureResponse, error)
+
+	// CreateRefundWithBodyWithResponse request with any body
+	CreateRefundWithBodyWithResponse(ctx context.Context, params *CreateRefundParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateRefundResponse, error)
+
+	CreateRefundWithResponse(ctx context.Context, params *CreateRefundParams, body CreateRefundJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateRefundResponse, error)
+
+	// GetRefundWithResponse request
+	GetRefundWithResponse(ctx context.Context, refundId RefundId, reqEditors ...RequestEditorFn) (*GetRefundResponse, error)
+
+	// ListTransactionsWithResponse request
+	ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error)
+
+	// CreateVoidWithBodyWithResponse request with any body
+	CreateVoidWithBodyWithResponse(ctx context.Context, params *CreateVoidParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateVoidResponse, error)
+
+	CreateVoidWithResponse(ctx context.Context, params *CreateVoidParams, body CreateVoidJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateVoidResponse, error)
+
+	// GetVoidWithResponse request
+	GetVoidWithResponse(ctx context.Context, voidId VoidId, reqEditors ...RequestEditorFn) (*GetVoidResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+}
+
+type ResetChaosSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChaosSettings
+}
+
+// Status returns HTTPResponse.Status
+func (r ResetChaosSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResetChaosSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetChaosSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChaosSettings
+}
+
+// Status returns HTTPResponse.Status
+func (r GetChaosSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetChaosSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateChaosSettingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ChaosSettings
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateChaosSettingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateChaosSettingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteIdempotencyKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteIdempotencyKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteIdempotencyKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetIdempotencyKeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IdempotencyKeyListResponse
+	JSON404      *NotFound
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetIdempotencyKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetIdempotencyKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResetRateLimitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RateLimitSettings
+}
+
+// Status returns HTTPResponse.Status
+func (r ResetRateLimitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResetRateLimitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRateLimitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RateLimitSettings
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRateLimitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRateLimitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateRateLimitsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RateLimitSettings
+	JSON400      *BadRequest
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateRateLimitsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateRateLimitsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAuthorizationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthorizationResponse
+	JSON400      *BadRequest
+	JSON402      *PaymentRequired
+	JSON409      *RequestInProgress
+	JSON422      *IdempotencyKeyReused
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAuthorizationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAuthorizationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAuthorizationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AuthorizationResponse
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAuthorizationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAuthorizationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAuthorizationIncrementResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *IncrementResponse
+	JSON400      *BadRequest
+	JSON402      *PaymentRequired
+	JSON409      *RequestInProgress
+	JSON422      *IdempotencyKeyReused
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAuthorizationIncrementResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAuthorizationIncrementResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateAuthorizationReversalResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReversalResponse
+	JSON400      *BadRequest
+	JSON409      *RequestInProgress
+	JSON422      *IdempotencyKeyReused
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateAuthorizationReversalResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateAuthorizationReversalResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateCaptureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CaptureResponse
+	JSON400      *BadRequest
+	JSON409      *RequestInProgress
+	JSON422      *IdempotencyKeyReused
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateCaptureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateCaptureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCaptureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CaptureResponse
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCaptureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCaptureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateRefundResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RefundResponse
+	JSON400      *BadRequest
+	JSON409      *RequestInProgress
+	JSON422      *IdempotencyKeyReused
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateRefundResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateRefundResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRefundResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RefundResponse
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRefundResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRefundResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListTransactionsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TransactionListResponse
+	JSON400      *BadRequest
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTransactionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTransactionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateVoidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VoidResponse
+	JSON400      *BadRequest
+	JSON409      *RequestInProgress
+	JSON422      *IdempotencyKeyReused
+	JSON429      *TooManyRequests
+	JSON500      *InternalError
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateVoidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateVoidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVoidResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VoidResponse
+	JSON404      *NotFound
+	JSON429      *TooManyRequests
+	JSON503      *Maintenance
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVoidResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVoidResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthResponse
+	JSON503      *HealthResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ResetChaosSettingsWithResponse request returning *ResetChaosSettingsResponse
+func (c *ClientWithResponses) ResetChaosSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResetChaosSettingsResponse, error) {
+	rsp, err := c.ResetChaosSettings(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResetChaosSettingsResponse(rsp)
+}
+
+// GetChaosSettingsWithResponse request returning *GetChaosSettingsResponse
+func (c *ClientWithResponses) GetChaosSettingsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetChaosSettingsResponse, error) {
+	rsp, err := c.GetChaosSettings(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetChaosSettingsResponse(rsp)
+}
+
+// UpdateChaosSettingsWithBodyWithResponse request with arbitrary body returning *UpdateChaosSettingsResponse
+func (c *ClientWithResponses) UpdateChaosSettingsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateChaosSettingsResponse, error) {
+	rsp, err := c.UpdateChaosSettingsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChaosSettingsResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateChaosSettingsWithResponse(ctx context.Context, body UpdateChaosSettingsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateChaosSettingsResponse, error) {
+	rsp, err := c.UpdateChaosSettings(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateChaosSettingsResponse(rsp)
+}
+
+// DeleteIdempotencyKeyWithResponse request returning *DeleteIdempotencyKeyResponse
+func (c *ClientWithResponses) DeleteIdempotencyKeyWithResponse(ctx context.Context, idempotencyKey IdempotencyKeyPath, params *DeleteIdempotencyKeyParams, reqEditors ...RequestEditorFn) (*DeleteIdempotencyKeyResponse, error) {
+	rsp, err := c.DeleteIdempotencyKey(ctx, idempotencyKey, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteIdempotencyKeyResponse(rsp)
+}
+
+// GetIdempotencyKeyWithResponse request returning *GetIdempotencyKeyResponse
+func (c *ClientWithResponses) GetIdempotencyKeyWithResponse(ctx context.Context, idempotencyKey IdempotencyKeyPath, reqEditors ...RequestEditorFn) (*GetIdempotencyKeyResponse, error) {
+	rsp, err := c.GetIdempotencyKey(ctx, idempotencyKey, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetIdempotencyKeyResponse(rsp)
+}
+
+// ResetRateLimitsWithResponse request returning *ResetRateLimitsResponse
+func (c *ClientWithResponses) ResetRateLimitsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ResetRateLimitsResponse, error) {
+	rsp, err := c.ResetRateLimits(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResetRateLimitsResponse(rsp)
+}
+
+// GetRateLimitsWithResponse request returning *GetRateLimitsResponse
+func (c *ClientWithResponses) GetRateLimitsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRateLimitsResponse, error) {
+	rsp, err := c.GetRateLimits(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRateLimitsResponse(rsp)
+}
+
+// UpdateRateLimitsWithBodyWithResponse request with arbitrary body returning *UpdateRateLimitsResponse
+func (c *ClientWithResponses) UpdateRateLimitsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateRateLimitsResponse, error) {
+	rsp, err := c.UpdateRateLimitsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateRateLimitsResponse(rsp)
+}
+
+func (c *ClientWithResponses) UpdateRateLimitsWithResponse(ctx context.Context, body UpdateRateLimitsJSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateRateLimitsResponse, error) {
+	rsp, err := c.UpdateRateLimits(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateRateLimitsResponse(rsp)
+}
+
+// CreateAuthorizationWithBodyWithResponse request with arbitrary body returning *CreateAuthorizationResponse
+func (c *ClientWithResponses) CreateAuthorizationWithBodyWithResponse(ctx context.Context, params *CreateAuthorizationParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAuthorizationResponse, error) {
+	rsp, err := c.CreateAuthorizationWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAuthorizationResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAuthorizationWithResponse(ctx context.Context, params *CreateAuthorizationParams, body CreateAuthorizationJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAuthorizationResponse, error) {
+	rsp, err := c.CreateAuthorization(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAuthorizationResponse(rsp)
+}
+
+// GetAuthorizationWithResponse request returning *GetAuthorizationResponse
+func (c *ClientWithResponses) GetAuthorizationWithResponse(ctx context.Context, authorizationId AuthorizationId, reqEditors ...RequestEditorFn) (*GetAuthorizationResponse, error) {
+	rsp, err := c.GetAuthorization(ctx, authorizationId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAuthorizationResponse(rsp)
+}
+
+// CreateAuthorizationIncrementWithBodyWithResponse request with arbitrary body returning *CreateAuthorizationIncrementResponse
+func (c *ClientWithResponses) CreateAuthorizationIncrementWithBodyWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAuthorizationIncrementResponse, error) {
+	rsp, err := c.CreateAuthorizationIncrementWithBody(ctx, authorizationId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAuthorizationIncrementResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAuthorizationIncrementWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationIncrementParams, body CreateAuthorizationIncrementJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAuthorizationIncrementResponse, error) {
+	rsp, err := c.CreateAuthorizationIncrement(ctx, authorizationId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAuthorizationIncrementResponse(rsp)
+}
+
+// CreateAuthorizationReversalWithBodyWithResponse request with arbitrary body returning *CreateAuthorizationReversalResponse
+func (c *ClientWithResponses) CreateAuthorizationReversalWithBodyWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateAuthorizationReversalResponse, error) {
+	rsp, err := c.CreateAuthorizationReversalWithBody(ctx, authorizationId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAuthorizationReversalResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateAuthorizationReversalWithResponse(ctx context.Context, authorizationId AuthorizationId, params *CreateAuthorizationReversalParams, body CreateAuthorizationReversalJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateAuthorizationReversalResponse, error) {
+	rsp, err := c.CreateAuthorizationReversal(ctx, authorizationId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateAuthorizationReversalResponse(rsp)
+}
+
+// CreateCaptureWithBodyWithResponse request with arbitrary body returning *CreateCaptureResponse
+func (c *ClientWithResponses) CreateCaptureWithBodyWithResponse(ctx context.Context, params *CreateCaptureParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateCaptureResponse, error) {
+	rsp, err := c.CreateCaptureWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCaptureResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateCaptureWithResponse(ctx context.Context, params *CreateCaptureParams, body CreateCaptureJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateCaptureResponse, error) {
+	rsp, err := c.CreateCapture(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateCaptureResponse(rsp)
+}
+
+// GetCaptureWithResponse request returning *GetCaptureResponse
+func (c *ClientWithResponses) GetCaptureWithResponse(ctx context.Context, captureId CaptureId, reqEditors ...RequestEditorFn) (*GetCaptureResponse, error) {
+	rsp, err := c.GetCapture(ctx, captureId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCaptureResponse(rsp)
+}
+
+// CreateRefundWithBodyWithResponse request with arbitrary body returning *CreateRefundResponse
+func (c *ClientWithResponses) CreateRefundWithBodyWithResponse(ctx context.Context, params *CreateRefundParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateRefundResponse, error) {
+	rsp, err := c.CreateRefundWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateRefundResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateRefundWithResponse(ctx context.Context, params *CreateRefundParams, body CreateRefundJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateRefundResponse, error) {
+	rsp, err := c.CreateRefund(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateRefundResponse(rsp)
+}
+
+// GetRefundWithResponse request returning *GetRefundResponse
+func (c *ClientWithResponses) GetRefundWithResponse(ctx context.Context, refundId RefundId, reqEditors ...RequestEditorFn) (*GetRefundResponse, error) {
+	rsp, err := c.GetRefund(ctx, refundId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRefundResponse(rsp)
+}
+
+// ListTransactionsWithResponse request returning *ListTransactionsResponse
+func (c *ClientWithResponses) ListTransactionsWithResponse(ctx context.Context, params *ListTransactionsParams, reqEditors ...RequestEditorFn) (*ListTransactionsResponse, error) {
+	rsp, err := c.ListTransactions(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListTransactionsResponse(rsp)
+}
+
+// CreateVoidWithBodyWithResponse request with arbitrary body returning *CreateVoidResponse
+func (c *ClientWithResponses) CreateVoidWithBodyWithResponse(ctx context.Context, params *CreateVoidParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateVoidResponse, error) {
+	rsp, err := c.CreateVoidWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateVoidResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateVoidWithResponse(ctx context.Context, params *CreateVoidParams, body CreateVoidJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateVoidResponse, error) {
+	rsp, err := c.CreateVoid(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateVoidResponse(rsp)
+}
+
+// GetVoidWithResponse request returning *GetVoidResponse
+func (c *ClientWithResponses) GetVoidWithResponse(ctx context.Context, voidId VoidId, reqEditors ...RequestEditorFn) (*GetVoidResponse, error) {
+	rsp, err := c.GetVoid(ctx, voidId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVoidResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// ParseResetChaosSettingsResponse parses an HTTP response from a ResetChaosSettingsWithResponse call
+func ParseResetChaosSettingsResponse(rsp *http.Response) (*ResetChaosSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResetChaosSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChaosSettings
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetChaosSettingsResponse parses an HTTP response from a GetChaosSettingsWithResponse call
+func ParseGetChaosSettingsResponse(rsp *http.Response) (*GetChaosSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetChaosSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChaosSettings
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateChaosSettingsResponse parses an HTTP response from a UpdateChaosSettingsWithResponse call
+func ParseUpdateChaosSettingsResponse(rsp *http.Response) (*UpdateChaosSettingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateChaosSettingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ChaosSettings
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteIdempotencyKeyResponse parses an HTTP response from a DeleteIdempotencyKeyWithResponse call
+func ParseDeleteIdempotencyKeyResponse(rsp *http.Response) (*DeleteIdempotencyKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteIdempotencyKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetIdempotencyKeyResponse parses an HTTP response from a GetIdempotencyKeyWithResponse call
+func ParseGetIdempotencyKeyResponse(rsp *http.Response) (*GetIdempotencyKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetIdempotencyKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IdempotencyKeyListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResetRateLimitsResponse parses an HTTP response from a ResetRateLimitsWithResponse call
+func ParseResetRateLimitsResponse(rsp *http.Response) (*ResetRateLimitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResetRateLimitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RateLimitSettings
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRateLimitsResponse parses an HTTP response from a GetRateLimitsWithResponse call
+func ParseGetRateLimitsResponse(rsp *http.Response) (*GetRateLimitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRateLimitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RateLimitSettings
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUpdateRateLimitsResponse parses an HTTP response from a UpdateRateLimitsWithResponse call
+func ParseUpdateRateLimitsResponse(rsp *http.Response) (*UpdateRateLimitsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateRateLimitsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RateLimitSettings
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAuthorizationResponse parses an HTTP response from a CreateAuthorizationWithResponse call
+func ParseCreateAuthorizationResponse(rsp *http.Response) (*CreateAuthorizationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAuthorizationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthorizationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 402:
+		var dest PaymentRequired
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON402 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest RequestInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAuthorizationResponse parses an HTTP response from a GetAuthorizationWithResponse call
+func ParseGetAuthorizationResponse(rsp *http.Response) (*GetAuthorizationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAuthorizationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AuthorizationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAuthorizationIncrementResponse parses an HTTP response from a CreateAuthorizationIncrementWithResponse call
+func ParseCreateAuthorizationIncrementResponse(rsp *http.Response) (*CreateAuthorizationIncrementResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAuthorizationIncrementResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest IncrementResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 402:
+		var dest PaymentRequired
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON402 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest RequestInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateAuthorizationReversalResponse parses an HTTP response from a CreateAuthorizationReversalWithResponse call
+func ParseCreateAuthorizationReversalResponse(rsp *http.Response) (*CreateAuthorizationReversalResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateAuthorizationReversalResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReversalResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest RequestInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateCaptureResponse parses an HTTP response from a CreateCaptureWithResponse call
+func ParseCreateCaptureResponse(rsp *http.Response) (*CreateCaptureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateCaptureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CaptureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest RequestInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCaptureResponse parses an HTTP response from a GetCaptureWithResponse call
+func ParseGetCaptureResponse(rsp *http.Response) (*GetCaptureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCaptureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CaptureResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateRefundResponse parses an HTTP response from a CreateRefundWithResponse call
+func ParseCreateRefundResponse(rsp *http.Response) (*CreateRefundResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateRefundResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RefundResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest RequestInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRefundResponse parses an HTTP response from a GetRefundWithResponse call
+func ParseGetRefundResponse(rsp *http.Response) (*GetRefundResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRefundResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RefundResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListTransactionsResponse parses an HTTP response from a ListTransactionsWithResponse call
+func ParseListTransactionsResponse(rsp *http.Response) (*ListTransactionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListTransactionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TransactionListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateVoidResponse parses an HTTP response from a CreateVoidWithResponse call
+func ParseCreateVoidResponse(rsp *http.Response) (*CreateVoidResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateVoidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VoidResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest RequestInProgress
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest IdempotencyKeyReused
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetVoidResponse parses an HTTP response from a GetVoidWithResponse call
+func ParseGetVoidResponse(rsp *http.Response) (*GetVoidResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetVoidResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VoidResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest NotFound
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest TooManyRequests
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest Maintenance
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest HealthResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,359 @@
+// Package bankclient is a Go client for the mock bank API.
+//
+// It wraps the client generated from api/openapi.yaml (package bankclient/api) with
+// the handling every integration needs:
+//
+//   - each write operation gets an Idempotency-Key, reused by all of its retries
+//   - 5xx responses, timeouts, 409 request_in_progress and 429 rate_limited are retried
+//     with exponential backoff and jitter, or after the Retry-After the bank sent; other
+//     4xx responses are final and are never retried
+//   - error responses are returned as *Error, which matches the Err* values with errors.Is
+//   - responses replayed from an earlier request with the same key are flagged
+//
+// A minimal call:
+//
+//	client, err := bankclient.New("http://localhost:8787")
+//	auth, err := client.Authorize(ctx, api.CreateAuthorizationRequest{...})
+//	if errors.Is(err, bankclient.ErrInsufficientFunds) { ... }
+package bankclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/bankclient/api"
+	"github.com/google/uuid"
+)
+
+// ReplayedHeader is set by the bank when it returns the stored response of an earlier
+// request with the same Idempotency-Key
+const ReplayedHeader = "X-Idempotent-Replayed"
+
+// Default retry settings
+const (
+	DefaultMaxAttempts = 4
+	DefaultBaseDelay   = 100 * time.Millisecond
+	DefaultMaxDelay    = 2 * time.Second
+)
+
+// Client calls the bank API. It is safe for concurrent use.
+type Client struct {
+	api            *api.Client
+	newKey         func() string
+	baseDelay      time.Duration
+	maxDelay       time.Duration
+	attemptTimeout time.Duration
+	maxAttempts    int
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with httpClient instead of http.DefaultClient
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.api.Client = httpClient
+	}
+}
+
+// WithRetry sets how many times a request is attempted in total and the bounds of the
+// backoff between attempts. maxAttempts of 1 disables retries.
+func WithRetry(maxAttempts int, baseDelay, maxDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = max(maxAttempts, 1)
+		c.baseDelay = baseDelay
+		c.maxDelay = maxDelay
+	}
+}
+
+// WithAttemptTimeout bounds each attempt, so a hung request is abandoned and retried
+// instead of using up the caller's whole deadline. Zero, the default, means no bound.
+func WithAttemptTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.attemptTimeout = timeout
+	}
+}
+
+// WithIdempotencyKeyFunc sets how keys are generated for operations called without
+// WithIdempotencyKey. The default is a random UUID.
+func WithIdempotencyKeyFunc(newKey func() string) Option {
+	return func(c *Client) {
+		c.newKey = newKey
+	}
+}
+
+// New returns a client for the bank at baseURL, e.g. http://localhost:8787
+func New(baseURL string, opts ...Option) (*Client, error) {
+	apiClient, err := api.NewClient(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("bankclient: %w", err)
+	}
+
+	c := &Client{
+		api:         apiClient,
+		newKey:      uuid.NewString,
+		maxAttempts: DefaultMaxAttempts,
+		baseDelay:   DefaultBaseDelay,
+		maxDelay:    DefaultMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// Result is a successful response from the bank
+type Result[T any] struct {
+	Data T
+	// IdempotencyKey is the key the operation was sent with; empty for reads
+	IdempotencyKey string
+	// Attempts is how many requests were sent, including the successful one
+	Attempts int
+	// Replayed is true when the bank returned the stored response of an earlier request
+	// with the same key instead of running the operation again
+	Replayed bool
+}
+
+// CallOption configures a single operation
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	idempotencyKey string
+}
+
+// WithIdempotencyKey sends the operation with key instead of a generated one. Use it
+// to resume an operation whose outcome is unknown, e.g. after a crash, with the key
+// it was first sent with.
+func WithIdempotencyKey(key string) CallOption {
+	return func(o *callOptions) {
+		o.idempotencyKey = key
+	}
+}
+
+// Authorize places a hold on a card
+func (c *Client) Authorize(ctx context.Context, req api.CreateAuthorizationRequest, opts ...CallOption) (*Result[api.AuthorizationResponse], error) {
+	key := c.idempotencyKey(opts)
+	return do[api.AuthorizationResponse](ctx, c, key, func(ctx context.Context) (*http.Response, error) {
+		return c.api.CreateAuthorization(ctx, &api.CreateAuthorizationParams{IdempotencyKey: key}, req)
+	})
+}
+
+// GetAuthorization returns an authorization and its current state. An unknown ID is ErrNotFound.
+func (c *Client) GetAuthorization(ctx context.Context, authorizationID string) (*Result[api.AuthorizationResponse], error) {
+	return do[api.AuthorizationResponse](ctx, c, "", func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetAuthorization(ctx, authorizationID)
+	})
+}
+
+// IncrementAuthorization raises the amount held by an authorization
+func (c *Client) IncrementAuthorization(ctx context.Context, authorizationID string, req api.CreateIncrementRequest, opts ...CallOption) (*Result[api.IncrementResponse], error) {
+	key := c.idempotencyKey(opts)
+	return do[api.IncrementResponse](ctx, c, key, func(ctx context.Context) (*http.Response, error) {
+		return c.api.CreateAuthorizationIncrement(ctx, authorizationID, &api.CreateAuthorizationIncrementParams{IdempotencyKey: key}, req)
+	})
+}
+
+// ReverseAuthorization releases part of the amount held by an authorization
+func (c *Client) ReverseAuthorization(ctx context.Context, authorizationID string, req api.CreateReversalRequest, opts ...CallOption) (*Result[api.ReversalResponse], error) {
+	key := c.idempotencyKey(opts)
+	return do[api.ReversalResponse](ctx, c, key, func(ctx context.Context) (*http.Response, error) {
+		return c.api.CreateAuthorizationReversal(ctx, authorizationID, &api.CreateAuthorizationReversalParams{IdempotencyKey: key}, req)
+	})
+}
+
+// Capture moves funds held by an authorization
+func (c *Client) Capture(ctx context.Context, req api.CreateCaptureRequest, opts ...CallOption) (*Result[api.CaptureResponse], error) {
+	key := c.idempotencyKey(opts)
+	return do[api.CaptureResponse](ctx, c, key, func(ctx context.Context) (*http.Response, error) {
+		return c.api.CreateCapture(ctx, &api.CreateCaptureParams{IdempotencyKey: key}, req)
+	})
+}
+
+// GetCapture returns a capture. An unknown ID is ErrNotFound.
+func (c *Client) GetCapture(ctx context.Context, captureID string) (*Result[api.CaptureResponse], error) {
+	return do[api.CaptureResponse](ctx, c, "", func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetCapture(ctx, captureID)
+	})
+}
+
+// Void releases an authorization hold
+func (c *Client) Void(ctx context.Context, req api.CreateVoidRequest, opts ...CallOption) (*Result[api.VoidResponse], error) {
+	key := c.idempotencyKey(opts)
+	return do[api.VoidResponse](ctx, c, key, func(ctx context.Context) (*http.Response, error) {
+		return c.api.CreateVoid(ctx, &api.CreateVoidParams{IdempotencyKey: key}, req)
+	})
+}
+
+// GetVoid returns a void. An unknown ID is ErrNotFound.
+func (c *Client) GetVoid(ctx context.Context, voidID string) (*Result[api.VoidResponse], error) {
+	return do[api.VoidResponse](ctx, c, "", func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetVoid(ctx, voidID)
+	})
+}
+
+// Refund returns captured funds to the card
+func (c *Client) Refund(ctx context.Context, req api.CreateRefundRequest, opts ...CallOption) (*Result[api.RefundResponse], error) {
+	key := c.idempotencyKey(opts)
+	return do[api.RefundResponse](ctx, c, key, func(ctx context.Context) (*http.Response, error) {
+		return c.api.CreateRefund(ctx, &api.CreateRefundParams{IdempotencyKey: key}, req)
+	})
+}
+
+// GetRefund returns a refund. An unknown ID is ErrNotFound.
+func (c *Client) GetRefund(ctx context.Context, refundID string) (*Result[api.RefundResponse], error) {
+	return do[api.RefundResponse](ctx, c, "", func(ctx context.Context) (*http.Response, error) {
+		return c.api.GetRefund(ctx, refundID)
+	})
+}
+
+// ListTransactions returns a page of transactions matching params
+func (c *Client) ListTransactions(ctx context.Context, params api.ListTransactionsParams) (*Result[api.TransactionListResponse], error) {
+	return do[api.TransactionListResponse](ctx, c, "", func(ctx context.Context) (*http.Response, error) {
+		return c.api.ListTransactions(ctx, &params)
+	})
+}
+
+func (c *Client) idempotencyKey(opts []CallOption) string {
+	o := callOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.idempotencyKey != "" {
+		return o.idempotencyKey
+	}
+	return c.newKey()
+}
+
+// do sends a request until it gets a final answer or runs out of attempts. Every
+// attempt of a write carries the same Idempotency-Key, so the bank applies it at most once.
+// Errors are retried when they are Retryable, waiting for Retry-After when the bank sent one.
+func do[T any](ctx context.Context, c *Client, key string, send func(ctx context.Context) (*http.Response, error)) (*Result[T], error) {
+	for attempt := 1; ; attempt++ {
+		status, header, body, err := c.attempt(ctx, send)
+		last := attempt >= c.maxAttempts
+		delay := c.backoff(attempt)
+
+		if err != nil {
+			if last || !isTimeout(ctx, err) {
+				return nil, fmt.Errorf("bankclient: request failed after %d attempt(s): %w", attempt, err)
+			}
+		} else {
+			replayed := header.Get(ReplayedHeader) == "true"
+			if status < http.StatusBadRequest {
+				result := &Result[T]{IdempotencyKey: key, Replayed: replayed, Attempts: attempt}
+				if err := json.Unmarshal(body, &result.Data); err != nil {
+					return nil, fmt.Errorf("bankclient: decoding %d response: %w", status, err)
+				}
+				return result, nil
+			}
+
+			bankErr := newError(status, body, key, replayed, attempt)
+			if last || !bankErr.Retryable() {
+				return nil, bankErr
+			}
+			if wait, ok := retryAfter(header); ok {
+				// Waiting past the caller's deadline would only hide the bank's answer
+				if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+					return nil, bankErr
+				}
+				delay = wait
+			}
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, fmt.Errorf("bankclient: gave up after %d attempt(s): %w", attempt, err)
+		}
+	}
+}
+
+// attempt sends one request and reads the whole response, so a body cut off by a
+// timeout counts as a failed attempt rather than a decoding error
+func (c *Client) attempt(ctx context.Context, send func(ctx context.Context) (*http.Response, error)) (int, http.Header, []byte, error) {
+	if c.attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.attemptTimeout)
+		defer cancel()
+	}
+
+	resp, err := send(ctx)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck // the body has been read
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	return resp.StatusCode, resp.Header, bytes.TrimSpace(body), nil
+}
+
+// backoff returns a random delay up to baseDelay doubled for every earlier attempt,
+// capped at maxDelay ("full jitter")
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := c.maxDelay
+	if shift := attempt - 1; shift < 32 && c.baseDelay<<shift < ceiling {
+		ceiling = c.baseDelay << shift
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	jitter, err := rand.Int(rand.Reader, big.NewInt(int64(ceiling)+1))
+	if err != nil {
+		return ceiling
+	}
+	return time.Duration(jitter.Int64())
+}
+
+// retryAfter returns the wait the bank asked for in a Retry-After header, given in
+// seconds or as an HTTP date
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+	return 0, false
+}
+
+// isTimeout reports whether err is a timeout of one attempt. Once the caller's own
+// context is done there is no point retrying.
+func isTimeout(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(err, &timeout) && timeout.Timeout()
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,247 @@
+package bankclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/bankclient/api"
+	"github.com/benx421/payment-gateway/bank/banktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedBank answers each request with the next response in the script and records
+// the Idempotency-Key it was sent with
+type scriptedBank struct {
+	script []func(w http.ResponseWriter)
+	keys   []string
+	mu     sync.Mutex
+}
+
+func (b *scriptedBank) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	respond := b.script[min(len(b.keys), len(b.script)-1)]
+	b.keys = append(b.keys, r.Header.Get("Idempotency-Key"))
+	b.mu.Unlock()
+
+	respond(w)
+}
+
+func reply(status int, body string) func(w http.ResponseWriter) {
+	return func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body)) //nolint:errcheck // test server
+	}
+}
+
+const voided = `{"void_id":"void_1","authorization_id":"auth_1","status":"voided"}`
+
+func newTestClient(t *testing.T, bank http.Handler, opts ...Option) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(bank)
+	t.Cleanup(srv.Close)
+
+	client, err := New(srv.URL, append([]Option{WithRetry(3, time.Millisecond, 5*time.Millisecond)}, opts...)...)
+	require.NoError(t, err)
+	return client
+}
+
+func TestClient_Retries(t *testing.T) {
+	tests := []struct {
+		wantErr      error
+		name         string
+		script       []func(w http.ResponseWriter)
+		wantAttempts int
+	}{
+		{
+			name:         "succeeds first time",
+			script:       []func(w http.ResponseWriter){reply(http.StatusOK, voided)},
+			wantAttempts: 1,
+		},
+		{
+			name:         "retries server errors",
+			script:       []func(w http.ResponseWriter){reply(http.StatusInternalServerError, `{"error":"internal_error","message":"boom"}`), reply(http.StatusBadGateway, "<html>bad gateway</html>"), reply(http.StatusOK, voided)},
+			wantAttempts: 3,
+		},
+		{
+			name:         "gives up after the last attempt",
+			script:       []func(w http.ResponseWriter){reply(http.StatusServiceUnavailable, "")},
+			wantAttempts: 3,
+			wantErr:      ErrInternalError,
+		},
+		{
+			name:         "retries a duplicate still in progress",
+			script:       []func(w http.ResponseWriter){reply(http.StatusConflict, `{"error":"request_in_progress","message":"still running"}`), reply(http.StatusOK, voided)},
+			wantAttempts: 2,
+		},
+		{
+			name:         "retries rate limited requests",
+			script:       []func(w http.ResponseWriter){reply(http.StatusTooManyRequests, `{"error":"rate_limited","message":"slow down"}`), reply(http.StatusOK, voided)},
+			wantAttempts: 2,
+		},
+		{
+			name:         "gives up rate limited after the last attempt",
+			script:       []func(w http.ResponseWriter){reply(http.StatusTooManyRequests, `{"error":"rate_limited","message":"slow down"}`)},
+			wantAttempts: 3,
+			wantErr:      ErrRateLimited,
+		},
+		{
+			name:         "never retries client errors",
+			script:       []func(w http.ResponseWriter){reply(http.StatusConflict, `{"error":"already_captured","message":"authorization already captured"}`)},
+			wantAttempts: 1,
+			wantErr:      ErrAlreadyCaptured,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bank := &scriptedBank{script: tt.script}
+			client := newTestClient(t, bank)
+
+			result, err := client.Void(context.Background(), api.CreateVoidRequest{AuthorizationId: "auth_1"})
+
+			require.Len(t, bank.keys, tt.wantAttempts)
+			for _, key := range bank.keys {
+				assert.Equal(t, bank.keys[0], key, "every attempt carries the same key")
+			}
+			assert.NotEmpty(t, bank.keys[0])
+
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				var bankErr *Error
+				require.ErrorAs(t, err, &bankErr)
+				assert.Equal(t, tt.wantAttempts, bankErr.Attempts)
+				assert.Equal(t, bank.keys[0], bankErr.IdempotencyKey)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "void_1", result.Data.VoidId)
+			assert.Equal(t, tt.wantAttempts, result.Attempts)
+			assert.Equal(t, bank.keys[0], result.IdempotencyKey)
+		})
+	}
+}
+
+func TestClient_RetriesTimeouts(t *testing.T) {
+	bank := &scriptedBank{script: []func(w http.ResponseWriter){
+		func(w http.ResponseWriter) { time.Sleep(100 * time.Millisecond) },
+		reply(http.StatusOK, voided),
+	}}
+	client := newTestClient(t, bank, WithAttemptTimeout(20*time.Millisecond))
+
+	result, err := client.Void(context.Background(), api.CreateVoidRequest{AuthorizationId: "auth_1"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Attempts)
+	assert.Equal(t, bank.keys[0], bank.keys[1])
+}
+
+func TestClient_HonoursRetryAfter(t *testing.T) {
+	rateLimited := func(retryAfter string) func(w http.ResponseWriter) {
+		return func(w http.ResponseWriter) {
+			w.Header().Set("Retry-After", retryAfter)
+			reply(http.StatusTooManyRequests, `{"error":"rate_limited","message":"slow down"}`)(w)
+		}
+	}
+
+	t.Run("waits before retrying", func(t *testing.T) {
+		bank := &scriptedBank{script: []func(w http.ResponseWriter){rateLimited("1"), reply(http.StatusOK, voided)}}
+		client := newTestClient(t, bank)
+
+		start := time.Now()
+		result, err := client.Void(context.Background(), api.CreateVoidRequest{AuthorizationId: "auth_1"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Attempts)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	})
+
+	t.Run("gives up when the wait passes the deadline", func(t *testing.T) {
+		bank := &scriptedBank{script: []func(w http.ResponseWriter){rateLimited("60"), reply(http.StatusOK, voided)}}
+		client := newTestClient(t, bank)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		_, err := client.Void(ctx, api.CreateVoidRequest{AuthorizationId: "auth_1"})
+		require.ErrorIs(t, err, ErrRateLimited)
+		assert.Len(t, bank.keys, 1)
+	})
+}
+
+func TestClient_StopsWhenContextIsDone(t *testing.T) {
+	bank := &scriptedBank{script: []func(w http.ResponseWriter){reply(http.StatusInternalServerError, "")}}
+	client := newTestClient(t, bank, WithRetry(10, time.Second, time.Second))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Void(ctx, api.CreateVoidRequest{AuthorizationId: "auth_1"})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, len(bank.keys), 10, "the remaining attempts are abandoned")
+}
+
+func TestClient_Backoff(t *testing.T) {
+	client, err := New("http://bank.test", WithRetry(10, 100*time.Millisecond, time.Second))
+	require.NoError(t, err)
+
+	for attempt, ceiling := range map[int]time.Duration{1: 100 * time.Millisecond, 3: 400 * time.Millisecond, 8: time.Second, 100: time.Second} {
+		for range 50 {
+			delay := client.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, time.Duration(0))
+			assert.LessOrEqual(t, delay, ceiling)
+		}
+	}
+}
+
+func TestClient_AgainstBank(t *testing.T) {
+	ctx := context.Background()
+	bank := banktest.NewServer(t)
+	client, err := New(bank.URL, WithHTTPClient(bank.Client()))
+	require.NoError(t, err)
+
+	auth, err := client.Authorize(ctx, api.CreateAuthorizationRequest{
+		CardNumber:  "4242424242424242",
+		Cvv:         "456",
+		ExpiryMonth: 6,
+		ExpiryYear:  2030,
+		Amount:      10000,
+	})
+	require.NoError(t, err)
+	assert.False(t, auth.Replayed)
+
+	capture, err := client.Capture(ctx, api.CreateCaptureRequest{AuthorizationId: auth.Data.AuthorizationId, Amount: 10000})
+	require.NoError(t, err)
+
+	replay, err := client.Capture(ctx, api.CreateCaptureRequest{AuthorizationId: auth.Data.AuthorizationId, Amount: 10000},
+		WithIdempotencyKey(capture.IdempotencyKey))
+	require.NoError(t, err)
+	assert.True(t, replay.Replayed)
+	assert.Equal(t, capture.Data.CaptureId, replay.Data.CaptureId)
+
+	_, err = client.Capture(ctx, api.CreateCaptureRequest{AuthorizationId: auth.Data.AuthorizationId, Amount: 10000})
+	assert.ErrorIs(t, err, ErrAuthorizationAlreadyUsed)
+
+	_, err = client.GetVoid(ctx, "void_00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = client.GetRefund(ctx, "ref_00000000-0000-0000-0000-000000000000")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = client.Authorize(ctx, api.CreateAuthorizationRequest{
+		CardNumber:  "4242424242424242",
+		Cvv:         "456",
+		ExpiryMonth: 6,
+		ExpiryYear:  2030,
+		Amount:      100000,
+	})
+	var bankErr *Error
+	require.True(t, errors.As(err, &bankErr))
+	assert.Equal(t, api.ErrorCodeInsufficientFunds, bankErr.Code)
+	assert.Equal(t, http.StatusPaymentRequired, bankErr.StatusCode)
+	assert.False(t, bankErr.Retryable())
+}
@@ -0,0 +1,98 @@
+package bankclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/benx421/payment-gateway/bank/bankclient/api"
+)
+
+// Error is an error response from the bank. Compare it to the Err* values with
+// errors.Is, or inspect Code directly.
+type Error struct {
+	Code    api.ErrorCode
+	Message string
+	// IdempotencyKey is the key the operation was sent with; empty for reads
+	IdempotencyKey string
+	StatusCode     int
+	// Attempts is how many requests were sent before giving up
+	Attempts int
+	// Replayed is true when this is the stored outcome of an earlier request with the same key
+	Replayed bool
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("bank returned %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Is matches errors with the same Code, so errors.Is(err, ErrInsufficientFunds) works
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Retryable reports whether the same request may succeed if sent again with the same
+// Idempotency-Key: server errors, a duplicate that is still being processed, and a
+// request refused by the rate limit. These are the errors the Client retries itself,
+// so an Error that is Retryable has already used up its attempts.
+func (e *Error) Retryable() bool {
+	return e.StatusCode >= http.StatusInternalServerError ||
+		e.Code == api.ErrorCodeRequestInProgress ||
+		e.Code == api.ErrorCodeRateLimited
+}
+
+// Errors the bank returns, for use with errors.Is
+var (
+	ErrAlreadyCaptured              = &Error{Code: api.ErrorCodeAlreadyCaptured}
+	ErrAlreadyRefunded              = &Error{Code: api.ErrorCodeAlreadyRefunded}
+	ErrAlreadyVoided                = &Error{Code: api.ErrorCodeAlreadyVoided}
+	ErrAmountMismatch               = &Error{Code: api.ErrorCodeAmountMismatch}
+	ErrAuthorizationAlreadyUsed     = &Error{Code: api.ErrorCodeAuthorizationAlreadyUsed}
+	ErrAuthorizationExpired         = &Error{Code: api.ErrorCodeAuthorizationExpired}
+	ErrAuthorizationNotFound        = &Error{Code: api.ErrorCodeAuthorizationNotFound}
+	ErrCaptureExceedsAuthorization  = &Error{Code: api.ErrorCodeCaptureExceedsAuthorization}
+	ErrCaptureNotFound              = &Error{Code: api.ErrorCodeCaptureNotFound}
+	ErrCardExpired                  = &Error{Code: api.ErrorCodeCardExpired}
+	ErrIdempotencyKeyReused         = &Error{Code: api.ErrorCodeIdempotencyKeyReused}
+	ErrInsufficientFunds            = &Error{Code: api.ErrorCodeInsufficientFunds}
+	ErrInternalError                = &Error{Code: api.ErrorCodeInternalError}
+	ErrInvalidAmount                = &Error{Code: api.ErrorCodeInvalidAmount}
+	ErrInvalidCard                  = &Error{Code: api.ErrorCodeInvalidCard}
+	ErrInvalidChaosHeader           = &Error{Code: api.ErrorCodeInvalidChaosHeader}
+	ErrInvalidCVV                   = &Error{Code: api.ErrorCodeInvalidCvv}
+	ErrInvalidExpiry                = &Error{Code: api.ErrorCodeInvalidExpiry}
+	ErrInvalidMetadata              = &Error{Code: api.ErrorCodeInvalidMetadata}
+	ErrInvalidQuery                 = &Error{Code: api.ErrorCodeInvalidQuery}
+	ErrMaintenance                  = &Error{Code: api.ErrorCodeMaintenance}
+	ErrMissingIdempotencyKey        = &Error{Code: api.ErrorCodeMissingIdempotencyKey}
+	ErrNotFound                     = &Error{Code: api.ErrorCodeNotFound}
+	ErrRateLimited                  = &Error{Code: api.ErrorCodeRateLimited}
+	ErrRefundExceedsCapture         = &Error{Code: api.ErrorCodeRefundExceedsCapture}
+	ErrRequestInProgress            = &Error{Code: api.ErrorCodeRequestInProgress}
+	ErrReversalExceedsAuthorization = &Error{Code: api.ErrorCodeReversalExceedsAuthorization}
+)
+
+// newError builds an Error from an error response. Bodies that are not an ErrorResponse,
+// e.g. from a proxy, keep the status code and are reported as internal_error for 5xx.
+func newError(status int, body []byte, key string, replayed bool, attempts int) *Error {
+	e := &Error{
+		StatusCode:     status,
+		IdempotencyKey: key,
+		Replayed:       replayed,
+		Attempts:       attempts,
+	}
+
+	var resp api.ErrorResponse
+	if err := json.Unmarshal(body, &resp); err == nil && resp.Error != "" {
+		e.Code = resp.Error
+		e.Message = resp.Message
+		return e
+	}
+
+	if status >= http.StatusInternalServerError {
+		e.Code = api.ErrorCodeInternalError
+	}
+	e.Message = http.StatusText(status)
+	return e
+}
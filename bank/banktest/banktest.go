@@ -0,0 +1,353 @@
+// Package banktest runs the mock bank inside Go tests.
+//
+// NewServer starts the real bank handlers, services and middleware on an
+// httptest.Server backed by in-memory storage, so tests need no database:
+//
+//	bank := banktest.NewServer(t, banktest.WithFailureRate(0.2))
+//	client := mygateway.NewBankClient(bank.URL)
+//
+// Chaos is off unless an option turns it on. Settings without an option keep the
+// bank's defaults; the environment is not read, so a test runs the same anywhere.
+package banktest
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/handlers"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository/memory"
+	"github.com/benx421/payment-gateway/bank/internal/service"
+)
+
+// Server is a mock bank listening on a local URL
+type Server struct {
+	*httptest.Server
+	store *memory.Store
+	clock Clock
+	cfg   *config.Config
+}
+
+// Clock tells the bank what time it is. FakeClock is an implementation tests can move.
+type Clock interface {
+	Now() time.Time
+}
+
+// Account is a card account the bank starts with
+type Account struct {
+	CardNumber   string
+	CVV          string
+	BalanceCents int64
+	ExpiryMonth  int
+	ExpiryYear   int
+}
+
+// Option configures a Server
+type Option func(*options)
+
+type options struct {
+	clock        Clock
+	faultRates   map[string]float64
+	logger       *slog.Logger
+	accounts     []Account
+	script       []FaultStep
+	outages      []config.OutageWindow
+	rateLimit    config.RateLimitConfig
+	failureRate  float64
+	postCommit   float64
+	minLatency   time.Duration
+	maxLatency   time.Duration
+	authExpiry   time.Duration
+	seed         uint64
+	degradedRate float64
+	degradedMin  time.Duration
+	degradedMax  time.Duration
+	withAccounts bool
+	mockHeaders  bool
+}
+
+// WithFailureRate makes the bank fail that fraction of requests (0 to 1) with a 500
+func WithFailureRate(rate float64) Option {
+	return func(o *options) {
+		o.failureRate = rate
+	}
+}
+
+// WithPostCommitFailureRate makes the bank run and commit that fraction of requests
+// (0 to 1) but answer them with a 500. A retry with the same Idempotency-Key gets the
+// real response.
+func WithPostCommitFailureRate(rate float64) Option {
+	return func(o *options) {
+		o.postCommit = rate
+	}
+}
+
+// Fault kinds for WithFaultRate
+const (
+	FaultHang                     = config.FaultHang
+	FaultReset                    = config.FaultReset
+	FaultTruncatedBody            = config.FaultTruncatedBody
+	FaultMalformedBody            = config.FaultMalformedBody
+	FaultBadGateway               = config.FaultBadGateway
+	FaultBadGatewayRetryAfter     = config.FaultBadGatewayRetryAfter
+	FaultUnavailable              = config.FaultUnavailable
+	FaultUnavailableRetryAfter    = config.FaultUnavailableRetryAfter
+	FaultGatewayTimeout           = config.FaultGatewayTimeout
+	FaultGatewayTimeoutRetryAfter = config.FaultGatewayTimeoutRetryAfter
+)
+
+// WithFaultRate makes that fraction of requests (0 to 1) fail with the given fault kind.
+// All failure rates together cannot exceed 1.
+func WithFaultRate(kind string, rate float64) Option {
+	return func(o *options) {
+		if o.faultRates == nil {
+			o.faultRates = map[string]float64{}
+		}
+		o.faultRates[kind] = rate
+	}
+}
+
+// WithChaosSeed seeds the random latency and faults, so a test that makes the same
+// requests in the same order always gets the same chaos
+func WithChaosSeed(seed uint64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// FaultStep is one scripted fault: the Request-th request matching Method and Path
+// (either empty to match any) gets Fault
+type FaultStep = config.FaultStep
+
+// Fault kinds that only a script can pick on their own; their rates are set with
+// WithFailureRate and WithPostCommitFailureRate
+const (
+	FaultError            = config.FaultError
+	FaultErrorAfterCommit = config.FaultErrorAfterCommit
+)
+
+// WithFaultScript replaces the random faults with a script: only the listed requests
+// fail. Failing the 3rd capture and hanging the 5th request of any kind is
+//
+//	banktest.WithFaultScript(
+//		banktest.FaultStep{Method: "POST", Path: "/api/v1/captures", Request: 3, Fault: banktest.FaultError},
+//		banktest.FaultStep{Request: 5, Fault: banktest.FaultHang},
+//	)
+func WithFaultScript(steps ...FaultStep) Option {
+	return func(o *options) {
+		o.script = append(o.script, steps...)
+	}
+}
+
+// WithChaosHeaders lets each request force its own outcome with the X-Mock-Fault
+// header (none, timeout, 500, 500-after-commit or a fault kind) and the
+// X-Mock-Latency-Ms header, without changing the server's chaos
+func WithChaosHeaders() Option {
+	return func(o *options) {
+		o.mockHeaders = true
+	}
+}
+
+// WithRateLimit lets each client, told apart by X-API-Key or else IP, send
+// requestsPerSecond requests per second with bursts of up to burst. Requests over
+// the limit get 429 with Retry-After.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(o *options) {
+		o.rateLimit.RequestsPerSecond = requestsPerSecond
+		o.rateLimit.Burst = burst
+	}
+}
+
+// WithEndpointRateLimit gives the requests matching method and path (a path.Match
+// pattern; an empty method matches any) their own limit instead of WithRateLimit's
+func WithEndpointRateLimit(method, path string, requestsPerSecond float64, burst int) Option {
+	return func(o *options) {
+		o.rateLimit.Endpoints = append(o.rateLimit.Endpoints, config.EndpointRateLimit{
+			Method:            method,
+			Path:              path,
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             burst,
+		})
+	}
+}
+
+// Outage modes for WithOutageWindow
+const (
+	OutageMaintenance = config.OutageMaintenance
+	OutageDegraded    = config.OutageDegraded
+)
+
+// WithOutageWindow puts the bank in mode for duration, starting after the server
+// starts. During maintenance every API call gets 503 with Retry-After; during a
+// degraded window requests get the chaos set with WithDegradedChaos. GET /health
+// reports either. With a FakeClock a test can step into and out of the window.
+func WithOutageWindow(mode string, after, duration time.Duration) Option {
+	return func(o *options) {
+		o.outages = append(o.outages, config.OutageWindow{Mode: mode, After: after, Duration: duration})
+	}
+}
+
+// WithDegradedChaos makes at least that fraction of requests (0 to 1) fail with a
+// 500 and delays each by at least a random duration between min and max while a
+// degraded window is in effect. Without it a degraded window only shows in GET /health.
+func WithDegradedChaos(failureRate float64, minLatency, maxLatency time.Duration) Option {
+	return func(o *options) {
+		o.degradedRate = failureRate
+		o.degradedMin = minLatency
+		o.degradedMax = maxLatency
+	}
+}
+
+// WithLatency delays every request by a random duration between min and max
+func WithLatency(minLatency, maxLatency time.Duration) Option {
+	return func(o *options) {
+		o.minLatency = minLatency
+		o.maxLatency = maxLatency
+	}
+}
+
+// WithAccounts replaces the default test cards with the given accounts
+func WithAccounts(accounts ...Account) Option {
+	return func(o *options) {
+		o.accounts = accounts
+		o.withAccounts = true
+	}
+}
+
+// WithClock makes the bank read the time from clock, e.g. a FakeClock
+func WithClock(clock Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+// WithAuthExpiry sets how long an authorization hold lasts
+func WithAuthExpiry(expiry time.Duration) Option {
+	return func(o *options) {
+		o.authExpiry = expiry
+	}
+}
+
+// WithLogger sends the bank's logs to logger. They are discarded by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		o.logger = logger
+	}
+}
+
+// DefaultAccounts returns the test cards the bank starts with unless WithAccounts is given
+func DefaultAccounts() []Account {
+	seeded := memory.TestAccounts()
+	accounts := make([]Account, len(seeded))
+	for i, account := range seeded {
+		accounts[i] = Account{
+			CardNumber:   account.AccountNumber,
+			CVV:          account.CVV,
+			BalanceCents: account.BalanceCents,
+			ExpiryMonth:  account.ExpiryMonth,
+			ExpiryYear:   account.ExpiryYear,
+		}
+	}
+	return accounts
+}
+
+// NewServer starts a mock bank and stops it when the test ends
+func NewServer(t testing.TB, opts ...Option) *Server {
+	t.Helper()
+
+	o := &options{
+		clock:  clock.System,
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if !o.withAccounts {
+		o.accounts = DefaultAccounts()
+	}
+
+	cfg, err := config.Default()
+	if err != nil {
+		t.Fatalf("banktest: %v", err)
+	}
+	cfg.Storage.Backend = config.StorageBackendMemory
+	cfg.RateLimit = o.rateLimit
+	cfg.App.FailureRate = o.failureRate
+	cfg.App.PostCommitFailureRate = o.postCommit
+	cfg.App.FaultRates = o.faultRates
+	cfg.App.ChaosSeed = o.seed
+	cfg.App.ChaosScript = o.script
+	cfg.App.ChaosHeadersEnabled = o.mockHeaders
+	cfg.App.OutageWindows = o.outages
+	cfg.App.DegradedFailureRate = o.degradedRate
+	cfg.App.DegradedMinLatencyMS = int(o.degradedMin.Milliseconds())
+	cfg.App.DegradedMaxLatencyMS = int(o.degradedMax.Milliseconds())
+	cfg.App.MinLatencyMS = int(o.minLatency.Milliseconds())
+	cfg.App.MaxLatencyMS = int(o.maxLatency.Milliseconds())
+	if o.authExpiry > 0 {
+		cfg.App.AuthExpiryDuration = o.authExpiry
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("banktest: %v", err)
+	}
+
+	seeded := make([]*models.Account, len(o.accounts))
+	for i, account := range o.accounts {
+		seeded[i] = &models.Account{
+			AccountNumber:         account.CardNumber,
+			CVV:                   account.CVV,
+			ExpiryMonth:           account.ExpiryMonth,
+			ExpiryYear:            account.ExpiryYear,
+			BalanceCents:          account.BalanceCents,
+			AvailableBalanceCents: account.BalanceCents,
+		}
+	}
+	store := memory.NewStore(seeded...)
+
+	srv := &Server{
+		Server: httptest.NewServer(handlers.NewRouter(store, o.clock, cfg, o.logger)),
+		store:  store,
+		clock:  o.clock,
+		cfg:    cfg,
+	}
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// Balance returns the ledger and available balance of a card, in cents
+func (s *Server) Balance(t testing.TB, cardNumber string) (balanceCents, availableCents int64) {
+	t.Helper()
+
+	account, err := s.store.Accounts().FindByAccountNumber(context.Background(), cardNumber)
+	if err != nil {
+		t.Fatalf("banktest: %v", err)
+	}
+	return account.BalanceCents, account.AvailableBalanceCents
+}
+
+// ExpireHolds runs the authorization expiry sweep now, as the bank binary does
+// periodically, and returns the number of holds released. Combined with a
+// FakeClock it lets a test expire authorizations without waiting.
+func (s *Server) ExpireHolds(t testing.TB) int {
+	t.Helper()
+
+	expiryService := service.NewExpiryService(s.store, s.clock, s.cfg.App.ExpirySweepBatchSize)
+	total := 0
+	for {
+		expired, err := expiryService.ExpireHolds(context.Background())
+		if err != nil {
+			t.Fatalf("banktest: %v", err)
+		}
+		total += expired
+		if expired < s.cfg.App.ExpirySweepBatchSize {
+			return total
+		}
+	}
+}
@@ -0,0 +1,312 @@
+//nolint:errcheck // unchecked errors are acceptable in test files
+package banktest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/banktest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decode(t *testing.T, resp *http.Response) map[string]any {
+	t.Helper()
+	defer resp.Body.Close()
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func TestServer_PaymentLifecycle(t *testing.T) {
+	bank := banktest.NewServer(t)
+
+	authResp := bank.Authorize(t, "4111111111111111", "123", 5000, "auth-1")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+	authID := decode(t, authResp)["authorization_id"].(string)
+
+	balance, available := bank.Balance(t, "4111111111111111")
+	assert.Equal(t, int64(1000000), balance)
+	assert.Equal(t, int64(995000), available)
+
+	captureResp := bank.Capture(t, authID, 5000, "capture-1")
+	require.Equal(t, http.StatusOK, captureResp.StatusCode)
+	captureID := decode(t, captureResp)["capture_id"].(string)
+
+	refundResp := bank.Refund(t, captureID, 5000, "refund-1")
+	require.Equal(t, http.StatusOK, refundResp.StatusCode)
+	refundResp.Body.Close()
+
+	balance, available = bank.Balance(t, "4111111111111111")
+	assert.Equal(t, int64(1000000), balance)
+	assert.Equal(t, int64(1000000), available)
+
+	replay := bank.Refund(t, captureID, 5000, "refund-1")
+	replay.Body.Close()
+	assert.Equal(t, "true", replay.Header.Get("X-Idempotent-Replayed"))
+}
+
+func TestServer_WithAccounts(t *testing.T) {
+	bank := banktest.NewServer(t, banktest.WithAccounts(banktest.Account{
+		CardNumber:   "4000000000000002",
+		CVV:          "999",
+		BalanceCents: 1000,
+		ExpiryMonth:  6,
+		ExpiryYear:   2031,
+	}))
+
+	resp := bank.Authorize(t, "4000000000000002", "999", 1000, "auth-1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	resp = bank.Authorize(t, "4000000000000002", "999", 1, "auth-2")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusPaymentRequired, resp.StatusCode)
+
+	resp = bank.Authorize(t, "4111111111111111", "123", 100, "auth-3")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode, "the default cards are replaced")
+}
+
+func TestServer_WithClock(t *testing.T) {
+	clock := banktest.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	bank := banktest.NewServer(t, banktest.WithClock(clock), banktest.WithAuthExpiry(24*time.Hour))
+
+	authResp := bank.Authorize(t, "4111111111111111", "123", 5000, "auth-1")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+	auth := decode(t, authResp)
+	assert.Equal(t, "2026-03-02T12:00:00Z", auth["expires_at"])
+
+	assert.Equal(t, 0, bank.ExpireHolds(t), "the hold has not expired yet")
+
+	clock.Advance(25 * time.Hour)
+	assert.Equal(t, 1, bank.ExpireHolds(t))
+
+	_, available := bank.Balance(t, "4111111111111111")
+	assert.Equal(t, int64(1000000), available, "the expired hold is released")
+
+	captureResp := bank.Capture(t, auth["authorization_id"].(string), 5000, "capture-1")
+	assert.Equal(t, "authorization_expired", decode(t, captureResp)["error"])
+}
+
+func TestServer_WithAuthExpiryUnderAnHour(t *testing.T) {
+	clock := banktest.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	bank := banktest.NewServer(t, banktest.WithClock(clock), banktest.WithAuthExpiry(30*time.Minute))
+
+	authResp := bank.Authorize(t, "4111111111111111", "123", 5000, "auth-1")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+	assert.Equal(t, "2026-03-01T12:30:00Z", decode(t, authResp)["expires_at"])
+
+	assert.Equal(t, 0, bank.ExpireHolds(t), "the hold has not expired yet")
+	clock.Advance(31 * time.Minute)
+	assert.Equal(t, 1, bank.ExpireHolds(t))
+}
+
+func TestServer_IgnoresEnvironment(t *testing.T) {
+	t.Setenv("IDEMPOTENCY_CACHED_ERROR_STATUSES", "")
+	t.Setenv("AUTH_EXPIRY_HOURS", "1")
+	bank := banktest.NewServer(t)
+
+	authResp := bank.Authorize(t, "4111111111111111", "123", 5000, "auth-1")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+	expiresAt, err := time.Parse(time.RFC3339, decode(t, authResp)["expires_at"].(string))
+	require.NoError(t, err)
+	assert.Greater(t, time.Until(expiresAt), 24*time.Hour, "AUTH_EXPIRY_HOURS is not read")
+
+	declined := bank.Authorize(t, "4111111111111111", "999", 5000, "auth-2")
+	declined.Body.Close()
+	require.Equal(t, http.StatusBadRequest, declined.StatusCode)
+	replayed := bank.Authorize(t, "4111111111111111", "999", 5000, "auth-2")
+	replayed.Body.Close()
+	assert.Equal(t, "true", replayed.Header.Get("X-Idempotent-Replayed"), "IDEMPOTENCY_CACHED_ERROR_STATUSES is not read")
+}
+
+func TestServer_WithFailureRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		wantStatus int
+		rate       float64
+	}{
+		{name: "no chaos by default", rate: 0, wantStatus: http.StatusOK},
+		{name: "every request fails", rate: 1, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bank := banktest.NewServer(t, banktest.WithFailureRate(tt.rate))
+
+			for i := range 5 {
+				resp := bank.Authorize(t, "4111111111111111", "123", 100, fmt.Sprintf("auth-%d", i))
+				resp.Body.Close()
+				assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestServer_WithPostCommitFailureRate(t *testing.T) {
+	bank := banktest.NewServer(t, banktest.WithPostCommitFailureRate(1))
+
+	resp := bank.Authorize(t, "4111111111111111", "123", 5000, "auth-1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	_, available := bank.Balance(t, "4111111111111111")
+	assert.Equal(t, int64(995000), available, "the authorization was committed")
+
+	retry := bank.Authorize(t, "4111111111111111", "123", 5000, "auth-1")
+	require.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("X-Idempotent-Replayed"))
+	assert.NotEmpty(t, decode(t, retry)["authorization_id"])
+
+	_, available = bank.Balance(t, "4111111111111111")
+	assert.Equal(t, int64(995000), available, "the retry did not authorize again")
+}
+
+func TestServer_WithFaultRate(t *testing.T) {
+	bank := banktest.NewServer(t, banktest.WithFaultRate(banktest.FaultUnavailableRetryAfter, 1))
+
+	resp := bank.Authorize(t, "4111111111111111", "123", 100, "auth-1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+}
+
+func TestServer_WithFaultScript(t *testing.T) {
+	bank := banktest.NewServer(t, banktest.WithFaultScript(
+		banktest.FaultStep{Method: http.MethodPost, Path: "/api/v1/authorizations", Request: 2, Fault: banktest.FaultError},
+	))
+
+	var statuses []int
+	for i := range 4 {
+		resp := bank.Authorize(t, "4111111111111111", "123", 100, fmt.Sprintf("auth-%d", i))
+		resp.Body.Close()
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	assert.Equal(t, []int{http.StatusOK, http.StatusInternalServerError, http.StatusOK, http.StatusOK}, statuses)
+}
+
+func TestServer_WithChaosSeed(t *testing.T) {
+	run := func() []int {
+		bank := banktest.NewServer(t, banktest.WithChaosSeed(42), banktest.WithFailureRate(0.5))
+
+		var statuses []int
+		for i := range 20 {
+			resp := bank.Authorize(t, "4111111111111111", "123", 100, fmt.Sprintf("auth-%d", i))
+			resp.Body.Close()
+			statuses = append(statuses, resp.StatusCode)
+		}
+		return statuses
+	}
+
+	first := run()
+	assert.Contains(t, first, http.StatusInternalServerError)
+	assert.Contains(t, first, http.StatusOK)
+	assert.Equal(t, first, run())
+}
+
+func TestServer_WithChaosHeaders(t *testing.T) {
+	bank := banktest.NewServer(t, banktest.WithChaosHeaders())
+
+	authorize := func(fault string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, bank.URL+"/api/v1/authorizations",
+			strings.NewReader(`{"card_number":"4111111111111111","cvv":"123","expiry_month":12,"expiry_year":2030,"amount":100}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", "auth-1")
+		req.Header.Set("X-Mock-Fault", fault)
+
+		resp, err := bank.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, authorize("500-after-commit").StatusCode)
+	_, available := bank.Balance(t, "4111111111111111")
+	assert.Equal(t, int64(999900), available, "the authorization went through")
+
+	retry := authorize("500-after-commit")
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("X-Idempotent-Replayed"))
+}
+
+func TestServer_WithRateLimit(t *testing.T) {
+	bank := banktest.NewServer(t,
+		banktest.WithRateLimit(0.01, 1),
+		banktest.WithEndpointRateLimit(http.MethodGet, "/api/v1/transactions", 0, 0),
+	)
+
+	first := bank.Authorize(t, "4111111111111111", "123", 100, "auth-1")
+	first.Body.Close()
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Equal(t, "1", first.Header.Get("RateLimit-Limit"))
+
+	limited := bank.Authorize(t, "4111111111111111", "123", 100, "auth-2")
+	limited.Body.Close()
+	assert.Equal(t, http.StatusTooManyRequests, limited.StatusCode)
+	assert.NotEmpty(t, limited.Header.Get("Retry-After"))
+
+	for range 3 {
+		resp, err := bank.Client().Get(bank.URL + "/api/v1/transactions")
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "the endpoint has no limit")
+	}
+}
+
+func TestServer_WithOutageWindow(t *testing.T) {
+	clock := banktest.NewFakeClock(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	bank := banktest.NewServer(t,
+		banktest.WithClock(clock),
+		banktest.WithOutageWindow(banktest.OutageMaintenance, time.Minute, 5*time.Minute),
+	)
+
+	health := func() (int, map[string]any) {
+		resp, err := bank.Client().Get(bank.URL + "/health")
+		require.NoError(t, err)
+		return resp.StatusCode, decode(t, resp)
+	}
+
+	status, body := health()
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "healthy", body["status"])
+
+	clock.Advance(time.Minute)
+	resp := bank.Authorize(t, "4111111111111111", "123", 100, "auth-1")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "300", resp.Header.Get("Retry-After"))
+	assert.Equal(t, "maintenance", decode(t, resp)["error"])
+
+	status, body = health()
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "maintenance", body["status"])
+	assert.Equal(t, "2026-03-01T12:06:00Z", body["until"])
+
+	clock.Advance(5 * time.Minute)
+	resp = bank.Authorize(t, "4111111111111111", "123", 100, "auth-1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "the retry succeeds once the window is over")
+}
+
+func TestServer_WithDegradedChaos(t *testing.T) {
+	bank := banktest.NewServer(t,
+		banktest.WithOutageWindow(banktest.OutageDegraded, 0, time.Hour),
+		banktest.WithDegradedChaos(1, 0, 0),
+	)
+
+	resp := bank.Authorize(t, "4111111111111111", "123", 100, "auth-1")
+	resp.Body.Close()
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	health, err := bank.Client().Get(bank.URL + "/health")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, health.StatusCode)
+	assert.Equal(t, "degraded", decode(t, health)["status"])
+}
@@ -0,0 +1,38 @@
+package banktest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock that only moves when the test moves it. It is safe for concurrent use.
+type FakeClock struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+// NewFakeClock returns a FakeClock stopped at now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's current time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to now
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
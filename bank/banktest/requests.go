@@ -0,0 +1,83 @@
+package banktest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// Authorize places a hold on a card. The card's expiry date is looked up from its
+// account, so only the number and CVV are needed. An empty idempotencyKey is omitted.
+func (s *Server) Authorize(t testing.TB, cardNumber, cvv string, amount int64, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	body := map[string]any{
+		"card_number":  cardNumber,
+		"cvv":          cvv,
+		"expiry_month": 12,
+		"expiry_year":  2030,
+		"amount":       amount,
+	}
+	if account, err := s.store.Accounts().FindByAccountNumber(context.Background(), cardNumber); err == nil {
+		body["expiry_month"] = account.ExpiryMonth
+		body["expiry_year"] = account.ExpiryYear
+	}
+
+	return s.post(t, "/api/v1/authorizations", body, idempotencyKey)
+}
+
+// Capture captures amount from an authorization
+func (s *Server) Capture(t testing.TB, authID string, amount int64, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	return s.post(t, "/api/v1/captures", map[string]any{
+		"authorization_id": authID,
+		"amount":           amount,
+	}, idempotencyKey)
+}
+
+// Void releases an authorization hold
+func (s *Server) Void(t testing.TB, authID string, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	return s.post(t, "/api/v1/voids", map[string]any{
+		"authorization_id": authID,
+	}, idempotencyKey)
+}
+
+// Refund returns amount of a capture to the card
+func (s *Server) Refund(t testing.TB, captureID string, amount int64, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	return s.post(t, "/api/v1/refunds", map[string]any{
+		"capture_id": captureID,
+		"amount":     amount,
+	}, idempotencyKey)
+}
+
+func (s *Server) post(t testing.TB, path string, body map[string]any, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("banktest: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.URL+path, bytes.NewReader(jsonBody))
+	if err != nil {
+		t.Fatalf("banktest: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := s.Client().Do(req)
+	if err != nil {
+		t.Fatalf("banktest: %v", err)
+	}
+
+	return resp
+}
@@ -10,9 +10,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/config"
 	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/handlers"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/benx421/payment-gateway/bank/internal/repository/memory"
+	"github.com/benx421/payment-gateway/bank/internal/service"
 )
 
 func main() {
@@ -28,25 +32,25 @@ func main() {
 	logger.Info("starting bank api",
 		"port", cfg.Server.Port,
 		"log_level", cfg.Logger.Level,
+		"storage_backend", cfg.Storage.Backend,
 	)
 
 	ctx := context.Background()
-	database, err := db.Connect(ctx, &cfg.Database, logger)
+	store, closeStore, err := openStore(ctx, cfg, logger)
 	if err != nil {
-		logger.Error("failed to connect to database", "error", err)
+		logger.Error("failed to open storage", "backend", cfg.Storage.Backend, "error", err)
 		os.Exit(1)
 	}
-	defer func() {
-		if err = database.Close(); err != nil {
-			logger.Error("failed to close database connection", "error", err)
-		}
-	}()
+	defer closeStore()
 
-	// Start periodic cleanup goroutine
+	// Start periodic cleanup and expiry goroutines
 	stopCleanup := make(chan struct{})
-	go runPeriodicCleanup(database, logger, stopCleanup)
+	go runPeriodicCleanup(store.IdempotencyKeys(), &cfg.App, logger, stopCleanup)
+
+	expiryService := service.NewExpiryService(store, clock.System, cfg.App.ExpirySweepBatchSize)
+	go runPeriodicExpiry(expiryService, &cfg.App, logger, stopCleanup)
 
-	router := handlers.NewRouter(database, cfg, logger)
+	router := handlers.NewRouter(store, clock.System, cfg, logger)
 
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Port,
@@ -81,18 +85,31 @@ func main() {
 	logger.Info("server stopped")
 }
 
-// cleanupIdempotencyKeys removes idempotency keys older than 24 hours
-func cleanupIdempotencyKeys(ctx context.Context, database *db.DB, logger *slog.Logger) {
-	cutoffTime := time.Now().Add(-24 * time.Hour)
-	result, err := database.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE created_at < $1", cutoffTime)
+// openStore opens the configured storage backend and returns a function that closes it
+func openStore(ctx context.Context, cfg *config.Config, logger *slog.Logger) (repository.Store, func(), error) {
+	if cfg.Storage.Backend == config.StorageBackendMemory {
+		logger.Warn("using in-memory storage; all data is lost when the process exits")
+		return memory.NewStore(memory.TestAccounts()...), func() {}, nil
+	}
+
+	database, err := db.Connect(ctx, &cfg.Database, logger)
 	if err != nil {
-		logger.Warn("failed to cleanup old idempotency keys", "error", err)
-		return
+		return nil, nil, err
 	}
 
-	rowsDeleted, err := result.RowsAffected()
+	closeDatabase := func() {
+		if err := database.Close(); err != nil {
+			logger.Error("failed to close database connection", "error", err)
+		}
+	}
+	return repository.NewPostgresStore(database), closeDatabase, nil
+}
+
+// cleanupIdempotencyKeys removes idempotency keys older than the retention period
+func cleanupIdempotencyKeys(ctx context.Context, repo repository.IdempotencyRepository, retention time.Duration, logger *slog.Logger) {
+	rowsDeleted, err := repo.DeleteOlderThan(ctx, time.Now().Add(-retention))
 	if err != nil {
-		logger.Warn("failed to get rows affected", "error", err)
+		logger.Warn("failed to cleanup old idempotency keys", "error", err)
 		return
 	}
 	if rowsDeleted > 0 {
@@ -100,16 +117,16 @@ func cleanupIdempotencyKeys(ctx context.Context, database *db.DB, logger *slog.L
 	}
 }
 
-// runPeriodicCleanup runs idempotency key cleanup every hour
-func runPeriodicCleanup(database *db.DB, logger *slog.Logger, stop <-chan struct{}) {
-	ticker := time.NewTicker(1 * time.Hour)
+// runPeriodicCleanup runs idempotency key cleanup on the configured interval
+func runPeriodicCleanup(repo repository.IdempotencyRepository, cfg *config.AppConfig, logger *slog.Logger, stop <-chan struct{}) {
+	ticker := time.NewTicker(cfg.IdempotencySweepInterval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ticker.C:
 			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-			cleanupIdempotencyKeys(ctx, database, logger)
+			cleanupIdempotencyKeys(ctx, repo, cfg.IdempotencyRetention, logger)
 			cancel()
 		case <-stop:
 			logger.Info("stopping periodic cleanup")
@@ -117,3 +134,42 @@ func runPeriodicCleanup(database *db.DB, logger *slog.Logger, stop <-chan struct
 		}
 	}
 }
+
+// expireAuthorizationHolds releases expired authorization holds in batches
+// until fewer than a full batch remains
+func expireAuthorizationHolds(ctx context.Context, expiryService *service.ExpiryService, batchSize int, logger *slog.Logger) {
+	total := 0
+	for {
+		expired, err := expiryService.ExpireHolds(ctx)
+		if err != nil {
+			logger.Warn("failed to expire authorization holds", "error", err)
+			break
+		}
+		total += expired
+		if expired < batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		logger.Info("expired authorization holds", "holds_expired", total)
+	}
+}
+
+// runPeriodicExpiry runs the authorization expiry sweep on the configured interval
+func runPeriodicExpiry(expiryService *service.ExpiryService, cfg *config.AppConfig, logger *slog.Logger, stop <-chan struct{}) {
+	ticker := time.NewTicker(cfg.ExpirySweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			expireAuthorizationHolds(ctx, expiryService, cfg.ExpirySweepBatchSize, logger)
+			cancel()
+		case <-stop:
+			logger.Info("stopping periodic expiry")
+			return
+		}
+	}
+}
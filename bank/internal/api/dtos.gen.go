@@ -7,9 +7,21 @@ import (
 	"time"
 )
 
+// Defines values for AuthorizationEventType.
+const (
+	Capture   AuthorizationEventType = "capture"
+	Increment AuthorizationEventType = "increment"
+	Reversal  AuthorizationEventType = "reversal"
+	Void      AuthorizationEventType = "void"
+)
+
 // Defines values for AuthorizationResponseStatus.
 const (
-	Approved AuthorizationResponseStatus = "approved"
+	Active                AuthorizationResponseStatus = "active"
+	Approved              AuthorizationResponseStatus = "approved"
+	AuthorizationCaptured AuthorizationResponseStatus = "captured"
+	AuthorizationVoided   AuthorizationResponseStatus = "voided"
+	Expired               AuthorizationResponseStatus = "expired"
 )
 
 // Defines values for CaptureResponseStatus.
@@ -19,29 +31,50 @@ const (
 
 // Defines values for ErrorCode.
 const (
-	ErrorCodeAlreadyCaptured          ErrorCode = "already_captured"
-	ErrorCodeAlreadyRefunded          ErrorCode = "already_refunded"
-	ErrorCodeAlreadyVoided            ErrorCode = "already_voided"
-	ErrorCodeAmountMismatch           ErrorCode = "amount_mismatch"
-	ErrorCodeAuthorizationAlreadyUsed ErrorCode = "authorization_already_used"
-	ErrorCodeAuthorizationExpired     ErrorCode = "authorization_expired"
-	ErrorCodeAuthorizationNotFound    ErrorCode = "authorization_not_found"
-	ErrorCodeCaptureNotFound          ErrorCode = "capture_not_found"
-	ErrorCodeCardExpired              ErrorCode = "card_expired"
-	ErrorCodeInsufficientFunds        ErrorCode = "insufficient_funds"
-	ErrorCodeInternalError            ErrorCode = "internal_error"
-	ErrorCodeInvalidAmount            ErrorCode = "invalid_amount"
-	ErrorCodeInvalidCard              ErrorCode = "invalid_card"
-	ErrorCodeInvalidCvv               ErrorCode = "invalid_cvv"
-	ErrorCodeMissingIdempotencyKey    ErrorCode = "missing_idempotency_key"
-	ErrorCodeNotFound                 ErrorCode = "not_found"
-	ErrorCodeRefundNotFound           ErrorCode = "refund_not_found"
+	ErrorCodeAlreadyCaptured              ErrorCode = "already_captured"
+	ErrorCodeAlreadyRefunded              ErrorCode = "already_refunded"
+	ErrorCodeAlreadyVoided                ErrorCode = "already_voided"
+	ErrorCodeAmountMismatch               ErrorCode = "amount_mismatch"
+	ErrorCodeAuthorizationAlreadyUsed     ErrorCode = "authorization_already_used"
+	ErrorCodeAuthorizationExpired         ErrorCode = "authorization_expired"
+	ErrorCodeAuthorizationNotFound        ErrorCode = "authorization_not_found"
+	ErrorCodeCaptureExceedsAuthorization  ErrorCode = "capture_exceeds_authorization"
+	ErrorCodeCaptureNotFound              ErrorCode = "capture_not_found"
+	ErrorCodeCardExpired                  ErrorCode = "card_expired"
+	ErrorCodeIdempotencyKeyReused         ErrorCode = "idempotency_key_reused"
+	ErrorCodeInsufficientFunds            ErrorCode = "insufficient_funds"
+	ErrorCodeInternalError                ErrorCode = "internal_error"
+	ErrorCodeInvalidAmount                ErrorCode = "invalid_amount"
+	ErrorCodeInvalidCard                  ErrorCode = "invalid_card"
+	ErrorCodeInvalidChaosHeader           ErrorCode = "invalid_chaos_header"
+	ErrorCodeInvalidChaosSettings         ErrorCode = "invalid_chaos_settings"
+	ErrorCodeInvalidCvv                   ErrorCode = "invalid_cvv"
+	ErrorCodeInvalidExpiry                ErrorCode = "invalid_expiry"
+	ErrorCodeInvalidMetadata              ErrorCode = "invalid_metadata"
+	ErrorCodeInvalidQuery                 ErrorCode = "invalid_query"
+	ErrorCodeInvalidRateLimitSettings     ErrorCode = "invalid_rate_limit_settings"
+	ErrorCodeMaintenance                  ErrorCode = "maintenance"
+	ErrorCodeMissingIdempotencyKey        ErrorCode = "missing_idempotency_key"
+	ErrorCodeNotFound                     ErrorCode = "not_found"
+	ErrorCodeRateLimited                  ErrorCode = "rate_limited"
+	ErrorCodeRefundExceedsCapture         ErrorCode = "refund_exceeds_capture"
+	ErrorCodeRefundNotFound               ErrorCode = "refund_not_found"
+	ErrorCodeRequestInProgress            ErrorCode = "request_in_progress"
+	ErrorCodeReversalExceedsAuthorization ErrorCode = "reversal_exceeds_authorization"
+	ErrorCodeVoidNotFound                 ErrorCode = "void_not_found"
 )
 
 // Defines values for HealthResponseStatus.
 const (
-	Healthy   HealthResponseStatus = "healthy"
-	Unhealthy HealthResponseStatus = "unhealthy"
+	HealthResponseStatusDegraded    HealthResponseStatus = "degraded"
+	HealthResponseStatusHealthy     HealthResponseStatus = "healthy"
+	HealthResponseStatusMaintenance HealthResponseStatus = "maintenance"
+	HealthResponseStatusUnhealthy   HealthResponseStatus = "unhealthy"
+)
+
+// Defines values for IncrementResponseStatus.
+const (
+	Incremented IncrementResponseStatus = "incremented"
 )
 
 // Defines values for RefundResponseStatus.
@@ -49,37 +82,164 @@ const (
 	Refunded RefundResponseStatus = "refunded"
 )
 
+// Defines values for ReversalResponseStatus.
+const (
+	Reversed ReversalResponseStatus = "reversed"
+)
+
+// Defines values for TransactionStatus.
+const (
+	TransactionStatusActive    TransactionStatus = "active"
+	TransactionStatusCompleted TransactionStatus = "completed"
+	TransactionStatusExpired   TransactionStatus = "expired"
+)
+
+// Defines values for TransactionType.
+const (
+	TransactionTypeAuthorization TransactionType = "authorization"
+	TransactionTypeCapture       TransactionType = "capture"
+	TransactionTypeIncrement     TransactionType = "increment"
+	TransactionTypeRefund        TransactionType = "refund"
+	TransactionTypeReversal      TransactionType = "reversal"
+	TransactionTypeVoid          TransactionType = "void"
+)
+
 // Defines values for VoidResponseStatus.
 const (
 	Voided VoidResponseStatus = "voided"
 )
 
+// AuthorizationEvent defines model for AuthorizationEvent.
+type AuthorizationEvent struct {
+	Amount    int64                  `json:"amount"`
+	CreatedAt time.Time              `json:"created_at"`
+	Id        string                 `json:"id"`
+	Type      AuthorizationEventType `json:"type"`
+}
+
+// AuthorizationEventType defines model for AuthorizationEvent.Type.
+type AuthorizationEventType string
+
 // AuthorizationResponse defines model for AuthorizationResponse.
 type AuthorizationResponse struct {
-	Amount          int64                       `json:"amount"`
-	AuthorizationId string                      `json:"authorization_id"`
-	CreatedAt       time.Time                   `json:"created_at"`
-	Currency        string                      `json:"currency"`
-	ExpiresAt       time.Time                   `json:"expires_at"`
-	Status          AuthorizationResponseStatus `json:"status"`
+	// Amount Authorized amount in cents
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+
+	// CaptureIds IDs of the captures taken against this authorization
+	CaptureIds []string `json:"capture_ids"`
+
+	// CapturedAmount Total captured so far in cents
+	CapturedAmount int64     `json:"captured_amount"`
+	CreatedAt      time.Time `json:"created_at"`
+	Currency       string    `json:"currency"`
+	ExpiresAt      time.Time `json:"expires_at"`
+
+	// History Ledger entries recorded against this authorization, oldest first
+	History []AuthorizationEvent `json:"history"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+
+	// RemainingAmount Amount in cents still held and available to capture
+	RemainingAmount int64 `json:"remaining_amount"`
+
+	// ReversedAmount Total released so far by partial reversals in cents
+	ReversedAmount int64 `json:"reversed_amount"`
+
+	// Status `approved` is returned when the authorization is created. Lookups report the
+	// current lifecycle state: `active` (still capturable), `captured`, `voided`
+	// (released without capture) or `expired`.
+	Status AuthorizationResponseStatus `json:"status"`
+
+	// VoidId ID of the void, if the authorization was voided
+	VoidId string `json:"void_id,omitempty,omitzero"`
 }
 
-// AuthorizationResponseStatus defines model for AuthorizationResponse.Status.
+// AuthorizationResponseStatus `approved` is returned when the authorization is created. Lookups report the
+// current lifecycle state: `active` (still capturable), `captured`, `voided`
+// (released without capture) or `expired`.
 type AuthorizationResponseStatus string
 
 // CaptureResponse defines model for CaptureResponse.
 type CaptureResponse struct {
-	Amount          int64                 `json:"amount"`
-	AuthorizationId string                `json:"authorization_id"`
-	CaptureId       string                `json:"capture_id"`
-	CapturedAt      time.Time             `json:"captured_at"`
-	Currency        string                `json:"currency"`
-	Status          CaptureResponseStatus `json:"status"`
+	Amount          int64     `json:"amount"`
+	AuthorizationId string    `json:"authorization_id"`
+	CaptureId       string    `json:"capture_id"`
+	CapturedAt      time.Time `json:"captured_at"`
+	Currency        string    `json:"currency"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+
+	// RefundableAmount Amount in cents still available to refund
+	RefundableAmount int64 `json:"refundable_amount"`
+
+	// RefundedAmount Total refunded so far in cents
+	RefundedAmount int64                 `json:"refunded_amount"`
+	Status         CaptureResponseStatus `json:"status"`
 }
 
 // CaptureResponseStatus defines model for CaptureResponse.Status.
 type CaptureResponseStatus string
 
+// ChaosProfile defines model for ChaosProfile.
+type ChaosProfile struct {
+	// FaultRates Fraction of requests (0 to 1) that get each fault kind: error, error_after_commit, hang,
+	// reset, truncated_body, malformed_body, bad_gateway, unavailable, gateway_timeout, and the
+	// three proxy errors with a _retry_after suffix. The rates may add up to at most 1.
+	FaultRates   map[string]float64 `json:"fault_rates"`
+	MaxLatencyMs int                `json:"max_latency_ms"`
+	MinLatencyMs int                `json:"min_latency_ms"`
+}
+
+// ChaosRoute defines model for ChaosRoute.
+type ChaosRoute struct {
+	// FaultRates Fault rates for matching requests, as in ChaosProfile
+	FaultRates   map[string]float64 `json:"fault_rates"`
+	MaxLatencyMs int                `json:"max_latency_ms"`
+
+	// Method HTTP method to match; any method if omitted
+	Method       string `json:"method,omitempty,omitzero"`
+	MinLatencyMs int    `json:"min_latency_ms"`
+
+	// Path Path to match; `*` stands for one path segment, e.g. /api/v1/authorizations/*/increments
+	Path string `json:"path"`
+}
+
+// ChaosScriptStep defines model for ChaosScriptStep.
+type ChaosScriptStep struct {
+	// Fault Fault kind, as in ChaosProfile fault_rates
+	Fault string `json:"fault"`
+
+	// Method HTTP method to count; any method if omitted
+	Method string `json:"method,omitempty,omitzero"`
+
+	// Path Path pattern to count, as in ChaosRoute; any path if omitted
+	Path string `json:"path,omitempty,omitzero"`
+
+	// Request Which matching request fails, counting from 1
+	Request int `json:"request"`
+}
+
+// ChaosSettings defines model for ChaosSettings.
+type ChaosSettings struct {
+	Default ChaosProfile `json:"default"`
+
+	// Routes Per-route profiles, tried in order; the first match replaces the default
+	Routes []ChaosRoute `json:"routes"`
+
+	// Script Scripted faults that replace the random ones: only the requests listed here fail, and
+	// latency still follows the profiles. Requests replayed from an idempotency key are not counted.
+	Script []ChaosScriptStep `json:"script,omitempty,omitzero"`
+
+	// Seed Seed for the random latency and faults. The same seed and order of requests give the
+	// same chaos. Omit or send 0 for a random seed; responses always show the seed in use.
+	Seed uint64 `json:"seed,omitempty,omitzero"`
+}
+
 // CreateAuthorizationRequest defines model for CreateAuthorizationRequest.
 type CreateAuthorizationRequest struct {
 	// Amount Amount in cents
@@ -89,33 +249,68 @@ type CreateAuthorizationRequest struct {
 	CardNumber string `json:"card_number"`
 
 	// Cvv Card verification value
-	Cvv         string `json:"cvv"`
-	ExpiryMonth int    `json:"expiry_month"`
-	ExpiryYear  int    `json:"expiry_year"`
+	Cvv string `json:"cvv"`
+
+	// ExpiryMonth Must match the card on file, otherwise the request fails with invalid_expiry
+	ExpiryMonth int `json:"expiry_month"`
+
+	// ExpiryYear Must match the card on file, otherwise the request fails with invalid_expiry
+	ExpiryYear int `json:"expiry_year"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
 }
 
 // CreateCaptureRequest defines model for CreateCaptureRequest.
 type CreateCaptureRequest struct {
-	// Amount Amount in cents (must match authorization)
+	// Amount Amount in cents (up to the remaining authorized amount)
 	Amount int64 `json:"amount"`
 
 	// AuthorizationId Authorization ID to capture
 	AuthorizationId string `json:"authorization_id"`
+
+	// FinalCapture Release any hold left on the authorization after this capture
+	FinalCapture bool `json:"final_capture,omitempty,omitzero"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+}
+
+// CreateIncrementRequest defines model for CreateIncrementRequest.
+type CreateIncrementRequest struct {
+	// Amount Amount in cents to add to the hold
+	Amount int64 `json:"amount"`
 }
 
 // CreateRefundRequest defines model for CreateRefundRequest.
 type CreateRefundRequest struct {
-	// Amount Amount in cents (must match capture)
+	// Amount Amount in cents (up to the remaining refundable amount)
 	Amount int64 `json:"amount"`
 
 	// CaptureId Capture ID to refund
 	CaptureId string `json:"capture_id"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+}
+
+// CreateReversalRequest defines model for CreateReversalRequest.
+type CreateReversalRequest struct {
+	// Amount Amount in cents to release (up to the remaining authorized amount)
+	Amount int64 `json:"amount"`
 }
 
 // CreateVoidRequest defines model for CreateVoidRequest.
 type CreateVoidRequest struct {
 	// AuthorizationId Authorization ID to void
 	AuthorizationId string `json:"authorization_id"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
 }
 
 // ErrorCode defines model for ErrorCode.
@@ -130,16 +325,103 @@ type ErrorResponse struct {
 // HealthResponse defines model for HealthResponse.
 type HealthResponse struct {
 	Status HealthResponseStatus `json:"status"`
+
+	// Until When the maintenance or degraded window ends
+	Until time.Time `json:"until,omitempty,omitzero"`
 }
 
 // HealthResponseStatus defines model for HealthResponse.Status.
 type HealthResponseStatus string
 
+// IdempotencyKeyListResponse defines model for IdempotencyKeyListResponse.
+type IdempotencyKeyListResponse struct {
+	Data []IdempotencyKeyRecord `json:"data"`
+}
+
+// IdempotencyKeyRecord defines model for IdempotencyKeyRecord.
+type IdempotencyKeyRecord struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// InProgress The original request is still being processed and has no stored response yet
+	InProgress bool   `json:"in_progress"`
+	Key        string `json:"key"`
+
+	// RequestHash SHA-256 of the canonical request body; empty for keys stored before fingerprinting
+	RequestHash string `json:"request_hash"`
+	RequestPath string `json:"request_path"`
+
+	// ResponseBody Response body replayed for this key
+	ResponseBody string `json:"response_body"`
+
+	// ResponseStatus HTTP status replayed for this key
+	ResponseStatus int `json:"response_status"`
+}
+
+// IncrementResponse defines model for IncrementResponse.
+type IncrementResponse struct {
+	// Amount Amount in cents added by this increment
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+
+	// AuthorizedAmount New total held by the authorization in cents
+	AuthorizedAmount int64     `json:"authorized_amount"`
+	CreatedAt        time.Time `json:"created_at"`
+	Currency         string    `json:"currency"`
+
+	// ExpiresAt Unchanged expiry of the authorization
+	ExpiresAt   time.Time `json:"expires_at"`
+	IncrementId string    `json:"increment_id"`
+
+	// RemainingAmount Amount in cents still held and available to capture
+	RemainingAmount int64                   `json:"remaining_amount"`
+	Status          IncrementResponseStatus `json:"status"`
+}
+
+// IncrementResponseStatus defines model for IncrementResponse.Status.
+type IncrementResponseStatus string
+
+// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+type Metadata map[string]string
+
+// RateLimit defines model for RateLimit.
+type RateLimit struct {
+	// Burst Requests the bucket holds when full, i.e. how many can be sent at once
+	Burst int `json:"burst"`
+
+	// RequestsPerSecond Rate the client's bucket refills at; 0 means no limit
+	RequestsPerSecond float64 `json:"requests_per_second"`
+}
+
+// RateLimitRoute defines model for RateLimitRoute.
+type RateLimitRoute struct {
+	Burst int `json:"burst"`
+
+	// Method HTTP method to match; any method if omitted
+	Method string `json:"method,omitempty,omitzero"`
+
+	// Path Path to match; `*` stands for one path segment, e.g. /api/v1/authorizations/*/increments
+	Path              string  `json:"path"`
+	RequestsPerSecond float64 `json:"requests_per_second"`
+}
+
+// RateLimitSettings defines model for RateLimitSettings.
+type RateLimitSettings struct {
+	Default RateLimit `json:"default"`
+
+	// Routes Per-route limits, tried in order; each has its own bucket per client
+	Routes []RateLimitRoute `json:"routes"`
+}
+
 // RefundResponse defines model for RefundResponse.
 type RefundResponse struct {
-	Amount     int64                `json:"amount"`
-	CaptureId  string               `json:"capture_id"`
-	Currency   string               `json:"currency"`
+	Amount    int64  `json:"amount"`
+	CaptureId string `json:"capture_id"`
+	Currency  string `json:"currency"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata   Metadata             `json:"metadata,omitempty,omitzero"`
 	RefundId   string               `json:"refund_id"`
 	RefundedAt time.Time            `json:"refunded_at"`
 	Status     RefundResponseStatus `json:"status"`
@@ -148,12 +430,68 @@ type RefundResponse struct {
 // RefundResponseStatus defines model for RefundResponse.Status.
 type RefundResponseStatus string
 
+// ReversalResponse defines model for ReversalResponse.
+type ReversalResponse struct {
+	// Amount Amount in cents released by this reversal
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+	Currency        string `json:"currency"`
+
+	// RemainingAmount Amount in cents still held and available to capture
+	RemainingAmount int64                  `json:"remaining_amount"`
+	ReversalId      string                 `json:"reversal_id"`
+	ReversedAt      time.Time              `json:"reversed_at"`
+	Status          ReversalResponseStatus `json:"status"`
+}
+
+// ReversalResponseStatus defines model for ReversalResponse.Status.
+type ReversalResponseStatus string
+
+// TransactionListResponse defines model for TransactionListResponse.
+type TransactionListResponse struct {
+	Data    []TransactionResponse `json:"data"`
+	HasMore bool                  `json:"has_more"`
+
+	// NextCursor Pass as `cursor` to get the next page; absent on the last page
+	NextCursor string `json:"next_cursor,omitempty,omitzero"`
+}
+
+// TransactionResponse defines model for TransactionResponse.
+type TransactionResponse struct {
+	Amount    int64     `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+	Currency  string    `json:"currency"`
+	Id        string    `json:"id"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata `json:"metadata,omitempty,omitzero"`
+
+	// ReferenceId Authorization or capture this transaction belongs to
+	ReferenceId string            `json:"reference_id,omitempty,omitzero"`
+	Status      TransactionStatus `json:"status"`
+	Type        TransactionType   `json:"type"`
+}
+
+// TransactionStatus defines model for TransactionStatus.
+type TransactionStatus string
+
+// TransactionType defines model for TransactionType.
+type TransactionType string
+
 // VoidResponse defines model for VoidResponse.
 type VoidResponse struct {
-	AuthorizationId string             `json:"authorization_id"`
-	Status          VoidResponseStatus `json:"status"`
-	VoidId          string             `json:"void_id"`
-	VoidedAt        time.Time          `json:"voided_at"`
+	// Amount Amount in cents released back to the account by the void
+	Amount          int64  `json:"amount"`
+	AuthorizationId string `json:"authorization_id"`
+	Currency        string `json:"currency"`
+
+	// Metadata Free-form key/value pairs stored with the transaction, e.g. order_id and customer_id.
+	// Up to 20 keys; keys up to 40 characters, values up to 500 characters.
+	Metadata Metadata           `json:"metadata,omitempty,omitzero"`
+	Status   VoidResponseStatus `json:"status"`
+	VoidId   string             `json:"void_id"`
+	VoidedAt time.Time          `json:"voided_at"`
 }
 
 // VoidResponseStatus defines model for VoidResponse.Status.
@@ -165,30 +503,66 @@ type AuthorizationId = string
 // CaptureId defines model for CaptureId.
 type CaptureId = string
 
+// IdempotencyKeyPath defines model for IdempotencyKeyPath.
+type IdempotencyKeyPath = string
+
 // IdempotencyKeyRequired defines model for IdempotencyKeyRequired.
 type IdempotencyKeyRequired = string
 
 // RefundId defines model for RefundId.
 type RefundId = string
 
+// VoidId defines model for VoidId.
+type VoidId = string
+
 // BadRequest defines model for BadRequest.
 type BadRequest = ErrorResponse
 
+// IdempotencyKeyReused defines model for IdempotencyKeyReused.
+type IdempotencyKeyReused = ErrorResponse
+
 // InternalError defines model for InternalError.
 type InternalError = ErrorResponse
 
+// Maintenance defines model for Maintenance.
+type Maintenance = ErrorResponse
+
 // NotFound defines model for NotFound.
 type NotFound = ErrorResponse
 
 // PaymentRequired defines model for PaymentRequired.
 type PaymentRequired = ErrorResponse
 
+// RequestInProgress defines model for RequestInProgress.
+type RequestInProgress = ErrorResponse
+
+// TooManyRequests defines model for TooManyRequests.
+type TooManyRequests = ErrorResponse
+
+// DeleteIdempotencyKeyParams defines parameters for DeleteIdempotencyKey.
+type DeleteIdempotencyKeyParams struct {
+	// RequestPath Only purge the entry for this request path; all paths if omitted
+	RequestPath string `form:"request_path,omitempty" json:"request_path,omitempty,omitzero"`
+}
+
 // CreateAuthorizationParams defines parameters for CreateAuthorization.
 type CreateAuthorizationParams struct {
 	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
 	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
 }
 
+// CreateAuthorizationIncrementParams defines parameters for CreateAuthorizationIncrement.
+type CreateAuthorizationIncrementParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
+// CreateAuthorizationReversalParams defines parameters for CreateAuthorizationReversal.
+type CreateAuthorizationReversalParams struct {
+	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
+	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
+}
+
 // CreateCaptureParams defines parameters for CreateCapture.
 type CreateCaptureParams struct {
 	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
@@ -201,15 +575,60 @@ type CreateRefundParams struct {
 	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
 }
 
+// ListTransactionsParams defines parameters for ListTransactions.
+type ListTransactionsParams struct {
+	// AccountNumber Only transactions on this account (card number)
+	AccountNumber string `form:"account_number,omitempty" json:"account_number,omitempty,omitzero"`
+
+	// Type Only transactions of this type
+	Type TransactionType `form:"type,omitempty" json:"type,omitempty,omitzero"`
+
+	// Status Only transactions in this status
+	Status TransactionStatus `form:"status,omitempty" json:"status,omitempty,omitzero"`
+
+	// ReferenceId Only transactions that reference this ID, e.g. the captures of an authorization
+	ReferenceId string `form:"reference_id,omitempty" json:"reference_id,omitempty,omitzero"`
+
+	// MetadataKey Only transactions whose metadata has this key, e.g. order_id
+	MetadataKey string `form:"metadata_key,omitempty" json:"metadata_key,omitempty,omitzero"`
+
+	// MetadataValue Only transactions whose metadata_key has this value; requires metadata_key
+	MetadataValue string `form:"metadata_value,omitempty" json:"metadata_value,omitempty,omitzero"`
+
+	// CreatedFrom Only transactions created at or after this time
+	CreatedFrom time.Time `form:"created_from,omitempty" json:"created_from,omitempty,omitzero"`
+
+	// CreatedTo Only transactions created before this time
+	CreatedTo time.Time `form:"created_to,omitempty" json:"created_to,omitempty,omitzero"`
+
+	// Cursor Cursor returned as `next_cursor` by the previous page
+	Cursor string `form:"cursor,omitempty" json:"cursor,omitempty,omitzero"`
+
+	// Limit Page size
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
 // CreateVoidParams defines parameters for CreateVoid.
 type CreateVoidParams struct {
 	// IdempotencyKey Unique key for idempotent requests (max 255 chars)
 	IdempotencyKey IdempotencyKeyRequired `json:"Idempotency-Key"`
 }
 
+// UpdateChaosSettingsJSONRequestBody defines body for UpdateChaosSettings for application/json ContentType.
+type UpdateChaosSettingsJSONRequestBody = ChaosSettings
+
+// UpdateRateLimitsJSONRequestBody defines body for UpdateRateLimits for application/json ContentType.
+type UpdateRateLimitsJSONRequestBody = RateLimitSettings
+
 // CreateAuthorizationJSONRequestBody defines body for CreateAuthorization for application/json ContentType.
 type CreateAuthorizationJSONRequestBody = CreateAuthorizationRequest
 
+// CreateAuthorizationIncrementJSONRequestBody defines body for CreateAuthorizationIncrement for application/json ContentType.
+type CreateAuthorizationIncrementJSONRequestBody = CreateIncrementRequest
+
+// CreateAuthorizationReversalJSONRequestBody defines body for CreateAuthorizationReversal for application/json ContentType.
+type CreateAuthorizationReversalJSONRequestBody = CreateReversalRequest
+
 // CreateCaptureJSONRequestBody defines body for CreateCapture for application/json ContentType.
 type CreateCaptureJSONRequestBody = CreateCaptureRequest
 
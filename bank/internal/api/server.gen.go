@@ -17,12 +17,42 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Restore the startup chaos settings
+	// (DELETE /admin/chaos)
+	ResetChaosSettings(w http.ResponseWriter, r *http.Request)
+	// Read the chaos settings
+	// (GET /admin/chaos)
+	GetChaosSettings(w http.ResponseWriter, r *http.Request)
+	// Replace the chaos settings
+	// (PUT /admin/chaos)
+	UpdateChaosSettings(w http.ResponseWriter, r *http.Request)
+	// Purge a stored idempotency key
+	// (DELETE /admin/idempotency-keys/{idempotencyKey})
+	DeleteIdempotencyKey(w http.ResponseWriter, r *http.Request, idempotencyKey IdempotencyKeyPath, params DeleteIdempotencyKeyParams)
+	// Inspect a stored idempotency key
+	// (GET /admin/idempotency-keys/{idempotencyKey})
+	GetIdempotencyKey(w http.ResponseWriter, r *http.Request, idempotencyKey IdempotencyKeyPath)
+	// Restore the startup rate limits
+	// (DELETE /admin/rate-limits)
+	ResetRateLimits(w http.ResponseWriter, r *http.Request)
+	// Read the rate limits
+	// (GET /admin/rate-limits)
+	GetRateLimits(w http.ResponseWriter, r *http.Request)
+	// Replace the rate limits
+	// (PUT /admin/rate-limits)
+	UpdateRateLimits(w http.ResponseWriter, r *http.Request)
 	// Create authorization hold
 	// (POST /api/v1/authorizations)
 	CreateAuthorization(w http.ResponseWriter, r *http.Request, params CreateAuthorizationParams)
 	// Get authorization details
 	// (GET /api/v1/authorizations/{authorizationId})
 	GetAuthorization(w http.ResponseWriter, r *http.Request, authorizationId AuthorizationId)
+	// Increment authorization hold
+	// (POST /api/v1/authorizations/{authorizationId}/increments)
+	CreateAuthorizationIncrement(w http.ResponseWriter, r *http.Request, authorizationId AuthorizationId, params CreateAuthorizationIncrementParams)
+	// Partially reverse authorization hold
+	// (POST /api/v1/authorizations/{authorizationId}/reversals)
+	CreateAuthorizationReversal(w http.ResponseWriter, r *http.Request, authorizationId AuthorizationId, params CreateAuthorizationReversalParams)
 	// Capture authorization
 	// (POST /api/v1/captures)
 	CreateCapture(w http.ResponseWriter, r *http.Request, params CreateCaptureParams)
@@ -35,9 +65,15 @@ type ServerInterface interface {
 	// Get refund details
 	// (GET /api/v1/refunds/{refundId})
 	GetRefund(w http.ResponseWriter, r *http.Request, refundId RefundId)
+	// List transactions
+	// (GET /api/v1/transactions)
+	ListTransactions(w http.ResponseWriter, r *http.Request, params ListTransactionsParams)
 	// Void authorization
 	// (POST /api/v1/voids)
 	CreateVoid(w http.ResponseWriter, r *http.Request, params CreateVoidParams)
+	// Get void details
+	// (GET /api/v1/voids/{voidId})
+	GetVoid(w http.ResponseWriter, r *http.Request, voidId VoidId)
 	// Health check
 	// (GET /health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
@@ -52,6 +88,151 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ResetChaosSettings operation middleware
+func (siw *ServerInterfaceWrapper) ResetChaosSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetChaosSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetChaosSettings operation middleware
+func (siw *ServerInterfaceWrapper) GetChaosSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetChaosSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateChaosSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateChaosSettings(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateChaosSettings(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteIdempotencyKey operation middleware
+func (siw *ServerInterfaceWrapper) DeleteIdempotencyKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "idempotencyKey" -------------
+	var idempotencyKey IdempotencyKeyPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "idempotencyKey", r.PathValue("idempotencyKey"), &idempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "idempotencyKey", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteIdempotencyKeyParams
+
+	// ------------- Optional query parameter "request_path" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "request_path", r.URL.Query(), &params.RequestPath)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "request_path", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteIdempotencyKey(w, r, idempotencyKey, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetIdempotencyKey operation middleware
+func (siw *ServerInterfaceWrapper) GetIdempotencyKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "idempotencyKey" -------------
+	var idempotencyKey IdempotencyKeyPath
+
+	err = runtime.BindStyledParameterWithOptions("simple", "idempotencyKey", r.PathValue("idempotencyKey"), &idempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "idempotencyKey", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIdempotencyKey(w, r, idempotencyKey)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ResetRateLimits operation middleware
+func (siw *ServerInterfaceWrapper) ResetRateLimits(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResetRateLimits(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRateLimits operation middleware
+func (siw *ServerInterfaceWrapper) GetRateLimits(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRateLimits(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UpdateRateLimits operation middleware
+func (siw *ServerInterfaceWrapper) UpdateRateLimits(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateRateLimits(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // CreateAuthorization operation middleware
 func (siw *ServerInterfaceWrapper) CreateAuthorization(w http.ResponseWriter, r *http.Request) {
 
@@ -121,13 +302,22 @@ func (siw *ServerInterfaceWrapper) GetAuthorization(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// CreateCapture operation middleware
-func (siw *ServerInterfaceWrapper) CreateCapture(w http.ResponseWriter, r *http.Request) {
+// CreateAuthorizationIncrement operation middleware
+func (siw *ServerInterfaceWrapper) CreateAuthorizationIncrement(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "authorizationId" -------------
+	var authorizationId AuthorizationId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "authorizationId", r.PathValue("authorizationId"), &authorizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "authorizationId", Err: err})
+		return
+	}
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateCaptureParams
+	var params CreateAuthorizationIncrementParams
 
 	headers := r.Header
 
@@ -155,7 +345,7 @@ func (siw *ServerInterfaceWrapper) CreateCapture(w http.ResponseWriter, r *http.
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateCapture(w, r, params)
+		siw.Handler.CreateAuthorizationIncrement(w, r, authorizationId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -165,22 +355,50 @@ func (siw *ServerInterfaceWrapper) CreateCapture(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// GetCapture operation middleware
-func (siw *ServerInterfaceWrapper) GetCapture(w http.ResponseWriter, r *http.Request) {
+// CreateAuthorizationReversal operation middleware
+func (siw *ServerInterfaceWrapper) CreateAuthorizationReversal(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "captureId" -------------
-	var captureId CaptureId
+	// ------------- Path parameter "authorizationId" -------------
+	var authorizationId AuthorizationId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "captureId", r.PathValue("captureId"), &captureId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "authorizationId", r.PathValue("authorizationId"), &authorizationId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "captureId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "authorizationId", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateAuthorizationReversalParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyRequired
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetCapture(w, r, captureId)
+		siw.Handler.CreateAuthorizationReversal(w, r, authorizationId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -190,13 +408,13 @@ func (siw *ServerInterfaceWrapper) GetCapture(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// CreateRefund operation middleware
-func (siw *ServerInterfaceWrapper) CreateRefund(w http.ResponseWriter, r *http.Request) {
+// CreateCapture operation middleware
+func (siw *ServerInterfaceWrapper) CreateCapture(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateRefundParams
+	var params CreateCaptureParams
 
 	headers := r.Header
 
@@ -224,7 +442,7 @@ func (siw *ServerInterfaceWrapper) CreateRefund(w http.ResponseWriter, r *http.R
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateRefund(w, r, params)
+		siw.Handler.CreateCapture(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -234,22 +452,22 @@ func (siw *ServerInterfaceWrapper) CreateRefund(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
-// GetRefund operation middleware
-func (siw *ServerInterfaceWrapper) GetRefund(w http.ResponseWriter, r *http.Request) {
+// GetCapture operation middleware
+func (siw *ServerInterfaceWrapper) GetCapture(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "refundId" -------------
-	var refundId RefundId
+	// ------------- Path parameter "captureId" -------------
+	var captureId CaptureId
 
-	err = runtime.BindStyledParameterWithOptions("simple", "refundId", r.PathValue("refundId"), &refundId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "captureId", r.PathValue("captureId"), &captureId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "captureId", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRefund(w, r, refundId)
+		siw.Handler.GetCapture(w, r, captureId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -259,13 +477,13 @@ func (siw *ServerInterfaceWrapper) GetRefund(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-// CreateVoid operation middleware
-func (siw *ServerInterfaceWrapper) CreateVoid(w http.ResponseWriter, r *http.Request) {
+// CreateRefund operation middleware
+func (siw *ServerInterfaceWrapper) CreateRefund(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params CreateVoidParams
+	var params CreateRefundParams
 
 	headers := r.Header
 
@@ -293,7 +511,7 @@ func (siw *ServerInterfaceWrapper) CreateVoid(w http.ResponseWriter, r *http.Req
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateVoid(w, r, params)
+		siw.Handler.CreateRefund(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -303,11 +521,22 @@ func (siw *ServerInterfaceWrapper) CreateVoid(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
-// GetHealth operation middleware
-func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+// GetRefund operation middleware
+func (siw *ServerInterfaceWrapper) GetRefund(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "refundId" -------------
+	var refundId RefundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "refundId", r.PathValue("refundId"), &refundId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundId", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetHealth(w, r)
+		siw.Handler.GetRefund(w, r, refundId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -317,54 +546,236 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
-
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+// ListTransactions operation middleware
+func (siw *ServerInterfaceWrapper) ListTransactions(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	var err error
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTransactionsParams
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Optional query parameter "account_number" -------------
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindQueryParameter("form", true, false, "account_number", r.URL.Query(), &params.AccountNumber)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_number", Err: err})
+		return
+	}
 
-type RequiredParamError struct {
-	ParamName string
-}
+	// ------------- Optional query parameter "type" -------------
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "type", r.URL.Query(), &params.Type)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "type", Err: err})
+		return
+	}
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
+	// ------------- Optional query parameter "status" -------------
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Optional query parameter "reference_id" -------------
 
-type InvalidParamFormatError struct {
+	err = runtime.BindQueryParameter("form", true, false, "reference_id", r.URL.Query(), &params.ReferenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "metadata_key" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "metadata_key", r.URL.Query(), &params.MetadataKey)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "metadata_key", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "metadata_value" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "metadata_value", r.URL.Query(), &params.MetadataValue)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "metadata_value", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTransactions(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateVoid operation middleware
+func (siw *ServerInterfaceWrapper) CreateVoid(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateVoidParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKeyRequired
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateVoid(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetVoid operation middleware
+func (siw *ServerInterfaceWrapper) GetVoid(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "voidId" -------------
+	var voidId VoidId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "voidId", r.PathValue("voidId"), &voidId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "voidId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetVoid(w, r, voidId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetHealth(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
 	ParamName string
 	Err       error
 }
@@ -391,141 +802,618 @@ func Handler(si ServerInterface) http.Handler {
 	return HandlerWithOptions(si, StdHTTPServerOptions{})
 }
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/chaos", wrapper.ResetChaosSettings)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/chaos", wrapper.GetChaosSettings)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/chaos", wrapper.UpdateChaosSettings)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/idempotency-keys/{idempotencyKey}", wrapper.DeleteIdempotencyKey)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/idempotency-keys/{idempotencyKey}", wrapper.GetIdempotencyKey)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/rate-limits", wrapper.ResetRateLimits)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/rate-limits", wrapper.GetRateLimits)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/rate-limits", wrapper.UpdateRateLimits)
+	m.HandleFunc("POST "+options.BaseURL+"/api/v1/authorizations", wrapper.CreateAuthorization)
+	m.HandleFunc("GET "+options.BaseURL+"/api/v1/authorizations/{authorizationId}", wrapper.GetAuthorization)
+	m.HandleFunc("POST "+options.BaseURL+"/api/v1/authorizations/{authorizationId}/increments", wrapper.CreateAuthorizationIncrement)
+	m.HandleFunc("POST "+options.BaseURL+"/api/v1/authorizations/{authorizationId}/reversals", wrapper.CreateAuthorizationReversal)
+	m.HandleFunc("POST "+options.BaseURL+"/api/v1/captures", wrapper.CreateCapture)
+	m.HandleFunc("GET "+options.BaseURL+"/api/v1/captures/{captureId}", wrapper.GetCapture)
+	m.HandleFunc("POST "+options.BaseURL+"/api/v1/refunds", wrapper.CreateRefund)
+	m.HandleFunc("GET "+options.BaseURL+"/api/v1/refunds/{refundId}", wrapper.GetRefund)
+	m.HandleFunc("GET "+options.BaseURL+"/api/v1/transactions", wrapper.ListTransactions)
+	m.HandleFunc("POST "+options.BaseURL+"/api/v1/voids", wrapper.CreateVoid)
+	m.HandleFunc("GET "+options.BaseURL+"/api/v1/voids/{voidId}", wrapper.GetVoid)
+	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.GetHealth)
+
+	return m
+}
+
+type BadRequestJSONResponse ErrorResponse
+
+type IdempotencyKeyReusedJSONResponse ErrorResponse
+
+type InternalErrorJSONResponse ErrorResponse
+
+type MaintenanceResponseHeaders struct {
+	RetryAfter int
+}
+type MaintenanceJSONResponse struct {
+	Body ErrorResponse
+
+	Headers MaintenanceResponseHeaders
+}
+
+type NotFoundJSONResponse ErrorResponse
+
+type PaymentRequiredJSONResponse ErrorResponse
+
+type RequestInProgressResponseHeaders struct {
+	RetryAfter int
+}
+type RequestInProgressJSONResponse struct {
+	Body ErrorResponse
+
+	Headers RequestInProgressResponseHeaders
+}
+
+type TooManyRequestsResponseHeaders struct {
+	RateLimitLimit     int
+	RateLimitRemaining int
+	RateLimitReset     int
+	RetryAfter         int
+}
+type TooManyRequestsJSONResponse struct {
+	Body ErrorResponse
+
+	Headers TooManyRequestsResponseHeaders
+}
+
+type ResetChaosSettingsRequestObject struct {
+}
+
+type ResetChaosSettingsResponseObject interface {
+	VisitResetChaosSettingsResponse(w http.ResponseWriter) error
+}
+
+type ResetChaosSettings200JSONResponse ChaosSettings
+
+func (response ResetChaosSettings200JSONResponse) VisitResetChaosSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetChaosSettingsRequestObject struct {
+}
+
+type GetChaosSettingsResponseObject interface {
+	VisitGetChaosSettingsResponse(w http.ResponseWriter) error
+}
+
+type GetChaosSettings200JSONResponse ChaosSettings
+
+func (response GetChaosSettings200JSONResponse) VisitGetChaosSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChaosSettingsRequestObject struct {
+	Body *UpdateChaosSettingsJSONRequestBody
+}
+
+type UpdateChaosSettingsResponseObject interface {
+	VisitUpdateChaosSettingsResponse(w http.ResponseWriter) error
+}
+
+type UpdateChaosSettings200JSONResponse ChaosSettings
+
+func (response UpdateChaosSettings200JSONResponse) VisitUpdateChaosSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateChaosSettings400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateChaosSettings400JSONResponse) VisitUpdateChaosSettingsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteIdempotencyKeyRequestObject struct {
+	IdempotencyKey IdempotencyKeyPath `json:"idempotencyKey"`
+	Params         DeleteIdempotencyKeyParams
+}
+
+type DeleteIdempotencyKeyResponseObject interface {
+	VisitDeleteIdempotencyKeyResponse(w http.ResponseWriter) error
+}
+
+type DeleteIdempotencyKey204Response struct {
+}
+
+func (response DeleteIdempotencyKey204Response) VisitDeleteIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteIdempotencyKey404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response DeleteIdempotencyKey404JSONResponse) VisitDeleteIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteIdempotencyKey500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response DeleteIdempotencyKey500JSONResponse) VisitDeleteIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdempotencyKeyRequestObject struct {
+	IdempotencyKey IdempotencyKeyPath `json:"idempotencyKey"`
+}
+
+type GetIdempotencyKeyResponseObject interface {
+	VisitGetIdempotencyKeyResponse(w http.ResponseWriter) error
+}
+
+type GetIdempotencyKey200JSONResponse IdempotencyKeyListResponse
+
+func (response GetIdempotencyKey200JSONResponse) VisitGetIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdempotencyKey404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetIdempotencyKey404JSONResponse) VisitGetIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetIdempotencyKey500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetIdempotencyKey500JSONResponse) VisitGetIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResetRateLimitsRequestObject struct {
+}
+
+type ResetRateLimitsResponseObject interface {
+	VisitResetRateLimitsResponse(w http.ResponseWriter) error
+}
+
+type ResetRateLimits200JSONResponse RateLimitSettings
+
+func (response ResetRateLimits200JSONResponse) VisitResetRateLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRateLimitsRequestObject struct {
+}
+
+type GetRateLimitsResponseObject interface {
+	VisitGetRateLimitsResponse(w http.ResponseWriter) error
+}
+
+type GetRateLimits200JSONResponse RateLimitSettings
+
+func (response GetRateLimits200JSONResponse) VisitGetRateLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRateLimitsRequestObject struct {
+	Body *UpdateRateLimitsJSONRequestBody
+}
+
+type UpdateRateLimitsResponseObject interface {
+	VisitUpdateRateLimitsResponse(w http.ResponseWriter) error
+}
+
+type UpdateRateLimits200JSONResponse RateLimitSettings
+
+func (response UpdateRateLimits200JSONResponse) VisitUpdateRateLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateRateLimits400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response UpdateRateLimits400JSONResponse) VisitUpdateRateLimitsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorizationRequestObject struct {
+	Params CreateAuthorizationParams
+	Body   *CreateAuthorizationJSONRequestBody
+}
+
+type CreateAuthorizationResponseObject interface {
+	VisitCreateAuthorizationResponse(w http.ResponseWriter) error
+}
+
+type CreateAuthorization200JSONResponse AuthorizationResponse
+
+func (response CreateAuthorization200JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorization400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateAuthorization400JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorization402JSONResponse struct{ PaymentRequiredJSONResponse }
+
+func (response CreateAuthorization402JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(402)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorization409JSONResponse struct{ RequestInProgressJSONResponse }
+
+func (response CreateAuthorization409JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAuthorization422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreateAuthorization422JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorization429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response CreateAuthorization429JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAuthorization500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateAuthorization500JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorization503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response CreateAuthorization503JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuthorizationRequestObject struct {
+	AuthorizationId AuthorizationId `json:"authorizationId"`
+}
+
+type GetAuthorizationResponseObject interface {
+	VisitGetAuthorizationResponse(w http.ResponseWriter) error
+}
+
+type GetAuthorization200JSONResponse AuthorizationResponse
+
+func (response GetAuthorization200JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthorization404JSONResponse struct{ NotFoundJSONResponse }
+
+func (response GetAuthorization404JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthorization429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetAuthorization429JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetAuthorization500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response GetAuthorization500JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAuthorization503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response GetAuthorization503JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateAuthorizationIncrementRequestObject struct {
+	AuthorizationId AuthorizationId `json:"authorizationId"`
+	Params          CreateAuthorizationIncrementParams
+	Body            *CreateAuthorizationIncrementJSONRequestBody
+}
+
+type CreateAuthorizationIncrementResponseObject interface {
+	VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error
+}
+
+type CreateAuthorizationIncrement200JSONResponse IncrementResponse
+
+func (response CreateAuthorizationIncrement200JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorizationIncrement400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateAuthorizationIncrement400JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorizationIncrement402JSONResponse struct{ PaymentRequiredJSONResponse }
+
+func (response CreateAuthorizationIncrement402JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(402)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateAuthorizationIncrement409JSONResponse struct{ RequestInProgressJSONResponse }
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+func (response CreateAuthorizationIncrement409JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(409)
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+type CreateAuthorizationIncrement422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+func (response CreateAuthorizationIncrement422JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+type CreateAuthorizationIncrement429JSONResponse struct{ TooManyRequestsJSONResponse }
 
-	m.HandleFunc("POST "+options.BaseURL+"/api/v1/authorizations", wrapper.CreateAuthorization)
-	m.HandleFunc("GET "+options.BaseURL+"/api/v1/authorizations/{authorizationId}", wrapper.GetAuthorization)
-	m.HandleFunc("POST "+options.BaseURL+"/api/v1/captures", wrapper.CreateCapture)
-	m.HandleFunc("GET "+options.BaseURL+"/api/v1/captures/{captureId}", wrapper.GetCapture)
-	m.HandleFunc("POST "+options.BaseURL+"/api/v1/refunds", wrapper.CreateRefund)
-	m.HandleFunc("GET "+options.BaseURL+"/api/v1/refunds/{refundId}", wrapper.GetRefund)
-	m.HandleFunc("POST "+options.BaseURL+"/api/v1/voids", wrapper.CreateVoid)
-	m.HandleFunc("GET "+options.BaseURL+"/health", wrapper.GetHealth)
+func (response CreateAuthorizationIncrement429JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
 
-	return m
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type BadRequestJSONResponse ErrorResponse
+type CreateAuthorizationIncrement500JSONResponse struct{ InternalErrorJSONResponse }
 
-type InternalErrorJSONResponse ErrorResponse
+func (response CreateAuthorizationIncrement500JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-type NotFoundJSONResponse ErrorResponse
+	return json.NewEncoder(w).Encode(response)
+}
 
-type PaymentRequiredJSONResponse ErrorResponse
+type CreateAuthorizationIncrement503JSONResponse struct{ MaintenanceJSONResponse }
 
-type CreateAuthorizationRequestObject struct {
-	Params CreateAuthorizationParams
-	Body   *CreateAuthorizationJSONRequestBody
+func (response CreateAuthorizationIncrement503JSONResponse) VisitCreateAuthorizationIncrementResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateAuthorizationResponseObject interface {
-	VisitCreateAuthorizationResponse(w http.ResponseWriter) error
+type CreateAuthorizationReversalRequestObject struct {
+	AuthorizationId AuthorizationId `json:"authorizationId"`
+	Params          CreateAuthorizationReversalParams
+	Body            *CreateAuthorizationReversalJSONRequestBody
 }
 
-type CreateAuthorization200JSONResponse AuthorizationResponse
+type CreateAuthorizationReversalResponseObject interface {
+	VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error
+}
 
-func (response CreateAuthorization200JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+type CreateAuthorizationReversal200JSONResponse ReversalResponse
+
+func (response CreateAuthorizationReversal200JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateAuthorization400JSONResponse struct{ BadRequestJSONResponse }
+type CreateAuthorizationReversal400JSONResponse struct{ BadRequestJSONResponse }
 
-func (response CreateAuthorization400JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationReversal400JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateAuthorization402JSONResponse struct{ PaymentRequiredJSONResponse }
+type CreateAuthorizationReversal409JSONResponse struct{ RequestInProgressJSONResponse }
 
-func (response CreateAuthorization402JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationReversal409JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(402)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(409)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type CreateAuthorization500JSONResponse struct{ InternalErrorJSONResponse }
+type CreateAuthorizationReversal422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
 
-func (response CreateAuthorization500JSONResponse) VisitCreateAuthorizationResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationReversal422JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthorizationRequestObject struct {
-	AuthorizationId AuthorizationId `json:"authorizationId"`
-}
+type CreateAuthorizationReversal429JSONResponse struct{ TooManyRequestsJSONResponse }
 
-type GetAuthorizationResponseObject interface {
-	VisitGetAuthorizationResponse(w http.ResponseWriter) error
+func (response CreateAuthorizationReversal429JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
-type GetAuthorization200JSONResponse AuthorizationResponse
+type CreateAuthorizationReversal500JSONResponse struct{ InternalErrorJSONResponse }
 
-func (response GetAuthorization200JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationReversal500JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetAuthorization404JSONResponse struct{ NotFoundJSONResponse }
+type CreateAuthorizationReversal503JSONResponse struct{ MaintenanceJSONResponse }
 
-func (response GetAuthorization404JSONResponse) VisitGetAuthorizationResponse(w http.ResponseWriter) error {
+func (response CreateAuthorizationReversal503JSONResponse) VisitCreateAuthorizationReversalResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type CreateCaptureRequestObject struct {
@@ -555,6 +1443,40 @@ func (response CreateCapture400JSONResponse) VisitCreateCaptureResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateCapture409JSONResponse struct{ RequestInProgressJSONResponse }
+
+func (response CreateCapture409JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateCapture422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreateCapture422JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCapture429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response CreateCapture429JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type CreateCapture500JSONResponse struct{ InternalErrorJSONResponse }
 
 func (response CreateCapture500JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
@@ -564,6 +1486,16 @@ func (response CreateCapture500JSONResponse) VisitCreateCaptureResponse(w http.R
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateCapture503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response CreateCapture503JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type GetCaptureRequestObject struct {
 	CaptureId CaptureId `json:"captureId"`
 }
@@ -590,6 +1522,29 @@ func (response GetCapture404JSONResponse) VisitGetCaptureResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetCapture429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetCapture429JSONResponse) VisitGetCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCapture503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response GetCapture503JSONResponse) VisitGetCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type CreateRefundRequestObject struct {
 	Params CreateRefundParams
 	Body   *CreateRefundJSONRequestBody
@@ -617,6 +1572,40 @@ func (response CreateRefund400JSONResponse) VisitCreateRefundResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateRefund409JSONResponse struct{ RequestInProgressJSONResponse }
+
+func (response CreateRefund409JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateRefund422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreateRefund422JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response CreateRefund429JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type CreateRefund500JSONResponse struct{ InternalErrorJSONResponse }
 
 func (response CreateRefund500JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
@@ -626,6 +1615,16 @@ func (response CreateRefund500JSONResponse) VisitCreateRefundResponse(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type CreateRefund503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response CreateRefund503JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type GetRefundRequestObject struct {
 	RefundId RefundId `json:"refundId"`
 }
@@ -652,6 +1651,87 @@ func (response GetRefund404JSONResponse) VisitGetRefundResponse(w http.ResponseW
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetRefund429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response GetRefund429JSONResponse) VisitGetRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetRefund503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response GetRefund503JSONResponse) VisitGetRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTransactionsRequestObject struct {
+	Params ListTransactionsParams
+}
+
+type ListTransactionsResponseObject interface {
+	VisitListTransactionsResponse(w http.ResponseWriter) error
+}
+
+type ListTransactions200JSONResponse TransactionListResponse
+
+func (response ListTransactions200JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response ListTransactions400JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response ListTransactions429JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ListTransactions500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response ListTransactions500JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTransactions503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response ListTransactions503JSONResponse) VisitListTransactionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type CreateVoidRequestObject struct {
 	Params CreateVoidParams
 	Body   *CreateVoidJSONRequestBody
@@ -661,31 +1741,124 @@ type CreateVoidResponseObject interface {
 	VisitCreateVoidResponse(w http.ResponseWriter) error
 }
 
-type CreateVoid200JSONResponse VoidResponse
+type CreateVoid200JSONResponse VoidResponse
+
+func (response CreateVoid200JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid400JSONResponse struct{ BadRequestJSONResponse }
+
+func (response CreateVoid400JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid409JSONResponse struct{ RequestInProgressJSONResponse }
+
+func (response CreateVoid409JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateVoid422JSONResponse struct {
+	IdempotencyKeyReusedJSONResponse
+}
+
+func (response CreateVoid422JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid429JSONResponse struct{ TooManyRequestsJSONResponse }
+
+func (response CreateVoid429JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateVoid500JSONResponse struct{ InternalErrorJSONResponse }
+
+func (response CreateVoid500JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response CreateVoid503JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetVoidRequestObject struct {
+	VoidId VoidId `json:"voidId"`
+}
+
+type GetVoidResponseObject interface {
+	VisitGetVoidResponse(w http.ResponseWriter) error
+}
+
+type GetVoid200JSONResponse VoidResponse
 
-func (response CreateVoid200JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+func (response GetVoid200JSONResponse) VisitGetVoidResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateVoid400JSONResponse struct{ BadRequestJSONResponse }
+type GetVoid404JSONResponse struct{ NotFoundJSONResponse }
 
-func (response CreateVoid400JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+func (response GetVoid404JSONResponse) VisitGetVoidResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type CreateVoid500JSONResponse struct{ InternalErrorJSONResponse }
+type GetVoid429JSONResponse struct{ TooManyRequestsJSONResponse }
 
-func (response CreateVoid500JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+func (response GetVoid429JSONResponse) VisitGetVoidResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.Header().Set("RateLimit-Limit", fmt.Sprint(response.Headers.RateLimitLimit))
+	w.Header().Set("RateLimit-Remaining", fmt.Sprint(response.Headers.RateLimitRemaining))
+	w.Header().Set("RateLimit-Reset", fmt.Sprint(response.Headers.RateLimitReset))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(429)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetVoid503JSONResponse struct{ MaintenanceJSONResponse }
+
+func (response GetVoid503JSONResponse) VisitGetVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(503)
+
+	return json.NewEncoder(w).Encode(response.Body)
 }
 
 type GetHealthRequestObject struct {
@@ -715,12 +1888,42 @@ func (response GetHealth503JSONResponse) VisitGetHealthResponse(w http.ResponseW
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Restore the startup chaos settings
+	// (DELETE /admin/chaos)
+	ResetChaosSettings(ctx context.Context, request ResetChaosSettingsRequestObject) (ResetChaosSettingsResponseObject, error)
+	// Read the chaos settings
+	// (GET /admin/chaos)
+	GetChaosSettings(ctx context.Context, request GetChaosSettingsRequestObject) (GetChaosSettingsResponseObject, error)
+	// Replace the chaos settings
+	// (PUT /admin/chaos)
+	UpdateChaosSettings(ctx context.Context, request UpdateChaosSettingsRequestObject) (UpdateChaosSettingsResponseObject, error)
+	// Purge a stored idempotency key
+	// (DELETE /admin/idempotency-keys/{idempotencyKey})
+	DeleteIdempotencyKey(ctx context.Context, request DeleteIdempotencyKeyRequestObject) (DeleteIdempotencyKeyResponseObject, error)
+	// Inspect a stored idempotency key
+	// (GET /admin/idempotency-keys/{idempotencyKey})
+	GetIdempotencyKey(ctx context.Context, request GetIdempotencyKeyRequestObject) (GetIdempotencyKeyResponseObject, error)
+	// Restore the startup rate limits
+	// (DELETE /admin/rate-limits)
+	ResetRateLimits(ctx context.Context, request ResetRateLimitsRequestObject) (ResetRateLimitsResponseObject, error)
+	// Read the rate limits
+	// (GET /admin/rate-limits)
+	GetRateLimits(ctx context.Context, request GetRateLimitsRequestObject) (GetRateLimitsResponseObject, error)
+	// Replace the rate limits
+	// (PUT /admin/rate-limits)
+	UpdateRateLimits(ctx context.Context, request UpdateRateLimitsRequestObject) (UpdateRateLimitsResponseObject, error)
 	// Create authorization hold
 	// (POST /api/v1/authorizations)
 	CreateAuthorization(ctx context.Context, request CreateAuthorizationRequestObject) (CreateAuthorizationResponseObject, error)
 	// Get authorization details
 	// (GET /api/v1/authorizations/{authorizationId})
 	GetAuthorization(ctx context.Context, request GetAuthorizationRequestObject) (GetAuthorizationResponseObject, error)
+	// Increment authorization hold
+	// (POST /api/v1/authorizations/{authorizationId}/increments)
+	CreateAuthorizationIncrement(ctx context.Context, request CreateAuthorizationIncrementRequestObject) (CreateAuthorizationIncrementResponseObject, error)
+	// Partially reverse authorization hold
+	// (POST /api/v1/authorizations/{authorizationId}/reversals)
+	CreateAuthorizationReversal(ctx context.Context, request CreateAuthorizationReversalRequestObject) (CreateAuthorizationReversalResponseObject, error)
 	// Capture authorization
 	// (POST /api/v1/captures)
 	CreateCapture(ctx context.Context, request CreateCaptureRequestObject) (CreateCaptureResponseObject, error)
@@ -733,9 +1936,15 @@ type StrictServerInterface interface {
 	// Get refund details
 	// (GET /api/v1/refunds/{refundId})
 	GetRefund(ctx context.Context, request GetRefundRequestObject) (GetRefundResponseObject, error)
+	// List transactions
+	// (GET /api/v1/transactions)
+	ListTransactions(ctx context.Context, request ListTransactionsRequestObject) (ListTransactionsResponseObject, error)
 	// Void authorization
 	// (POST /api/v1/voids)
 	CreateVoid(ctx context.Context, request CreateVoidRequestObject) (CreateVoidResponseObject, error)
+	// Get void details
+	// (GET /api/v1/voids/{voidId})
+	GetVoid(ctx context.Context, request GetVoidRequestObject) (GetVoidResponseObject, error)
 	// Health check
 	// (GET /health)
 	GetHealth(ctx context.Context, request GetHealthRequestObject) (GetHealthResponseObject, error)
@@ -770,6 +1979,217 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// ResetChaosSettings operation middleware
+func (sh *strictHandler) ResetChaosSettings(w http.ResponseWriter, r *http.Request) {
+	var request ResetChaosSettingsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetChaosSettings(ctx, request.(ResetChaosSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetChaosSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetChaosSettingsResponseObject); ok {
+		if err := validResponse.VisitResetChaosSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetChaosSettings operation middleware
+func (sh *strictHandler) GetChaosSettings(w http.ResponseWriter, r *http.Request) {
+	var request GetChaosSettingsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetChaosSettings(ctx, request.(GetChaosSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetChaosSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetChaosSettingsResponseObject); ok {
+		if err := validResponse.VisitGetChaosSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateChaosSettings operation middleware
+func (sh *strictHandler) UpdateChaosSettings(w http.ResponseWriter, r *http.Request) {
+	var request UpdateChaosSettingsRequestObject
+
+	var body UpdateChaosSettingsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateChaosSettings(ctx, request.(UpdateChaosSettingsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateChaosSettings")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateChaosSettingsResponseObject); ok {
+		if err := validResponse.VisitUpdateChaosSettingsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteIdempotencyKey operation middleware
+func (sh *strictHandler) DeleteIdempotencyKey(w http.ResponseWriter, r *http.Request, idempotencyKey IdempotencyKeyPath, params DeleteIdempotencyKeyParams) {
+	var request DeleteIdempotencyKeyRequestObject
+
+	request.IdempotencyKey = idempotencyKey
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteIdempotencyKey(ctx, request.(DeleteIdempotencyKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteIdempotencyKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteIdempotencyKeyResponseObject); ok {
+		if err := validResponse.VisitDeleteIdempotencyKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetIdempotencyKey operation middleware
+func (sh *strictHandler) GetIdempotencyKey(w http.ResponseWriter, r *http.Request, idempotencyKey IdempotencyKeyPath) {
+	var request GetIdempotencyKeyRequestObject
+
+	request.IdempotencyKey = idempotencyKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetIdempotencyKey(ctx, request.(GetIdempotencyKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetIdempotencyKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetIdempotencyKeyResponseObject); ok {
+		if err := validResponse.VisitGetIdempotencyKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResetRateLimits operation middleware
+func (sh *strictHandler) ResetRateLimits(w http.ResponseWriter, r *http.Request) {
+	var request ResetRateLimitsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResetRateLimits(ctx, request.(ResetRateLimitsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResetRateLimits")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResetRateLimitsResponseObject); ok {
+		if err := validResponse.VisitResetRateLimitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRateLimits operation middleware
+func (sh *strictHandler) GetRateLimits(w http.ResponseWriter, r *http.Request) {
+	var request GetRateLimitsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRateLimits(ctx, request.(GetRateLimitsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRateLimits")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRateLimitsResponseObject); ok {
+		if err := validResponse.VisitGetRateLimitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// UpdateRateLimits operation middleware
+func (sh *strictHandler) UpdateRateLimits(w http.ResponseWriter, r *http.Request) {
+	var request UpdateRateLimitsRequestObject
+
+	var body UpdateRateLimitsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateRateLimits(ctx, request.(UpdateRateLimitsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateRateLimits")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateRateLimitsResponseObject); ok {
+		if err := validResponse.VisitUpdateRateLimitsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateAuthorization operation middleware
 func (sh *strictHandler) CreateAuthorization(w http.ResponseWriter, r *http.Request, params CreateAuthorizationParams) {
 	var request CreateAuthorizationRequestObject
@@ -829,6 +2249,74 @@ func (sh *strictHandler) GetAuthorization(w http.ResponseWriter, r *http.Request
 	}
 }
 
+// CreateAuthorizationIncrement operation middleware
+func (sh *strictHandler) CreateAuthorizationIncrement(w http.ResponseWriter, r *http.Request, authorizationId AuthorizationId, params CreateAuthorizationIncrementParams) {
+	var request CreateAuthorizationIncrementRequestObject
+
+	request.AuthorizationId = authorizationId
+	request.Params = params
+
+	var body CreateAuthorizationIncrementJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAuthorizationIncrement(ctx, request.(CreateAuthorizationIncrementRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAuthorizationIncrement")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAuthorizationIncrementResponseObject); ok {
+		if err := validResponse.VisitCreateAuthorizationIncrementResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateAuthorizationReversal operation middleware
+func (sh *strictHandler) CreateAuthorizationReversal(w http.ResponseWriter, r *http.Request, authorizationId AuthorizationId, params CreateAuthorizationReversalParams) {
+	var request CreateAuthorizationReversalRequestObject
+
+	request.AuthorizationId = authorizationId
+	request.Params = params
+
+	var body CreateAuthorizationReversalJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAuthorizationReversal(ctx, request.(CreateAuthorizationReversalRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAuthorizationReversal")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateAuthorizationReversalResponseObject); ok {
+		if err := validResponse.VisitCreateAuthorizationReversalResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateCapture operation middleware
 func (sh *strictHandler) CreateCapture(w http.ResponseWriter, r *http.Request, params CreateCaptureParams) {
 	var request CreateCaptureRequestObject
@@ -947,6 +2435,32 @@ func (sh *strictHandler) GetRefund(w http.ResponseWriter, r *http.Request, refun
 	}
 }
 
+// ListTransactions operation middleware
+func (sh *strictHandler) ListTransactions(w http.ResponseWriter, r *http.Request, params ListTransactionsParams) {
+	var request ListTransactionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListTransactions(ctx, request.(ListTransactionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListTransactions")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListTransactionsResponseObject); ok {
+		if err := validResponse.VisitListTransactionsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // CreateVoid operation middleware
 func (sh *strictHandler) CreateVoid(w http.ResponseWriter, r *http.Request, params CreateVoidParams) {
 	var request CreateVoidRequestObject
@@ -980,6 +2494,32 @@ func (sh *strictHandler) CreateVoid(w http.ResponseWriter, r *http.Request, para
 	}
 }
 
+// GetVoid operation middleware
+func (sh *strictHandler) GetVoid(w http.ResponseWriter, r *http.Request, voidId VoidId) {
+	var request GetVoidRequestObject
+
+	request.VoidId = voidId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVoid(ctx, request.(GetVoidRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVoid")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetVoidResponseObject); ok {
+		if err := validResponse.VisitGetVoidResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetHealth operation middleware
 func (sh *strictHandler) GetHealth(w http.ResponseWriter, r *http.Request) {
 	var request GetHealthRequestObject
@@ -18,42 +18,123 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9Ra63PbuBH/VzDodSaZoSzq5Yv0Lcm1V8+lrce5y5fY1UDkSsSZBHgAKEf16H/vAOAL",
-	"FPRw/Lj6G1/YXez+9gne44hnOWfAlMSze5wTQTJQIMzd+0IlXND/EkU5u4j1oxhkJGiuH+CZ+wG6+Am9",
-	"WXKREYVIoZL5dRGGo6goaGyu4C0OMNXLcqISHGBGMsAzTDpcAizgj4IKiPFMiQICLKMEMmLlUwqEpvEf",
-	"w+Jr2JuS3vLm/t22V1+PT7geDLc/4ACrTa5FkEpQtsLbbYA/klwVAny7LV+19xmR/NRtRjXhEzeoaT/9",
-	"/i5iyHKugEWbX2BzVQvS3exvjP5RALqFDVpygWi1TCEtPEgl0ZuMfEPDyQRFCRGy3nYCJAbRbLzFsfcL",
-	"bA5uPyPfPgFbqQTPhpNJgDPKqvuBbzdXsCxY7DOWfdO2lYDlqbYSFdkTTaVJP7Wptpq3zDmTYJzxA4mv",
-	"rOb1XcSZNoa+JHme0sh4T/93qTd/35LyBwFLPMN/6TeO3rdvZf9vQnBxVTKxLF0lfiEpja1zc4EWhaQM",
-	"pEQpX9EIgV6NNaSY1gNJDbmXE65iiySINYhGnn9x9XdesPjlRLkCyQsRAWJcoaXhvQ3wJdlkwFTbx15K",
-	"M7JYLmlEtbtqJEvjLOX6nchek9KgFjwHoajFHMl4YaWFbyTLU8Cz6XQ6DbB1KTzDlKnzcYNeyhSswFjB",
-	"CetzGjtUzNv5ZBLCu3EY9mA4XfTGg3jcIz8Oznvj8fn5ZDIeh2EY7npGgCMBREE8J0a0WpaYKOgpmoF3",
-	"TSGEDkGuGL99/sn3MXzLqQD5IAZSEVUYrQErMjz7qi0s+BpifOMLXU1g+bqrq5pcUNmgtQNHPkcbDSO+",
-	"+B0ihZuE9vqMbOXeIarT4gk0BwdoPiNydlFQ8TyOgtaOgwdDor01LwwMSjp+X6eTfYjolHvmOaIMRaZg",
-	"DI4iJqOMZloPAx96IiLiOSuyBQhftSViZF+iN5+KhKG1zUYQv21zxuPJaBgOJoPB8N0oHJ2fa7ZNGTGY",
-	"ulXEKGgn7uvr+H4wCgZTXwoOcLRe7xFsDYIuy/CtBSvAkWkwHLlijB0pdoUYBWO/CMbVN/OMM72whcDB",
-	"0DAo1Ts8puuSzgaIcMgMw+GkRWgYTqctUsNwON6ltoPbxoxWZx2xXe41fvfDtI5ZjwMoepMVUqGMqChB",
-	"jkO9fTR2fZHvSHOkOCq91MHKQ6Lk8/c/R9LSUdPZuvtJLVcq7e0TxJt2Stnb3CmObAPgmOnEvNOx0vM0",
-	"cfuzxjHzfOH0gHG+C9NrTuPXCmifokx5/ZHH0M7ilJnsM9exzvSM5a0JdtVdk5d1RLRFmv26qcfnth7X",
-	"UJWSstWcNu3x/Na0x66UjKu5bSm6bxoG7nOSCiDxZl5I+7K8rQuR5pE2nfPA4h4aKM0zKo0XNiWGI5Fd",
-	"4DxqX9OyR5vb5uzGk+DcbmYHk1A1lUc7ImOybYAzkJKswC3X3q8JTckiBbQgKWERICpRqptZlRBWzTQg",
-	"RrUNDyPJitUw8wHpH0BSlezf2m6tmJgVGgMFq66Plo0lGZ8EVTB+jtL/Werzh5TaJfS6/AUsT+E/2k/y",
-	"gf3BrhlrLzpqu2YPgRvID9b6bTF9ZrdBfq/Rn69n21VFGWF8jq9f7bA3D09gP8R7KD7IeB1rVBId7r4a",
-	"Lru61xQpW/LdzPlrQqWOOQRlPLpFC8Ju0fvLCzNcze2gCK2IgjuyQcbLhM2xCqSibHV2zS4UkjQrUqJA",
-	"Ip1h3JI2qOqkwGTkABEWl3UM0uY3H8mza2YkMUJ8qITIBV/TGCRaEEkjtCxYpL8mKVUbneK1ELWUy5Tf",
-	"SXRHVcILhQSQFGWcwQYpQZgkUcXnmr1PU3T578+/ImBxzqmu60p1I8JQZy6M7Nz47JpN/or4shkz39E0",
-	"RYKwmGfpBi0JTQ1zNAlDO/PTzDSrekVC1oAo0yaBGGmFsWiDFqDuABgahGFvGIZhptdpTFBloGe08U+t",
-	"l/eXF9rOIKS13eAsPAs1wHgOjOQUz/DoLDwb2folMYDvk5z214O+YxN7psKlp+C9TEkErgVRwtMYcYZI",
-	"FJlq2JQKZ9jwFfUhjK+VN5I0Rzdf/emy+aS/5xhge2M9AqT6wOPNk40sD0wftq4XKlFAd/Q9DMMnk8Q/",
-	"+fQMUd1StxyyaRCMrTQ+JrXU/da03iwZHl/SHRdvAzw5hZU7fjeD3iLLiNjUUPHATOOerDRU3I3iG03A",
-	"D+b+feeobquFW4GxiAvRn0E9Dp/do0cLzP8vTNSD/nE4Pm6m+lTCtdDPoDrmiUERmsrTLFTG/AOBpmps",
-	"CcoFrCkvZLqpOUJs0HCGyv5737xkXxD6WM8zXkH46UyVXjjwdOfwHnhVpnpcsHl80KgQ0/HgCo6V0b1A",
-	"7N/XB90Hw8P3Iqc5n3/WkPAAaz1ZGCgV5wkAXo3b2u6A55dH4KQiHFclnM/dy2/2OfpVNRB7BX7ujiBf",
-	"2M07Lbf3sNiY5U928kqK2g0rrJWW9kGtf1/9GHHQtb8TK/W/HM/q2Cfb58ncuuzBdr3ap2nduh1M5iyC",
-	"VHdPns5hAUuus8dhT/5ix7WvwI/bs+oX9mJnguL7MYbTP92DjQz7crSxskWWHSQeclg7qMTPqM/OKNSj",
-	"UfsFKqcsRj+jF2T/GcSaRoAKRqpJcUfdpYBRAtFtS9Gl7m4MTfsnkvUol/4nHpEUxbCGlOdmjGK/xQEu",
-	"RIpnOFEqn/X7qf4u4VLN3v347kfjYCWne7/CCItLpTVTluZHtlK6beA9xHZjSDMkata7nccumbJnrUsX",
-	"H42qeNld7bZTOvR5CRgs766+6s62mhVlZN3ebP8XAAD//5hqSvvhKgAA",
+	"H4sIAAAAAAAC/+x963LcNrLwq6D47VextyhpJEtJLP9SbG+iip2oJCe7VZHPCCJ7ZrDiAAwASp516d1P",
+	"NS4kSGJmOLqtfSr54Wh4ARqN7kbf+TnJxLwUHLhWyeHnpKSSzkGDNL+OKj0Tkv2Haib4cY6XclCZZCVe",
+	"SA7bD5DjN+TZRMg51YRWejY+r0ajF1lVsdz8Bc+TNGH4Wkn1LEkTTueQHCa0M0uaSPizYhLy5FDLCtJE",
+	"ZTOYUwuf1iBxjP8xU/wx2npJtyYfP39/u1X/vT/g7929278laaIXJYKgtGR8mtzepslrWupKQmy17la4",
+	"zoyWQ5eZ1QMPXCCO/fDrO85hXgoNPFv8DIsTBLG30OCZrZ9hQa5pUQFRwDW5XBA9A5IVDLiOL5S1Zli5",
+	"2jn99A74FGHYOzhIkznj/vfueuBP63G7C/iNsz8rIFewIBMhSQ2RJggLKK3Iszn9RPYODkg2o1LVezYD",
+	"moNsFtNBxYOu5hQmFc9jlGbvhIQmYTKU0KQfdiCd4dAPT2e/CxZdGl4PF3YtWD50ZdeCbbAuM/JDL+wW",
+	"J1el4AqMiPyB5qeWpPBXJjhSGf5Jy7JgmZFpO/9WuPTPAZh/kzBJDpP/t9OI3x17V+28lVLIUzeJnbKD",
+	"Qlqw3IpcIcllpRgHpUghpiwjgG8nEV6pFORPB2NXhNxQRWghgeYLgpCQG6ZnhJKcTSYgA84klyJfGPg5",
+	"biQtzFRPCLibliiQ1yAbfL6nDOenPIOng+bDDMgl5VeEKZKLG27EGb6dVwXkZB7AlDrZZcjyFLRcbB1N",
+	"NMg+C55BJniuSMU1K4w4D8YhN4zn4oYAz1UScpbjBHxwChKBvU2TX4T+h6j4ExLWKShRyQwIF5pMzNy3",
+	"aXJCF3PgOjwRnopcVDWZsAyPQ4JyVyVGshtaPuYnUkwlKPV0AB3VjGQ4DHdX0TmQLkMyRZRmRUEugfEp",
+	"KaXIQKGI2JyOtCA3lGlyCRMhgUh8B6XlWur5IMR7yhcOW+pp2cqqMFarmVu4nWqgZ5QTphWRVAMp2Jxp",
+	"QotC3KgOcqiGd3h3y/wbO8brEf183yhyWWVXoMlMFLkiNzPgZFIVxWpspcFkp4DsiggeMiHJKHc7rYDn",
+	"RLLpTBMubjaZUIEeIkbcypgyKyJ0ShlfO80jUtitv9+3Zt5eOyorpShBamaPczoXlb0On+i8LABVudEo",
+	"Tay2Ysf/dj9Je9OlSSaBasjH1Lxfv5BTDVuazaGvTaQJy1tzJajzHxyM4Pv90WgL9l5ebu3v5vtb9Lvd",
+	"b7f297/99uBgf380Gu3GxrIXPifAq3ly+EfCeCZhbtV0Z3wY1ekapKJIb6ggJR9jylujX/2BILpHUo+e",
+	"1lqbAcTlvyHTCEoL1TUjrsB23KyEnNhHCOMkMzZq2iDr5cuXLwdtTMvAHHdRjneH4HwUw7nD65jlKmJG",
+	"vVFETCwv2ucU0fQKuOUMpYmeMUVa4IUL/GM4OXxME6ZhrgJG6BJGQqWkiwDofLwM/x+EpoWHOSdKkAmV",
+	"0T14PM7IKinxsGpv1m9nb2IPw6eSSVAbTTBjSgu56K/+HeRTkAS4lgwUkZAJmUO+YtNSIoocj9wJk0on",
+	"wV6sOqUi8iiyWXPQNKd67Zn33j9n2NedEEt3+KjNVu6AmEGRE8pzQq8pK+hlASh5G9mxOetZabOW1CQU",
+	"QFVDapcLUlKpmbll5ZW6B/0pTXUVYdALWpZSXEN+gWeWBF1JDrk9k5FrW5uMjzhC3ibvhLiqSnynFBJJ",
+	"As65pVhNCjaBbJEVQHBeOCQXNNPsGi7IM4tmi1BE7/OUXHg2u0jJBUpkyC/O+bMaI6jFiUr7XXiOJt+F",
+	"pff8YvvcCAwn8P1qkjSxMzaCP3fi3vzh3u6L/jT5tIWDbV1TyekchfQfyVEz6pEftUW6r5spWtd/9/O9",
+	"9fPdWiCcCO4KSy8r8ZGUsElkC9CKDJbhpYIZdICc3EvWHXa9o6ImnvDw68jPPplHWDCQaC151ZKO7QOl",
+	"kVGxM9ahfcjp+oUemQ+n+jQ78lgnzN3EMBqEyOcbyuGW+LWj3FH64qsDpK997P4HfSNovUyq5c9aPTOg",
+	"i3QYH3aXF8N4i+1CMomy1IwKdSLFhBURfprQqtBjSbX9SfOcIXS0OGk/VhOfqC4LSFL0C7M5omPX+ITt",
+	"36N6fl7NLy362pvzD0kz6+SbBG7rEdLE7nM0UjWZgiZAsxkxsJErxvND67FK7f/GFA2rcSbmc6ZTMqN8",
+	"mp5zCQp0SrSseGZED/rcUjKnBQJf/76k+XhKNdzQRUoqXlNlStzVMbKWqHRqlAZzCOqZBEBvwqeFhUB5",
+	"T9/YWG0WIGKcJp+2CRriBqNkTheE5jmpSlwgRZtcabLrTjhPhp8TsO7A0fbowLhiQZsfu7eR/ZzTT+OC",
+	"Gp/H2OpjjVU3Go2iuxFQ85zxZa/vrnu7Q9wh7fQG7gG6lDZPRaW/BMo01Gb3DR2Sc6qzGXqRPJmmhBp9",
+	"rcVQ8W08GLZxa3YK9ExElIqfPnw4IfYmUpWB8xWhfOEvsgkRc6Z1R6E4+fXsQ/QUYHwjuMpoeA2DbgE4",
+	"F3+/QE2R5xaZggPB94iC6Ry4TglsT7fJDi3ZzvXuTksyqp2/79RmfkteJ/55b3muVX1cqOWBKPXMrPdM",
+	"Q7mEXPto+Uctw2LkQ9qANQu1lBQ/socQRYbnxH2IYsUmu2hUPU1rYYaZ7cRmv5dMu34j7T66EFQbin/O",
+	"WDbr8SeZUFao1MKE1ydSzMluOO2LQCDsrhVvfn5HPivIAjROqPpEkUNNFqv0q5ZEQSgQiRHT7gTklrlH",
+	"SvuwwvOOQY7oR3tevjL2hbHYLYLQmCtoBtZ76uEZaMw3Oxoz4i1kEf+m+RtyS9zKHuoODAOFpDwXc5QJ",
+	"6pAIXtjoe60NFEzh2zOQYPbUHMTn3DGo0yYnwviuzZseGdukdhWb6RaQWyKgnATxexNBp9IGWwy1QG5P",
+	"5OFICSRBDDMQi9+fAcIjZIgDvyjKPbqs+mCiGziMuWO2tqUxTdk1WN3EPJkhTNvkV3TqC2l94iMzF/Uz",
+	"4WCvSB3mJbS4oQtF1EzcGIDMZIxjHLOjnuzvBWpytUxP7jBPQ2mOmKPcY4zEjl+1ZvqBbtWNfamrRECa",
+	"ZFTmY6cjRJJlZE7sTfLsXTXj5NqGrSF/Hs6c7O+2/7OHTJ048bKdR/EiDUP85+f5590X6e7LWKw+TbLr",
+	"6yWAXYNkExdcsuktLZh29160wdhvQdEH4kW6HwfBWPqL8Vzw2CHxvqqFj/USy5wITpBFUyL0DOQNUxDy",
+	"vBXdVqdm3KB0bOcIF7C7F+p2e+s20gG5ACqfDsa90YtRAOXe6OXLAM690d7+Ek1vQzu8Z2Y2RGsppLNJ",
+	"bXTU5uZypqwdMfdjR/LMmj4Wkc57VPu/6lDI83uzbcy3syatL+4F3sgP9MiZe2kyYZwWYw9mqFFMaKGg",
+	"n0dgHKxG/cJQLClgopGw+15Ha7Iat3+DBTf/pRAFUP4gpBlxeqwlvmOv/d+b/NDqznNPgYiSJF0bBd1A",
+	"R1y7FJv09jhs1DiGHoyN2n7MpZmiUQ/eUGdnh3EeISP0QSRq6Lhbv8s2nvMQ9OqCJHeRnE9Azxj/WL7K",
+	"OwlhE7H/YiXwwwvAGHJNcs9rkXdyHayagad7kjY/r6+DX7UO4i8EER3pboN9vUnqGtukLiQNpTCeE5hH",
+	"4yuTEdy5MpY217I2y8eMj0ufCNY9f7nQY5vG1r3TwNO+7tIox24S/zOI9PlLdajMX/Ae85pRx3OmjG4X",
+	"hJ7gUwaQq3E3L8FHYpc+4AcIl2RnrF8J81DMjfBZE8YLL/h9+rOC1r7VhNZcMmbdWHn3QvdGndbtL0uq",
+	"YWxSu8KXmqsGR+30yjZkNkt0bL1PHyPc0E5C6wkA8GmtaxPZDK0b9lKKTqEdrjqqQ0WXtEBAMUxdgHJZ",
+	"bI4Caxm41gvovWl+shgH/gS00LPlS+tHgWbmDdzCijd/5zCVNO9hOoZMk2MWc2+5SH3wPlr1fuROMuuQ",
+	"uGAHH24tMTS0E6zfMaWXo8SLxUFuk27mdiZk3veddAA1M6wH043WA/BOyWuBWOuHFme4E2yKWnltGC5L",
+	"OjWumxlVhAuitJCQ1+4XsgAdVbdR9saynbzQnVEVsbjPfjra2jv4tsnL4oKzLAARQ1+vCMxLbWtHrmCh",
+	"PEwu/XDC+BRkKZlxnq7wx469a3hTb65dugnDxZI8HWbwduC+E85GsYfS8lGXZcMYv7i9uW7UZTrRVV0h",
+	"U6++syFtqulD1V392lTDwADaOM2wo1HSHEWGqXBi6KZv0ic3TwZ9xASKRrFdGtf/BW6INrF9k9LlirY6",
+	"6UwRN+Du3v7A1IInTunrlndlGMmGnJinFp6buwrJUDHmNrq3TYxnQ3bpRZzdHjkHb/he9c/jeslDEjNa",
+	"+BmYmtGn0bumRMV4/n1gaCwLdgfOW8OzvQ3qJlsAbCEuUdDt2ILHkjJZy/66mkJLypXNzHChWRNzGDO7",
+	"bVmltJib39vn/DdjmO6NzDHyyvzrMhz2R6bykGYapEqtD9rfOxiFN3spEMEUySH+Gu8ffJukiYcDcSXz",
+	"MTqxbSw9xMzeKILQOte/LzwvK6nWlTbEKxpSwrZhm2DUZI5etoxycunKSKkmwmrVgT94XT6GD+mMS5Bj",
+	"ZUoDIoBRDdF6CwkTVhSKUP2KjMgcKDfqhtH2W3w1SmOZEsuzI+Kx0BaUqUPjx1W4X5LcUW9ADeFB+oWk",
+	"Q3zR6Q1L6aWhuPttdFu92Xy/7x0Lr0caFgg3lB4Jg5sEMlS+mVYEawwdw5QggzrvIYZLh5TXmixDAp/e",
+	"K/wYWa6Pkor6NFmlPZixivvuuopPpNxAm+trFX6Y9SpFs4a07T6OJFs3KkIIZpxUvGv5vpZAnX3vjYGg",
+	"auqLsgU2orZHV0m/29+oLIQWESq+HoKL/fjyfBL+PanYDjOEiptVDE5ZXqUDhyuIEfiHRvV8QHdTMGo9",
+	"YiRTZ0bVeC5saLXvjeHwSY+zSiohYxqBUoQqcmEfuEDywdRlVNPwRVLSKWbCXRrd0MVgC6rsjbU+OucK",
+	"rgFcg7qNzpLd0Wj0hRS5PeQpdceDBxBaGBCuEtLLBys/A6OJXEIh+BQDeHcOZa1g44GUfmZfCMpmB774",
+	"AR9fVSO78ghbY9j2AQwEU1PTJRBl+g61XL3x64qu3p3XwSS9m2FFVxc3IcQdf8ywimR/0G++Jpz/qDNn",
+	"5/ZxAELn1usaos6N0wbAzp3fLby95y34rgvOQ6ojNLvykW6amYRM79zrhoa/iFKvx9aF+6e3C3nGYkhB",
+	"6eGDlA76isoNJH5HbHiIBioPgRxpZu6LkVvj05yIWEwG/dqKUDIX2ZVtLHN0cmzs8tJ2UPGlPcRQiLTS",
+	"XINCS3X7nB9roti8Kkzph0kF7BRCO7ZOXRUnao2WmwkSvnkIHVoGEgPEDx4IrDJlOShySRXLsJdKZv16",
+	"TC8MzYPSNZQTk9Hsa2Ml0ILMBYdFeNDgPOf8qCgI+i8I8LwUzLKT2QJCea8rio0Tb5/zU9diotNFBfOh",
+	"bWzEer6CCJdl8hTjBkWV46s5ZAXjoM75s/3RKCX7oz3856XByv7eHrKus4Gfb5OzoN+QsknXKHfqUIwB",
+	"qsIUBEINHCs7KLlqZmXmiScobJ/zI5JXtsmK88vdzLDUorWyOmbnonUGKRR9BUg2TKtm7edcyGbi0UsS",
+	"SYCwYAddP9DpxLRpjIA6gSCoHWyf84P/30rjvkEQbIJ2sTAZpnYk9JVanG3bza7fmNFrIIwjU0Bep49f",
+	"gr4B4GR3NNrCIrA5vlfHkQ1LyIo7MF//dPTr2fint0dv3p6ejd/+cvTDu7dvUkJrVGfUtGTKoHaeiEpn",
+	"Yg7n3Azwr633IrvasqUtz4ynq66ZQ/MR/9ky+YVbvkaPCw7PDYm4l99ZyLfeq1fnnHKfUmtdxikxmet+",
+	"daaA4EZURY6AGBUalxWm9BfIdR6Ic95s14jEciYQO2bHjj68Hb87fn/8YXx6ckZMAaHxHLk2M88uF+Rf",
+	"W0cnx8hHBiooFJDjk+dIQU0nHRvsPedT0EicL0mYc9EnDmFSGkybntySx4QqvX3O31TSskK0G5YP/D/7",
+	"9bcPRz++Hf/z+Jc3v/7z7DlBlloYeZPRokArRJ3zg9GLzsttKF6R3M/WzSxAQjSNPXDD/C5IZioDfnz7",
+	"gezYdAfXKAC941iQ4+JV+kYQE2kkMJlApq2nXzNtjiYjGZECEFyU+SCVleO726PtER5AogROS5YcJi+2",
+	"R9svbJLXzByIOzSfM75jttKeBKjN9c+EH0VLq/DJMChTXUkIXgZ+zaTgcysjQmZRmkqsCknSpJbwx7kN",
+	"U4NuF/10euftjUYP1vKpPVGk5dNZszAb0TGnsarmcyoXFly8bJGAa6pKWytCgvwgTae2JwLi1ih4U9Dx",
+	"3AdHGK5KMqQOe4zrYkHMcsH44pEim+rJ5mAwb5Z4DKCLtI/lH78kHL+2K+virYtomtsAzXrsllVMOy7L",
+	"YkE43HRGaFJAmz5emlApsQDIUDIXN0TwV80TLi/unMuKm6TRK4CyzQd6BgtP5EYw2Kqjm5kogGSCT9i0",
+	"knWDEFe7Zc9Wf0jU5XeCA7L+hXV3XxjpY6E0V75Rvj6LMK404gmfdpRwYdQmaIqU/qwAhZUrvSYXFkcX",
+	"FljbraZh4EuYMrPEFKv8UZoiQE1Z1IUCbINi9TWPHTOEhFKKvPJ1cUER1TnvkeNvZY4VET2KNCP+4NJZ",
+	"HosY211Bb78IaeNYHIX1/mi0bNwa0J2gn2iXbZqqwLWcc5t6+R+c/FsYfd753G7Pe7vqbHhjruMZa2Pg",
+	"qHUq0bjtWp0GmbaKk6WagICtAmvth5NKTgMNdiYU9JPDGCe1tmj77hEaKKpa4ETIe7UkjZGiBf6424w4",
+	"7HD9R3w3mkd2It2Sb9Mumn7FusyyklNwZ6WWiyZxyi8PT+ZXuB7zl2rHWhmO49Nc6z6+rTSqhjI3KvL+",
+	"2GOD/f5G/wxuAY5Q99cTat358zZNDoZQdrufa5u4TwzyajrrVKAOP3jPMNngBgVqoJ+YIXE7+vZeakSy",
+	"3TBzyAZ7hQSN/YZMt1rBMZlDwaQqrP5Dvd7rFbuQQoUkFYdPpbU86lw6j4sYtf4I+hFI9eMjysAV6a8x",
+	"gWi3wTdV87W9uLv/FYo75gq3ZxOaa4SqRA+PDadvoFrbFx5Isa5D7Y+q8fVzFSJb+86va7Be3ViDGyrV",
+	"nj8dKqOadGCUbqhIf0E49Zp0iKklavQ6ZK7RoYPXhyrQSzXgcx6owAbh1tz2rXZx+72C4LxXplGsTTjZ",
+	"JnGV+ZyHOrNxequgGWOgPZtlLNOdl+iqnU1/eEV1yX4/nbK6CRM/irq6mkJv01qNaSd/4eyliCUfnpih",
+	"W0/bGl7B67CIKdvqs3mkj8I9T9q657g9bR/B0lne+uGJKSne1jfWhLy1NS7oeSeiwlf21r/S7f9u3nu5",
+	"/r1+o3Z8c2/AjNHPK5iXB0zbbXx+R40G33qx/q3wywVtLrWkFeGkkE/Dm6v4dedz50s+xrZ0Z3nvsL0f",
+	"C3a/TPSomu4dyb7+OMHG2u1XRUM/gu4QUA6askI9DA2FCcBLD4RT6luQuH7hvuyF4oGAKQ7dRrJ15HDL",
+	"33D+MBPVdK62emrr2vN9oJtM2aJpFb2wWpE5htCL6B7yUTRbqBJTQyLiPUxSuCdfpF/madZrW/HEJ1m/",
+	"amwtO4c1M3+dZF+aFKo39NEOs526E/kKOeQaUpRUarQ/lokfIyUiyTzfKEK7Bd3e7Y9mkYsbmteVxiSE",
+	"ppc4tnRDAFGO4R8LPbMijWpbEz7RxGeOKWzU2gWqYFdAqEnc2CZvqWmIZ1eMZgFQaSKVvfe+UcS1qR4o",
+	"3YJ0qv+bwq3b4uSp7b1uGnxEtP2EFORa7BcLUuc831Ww/SWg1gmoky6yVwsqk1nYkk+1j3+p9PFdhzDG",
+	"IGQjhEgp4ZqJShWLelLIzYzb5Ih3ALFleue8+fwHwksLkzMSfu2H9r/S4rzlVYnpTPqct3pyhR170EeP",
+	"w9aSKZRs/X5byyVLk7T5FdjwnU5xTx207HwwIOZ2dDt1X4v9L3Gw1vL2vNoxgz3/u/txEbDzuf6Y7Eob",
+	"+67M0XwD91Ht6g0I8r9hS9/bKvZyr28PR3fX5smu1C7xgb54ryW1y45FR7qf2xVdN594CIV5SprGafUg",
+	"VpYv85ye+l52X4G4bTcUfHItrFW3Gv2SpdnOv2TtY8taj+haGno2rAs1+ly489l/RnmlhL0jO9Rffn5U",
+	"+TqYBL9K6Wr3JyJcY7salgUE+9mNgSkduvQYqNaH3VyrAqPGZoJnmKjXsrRd5D5npryeGiHrEoVN9r4y",
+	"Td6pBKxrZJzWGc+UE1FS/GS5rZA8JCVVilwEVZUXNgJr2ydMYVU55fY5/9nnMZoGzdisGemuSeCzef4G",
+	"imlMuUZUfAhx1qPwSApUiGRbxclUHY97ljUNwZ8vyXhyzzbtmXtf92wKaQZAMLEQmFfjE7pbw9gpUgS4",
+	"Hgjm0FBX88TAqG9uDIgvYxwCivu4gCvitGAdv/FEHX6k0nmuetV7sRy1oCY0nqN2jwqyIauyOYS+eMz0",
+	"sfD9yjrteZYswb/qmnnei+TawOCIDUCmXOOVLz5SpDPvStB8h/p7AecUDdN7R4ZGtqtVi0Hg3hmj7GnN",
+	"P6zabThQrrXeUHi0eABoXhvx2Xz3kXYlriut9O4TX40eBcy8stkWnaAcV+w/y8b0rYmaIevGMAdh6/z2",
+	"x6hinYsfU8tY1pQgom786g8vFM3h6XJH3fdrUkKNcqHbR6rXWAIUttUW9MWvdPjxDIqerHbhBctR3oO2",
+	"xJ5zFcxfgTUXttN+YluuVcUdoWu8/5cd9+gsZNC8zGHWd5gb5tn5jP9rW3EdrV+IK/SDUFey7HV8k9ko",
+	"55iVa3Roe7/OUDTaFJ40ptKS3ADHa1JU01k0ufROjPa7gf1xrcRBxP1VWohmv/r2YUAptjhzlYFve10/",
+	"Zj5wp5t2LFhnnnAmxKumBHVZUWqAvieCEGvXWQbtj4QKSXJMksFc/2iRbmfL3DKzGWRXwXbZy7hh+LQp",
+	"ko9ZoO9ERguSwzUUojSJAPbZJE0qWSSHyUzr8nBnp8DnZkLpw++/+/47w1pups9xtFOeO9Q3LQQaHc1B",
+	"F1Eue80Rgg4IzfvthISYjmgbHnh/bmwM703uv90a3fJDbADDEf23T7uNG5o37K3IO+7j+QVTtv5tImTt",
+	"LWFeZrtBQrWnP9JZVdpvqwtRMD59Zb5BpyrT5MLIZ1MEZ+v7XcuhJtHYdovxODaZxrcfb/93ADyK/FHu",
+	"kAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,402 @@
+// Package chaos holds the failure injection settings, which can be changed while the bank runs.
+package chaos
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	mathrand "math/rand/v2"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/reload"
+)
+
+// Kinds lists every fault kind, in the order a request's draw tries them
+var Kinds = slices.Concat([]string{config.FaultError}, config.FaultKinds, []string{config.FaultErrorAfterCommit})
+
+// Profile is the chaos applied to a set of requests
+type Profile struct {
+	// FaultRates is the fraction of requests that get each fault kind, e.g. config.FaultError
+	FaultRates   map[string]float64
+	MinLatencyMS int
+	MaxLatencyMS int
+}
+
+// Rule applies its Profile instead of the default to the requests it matches
+type Rule struct {
+	// Method is the HTTP method to match; empty matches any
+	Method string
+	// Path is a path.Match pattern, e.g. /api/v1/captures or /api/v1/authorizations/*/increments
+	Path string
+	Profile
+}
+
+// Settings is the complete chaos configuration
+type Settings struct {
+	// Routes are tried in order; the first match wins over Default
+	Routes []Rule
+	// Script, when set, replaces the random faults: only the requests it lists fail.
+	// Latency still follows the profiles.
+	Script  []config.FaultStep
+	Default Profile
+	// Seed seeds the random latency and faults, so the same seed and request order
+	// give the same chaos. 0 picks a random seed.
+	Seed uint64
+}
+
+// FromConfig returns the settings the bank starts with
+func FromConfig(cfg *config.AppConfig) Settings {
+	rates := maps.Clone(cfg.FaultRates)
+	if rates == nil {
+		rates = map[string]float64{}
+	}
+	rates[config.FaultError] = cfg.FailureRate
+	rates[config.FaultErrorAfterCommit] = cfg.PostCommitFailureRate
+
+	return Settings{
+		Script: slices.Clone(cfg.ChaosScript),
+		Default: Profile{
+			FaultRates:   rates,
+			MinLatencyMS: cfg.MinLatencyMS,
+			MaxLatencyMS: cfg.MaxLatencyMS,
+		},
+		Seed: cfg.ChaosSeed,
+	}
+}
+
+// Validate checks the settings can be applied
+func (s Settings) Validate() error {
+	if err := s.Default.validate(); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+
+	for i, rule := range s.Routes {
+		if !strings.HasPrefix(rule.Path, "/") {
+			return fmt.Errorf("route %d: path must start with /, got %q", i, rule.Path)
+		}
+		if err := validateMatch(rule.Method, rule.Path); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+	}
+
+	for i, step := range s.Script {
+		if err := step.Validate(); err != nil {
+			return fmt.Errorf("script step %d: %w", i+1, err)
+		}
+		if err := validateMatch(step.Method, step.Path); err != nil {
+			return fmt.Errorf("script step %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func validateMatch(method, pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return fmt.Errorf("invalid path pattern %q", pattern)
+	}
+	if method != "" && !slices.Contains(methods, method) {
+		return fmt.Errorf("invalid method %q", method)
+	}
+	return nil
+}
+
+// matches reports whether a request matches a method and path pattern, either of which may be empty to match any
+func matches(method, pattern, requestMethod, requestPath string) bool {
+	if method != "" && method != requestMethod {
+		return false
+	}
+	if pattern == "" {
+		return true
+	}
+	matched, _ := path.Match(pattern, requestPath)
+	return matched
+}
+
+var methods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+func (p Profile) validate() error {
+	total := 0.0
+	for kind, rate := range p.FaultRates {
+		if !slices.Contains(Kinds, kind) {
+			return fmt.Errorf("invalid fault kind: %s (must be one of %s)", kind, strings.Join(Kinds, ", "))
+		}
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s fault rate must be between 0 and 1, got %f", kind, rate)
+		}
+		total += rate
+	}
+	if total > 1+1e-9 {
+		return fmt.Errorf("fault rates add up to %f, must be at most 1", total)
+	}
+
+	if p.MinLatencyMS < 0 {
+		return fmt.Errorf("min latency cannot be negative")
+	}
+	if p.MaxLatencyMS < p.MinLatencyMS {
+		return fmt.Errorf("max latency (%d) must be >= min latency (%d)", p.MaxLatencyMS, p.MinLatencyMS)
+	}
+	return nil
+}
+
+// clone returns a deep copy, so the stored settings never share memory with a caller
+func (s Settings) clone() Settings {
+	cloned := Settings{
+		Default: s.Default.clone(),
+		Routes:  make([]Rule, len(s.Routes)),
+		Script:  slices.Clone(s.Script),
+		Seed:    s.Seed,
+	}
+	for i, rule := range s.Routes {
+		cloned.Routes[i] = rule
+		cloned.Routes[i].Profile = rule.Profile.clone()
+	}
+	return cloned
+}
+
+func (p Profile) clone() Profile {
+	p.FaultRates = maps.Clone(p.FaultRates)
+	if p.FaultRates == nil {
+		p.FaultRates = map[string]float64{}
+	}
+	return p
+}
+
+// Limits bound the latency settings can ask for. A request held past the idempotency
+// lock timeout loses its key to a retry while it still runs, so the latest a profile's
+// latency, followed by a hang, may end is just before the lock timeout.
+type Limits struct {
+	// IdempotencyLockTimeout is the lock timeout; zero leaves latency unbounded
+	IdempotencyLockTimeout time.Duration
+	// FaultHangDuration is how long a hang holds a request after its latency
+	FaultHangDuration time.Duration
+}
+
+// LimitsFromConfig returns the limits that go with the bank's configuration
+func LimitsFromConfig(cfg *config.AppConfig) Limits {
+	return Limits{
+		IdempotencyLockTimeout: cfg.IdempotencyLockTimeout,
+		FaultHangDuration:      cfg.FaultHangDuration,
+	}
+}
+
+// check reports the first profile in settings whose latency the limits do not allow
+func (l Limits) check(settings Settings) error {
+	if err := l.checkProfile(settings.Default); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	for i, rule := range settings.Routes {
+		if err := l.checkProfile(rule.Profile); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (l Limits) checkProfile(p Profile) error {
+	if l.IdempotencyLockTimeout <= 0 {
+		return nil
+	}
+	maxLatency := time.Duration(p.MaxLatencyMS) * time.Millisecond
+	if maxLatency >= l.IdempotencyLockTimeout {
+		return fmt.Errorf("max latency (%d) must be less than the idempotency lock timeout (%s)",
+			p.MaxLatencyMS, l.IdempotencyLockTimeout)
+	}
+	if maxLatency+l.FaultHangDuration >= l.IdempotencyLockTimeout {
+		return fmt.Errorf("max latency (%d) plus the fault hang duration (%s) must be less than the idempotency lock timeout (%s)",
+			p.MaxLatencyMS, l.FaultHangDuration, l.IdempotencyLockTimeout)
+	}
+	return nil
+}
+
+// Controller holds the current chaos settings. It is safe for concurrent use:
+// requests read the settings without locking while an admin replaces them.
+type Controller struct {
+	runs   *reload.Holder[Settings, run]
+	limits Limits
+}
+
+// run is one application of a set of settings, with the random source and script
+// progress that start afresh each time settings are applied
+type run struct {
+	random *mathrand.Rand
+	// seen counts the requests that matched each script step so far
+	seen     []int
+	settings Settings
+	mu       sync.Mutex
+}
+
+func newRun(settings Settings) *run {
+	settings = settings.clone()
+	if settings.Seed == 0 {
+		settings.Seed = randomSeed()
+	}
+	return &run{
+		settings: settings,
+		//nolint:gosec // Chaos needs a seedable source to be reproducible, not a secure one
+		random: mathrand.New(mathrand.NewPCG(settings.Seed, settings.Seed)),
+		seen:   make([]int, len(settings.Script)),
+	}
+}
+
+func randomSeed() uint64 {
+	var b [8]byte
+	//nolint:errcheck // crypto/rand.Read never returns an error
+	rand.Read(b[:])
+	return binary.LittleEndian.Uint64(b[:]) | 1
+}
+
+// NewController returns a Controller starting with initial, which must be valid.
+// Updates must also keep within limits.
+func NewController(initial Settings, limits Limits) *Controller {
+	return &Controller{runs: reload.NewHolder(initial.clone(), newRun), limits: limits}
+}
+
+// Settings returns a copy of the current settings. Seed is the seed in use, even
+// when the settings asked for a random one.
+func (c *Controller) Settings() Settings {
+	return c.runs.Current().settings.clone()
+}
+
+// Update validates settings against themselves and the Controller's limits and makes
+// them the current settings. The random sequence and the script start again from the beginning.
+func (c *Controller) Update(settings Settings) error {
+	if err := c.limits.check(settings); err != nil {
+		return err
+	}
+	return c.runs.Update(settings)
+}
+
+// Reset restores the settings the bank started with and returns them
+func (c *Controller) Reset() Settings {
+	return c.runs.Reset().settings.clone()
+}
+
+// ProfileFor returns the profile that applies to a request. The returned profile
+// must not be modified.
+func (c *Controller) ProfileFor(method, requestPath string) Profile {
+	return c.runs.Current().settings.profileFor(method, requestPath)
+}
+
+func (s *Settings) profileFor(method, requestPath string) Profile {
+	for _, rule := range s.Routes {
+		if matches(rule.Method, rule.Path, method, requestPath) {
+			return rule.Profile
+		}
+	}
+	return s.Default
+}
+
+// Decision is the chaos a request gets
+type Decision struct {
+	// Fault is the fault kind to inject, or "" for none
+	Fault   string
+	Latency time.Duration
+}
+
+// Decide returns the chaos for the next request. With a fixed seed, the same
+// sequence of requests always gets the same decisions.
+func (c *Controller) Decide(method, requestPath string) Decision {
+	r := c.runs.Current()
+	profile := r.settings.profileFor(method, requestPath)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	decision := Decision{Latency: r.latency(profile)}
+	if len(r.settings.Script) > 0 {
+		decision.Fault = r.scriptedFault(method, requestPath)
+	} else {
+		decision.Fault = pickFault(profile, r.random.Float64())
+	}
+	return decision
+}
+
+// DecideDegraded returns the chaos for the next request while the bank is degraded:
+// each fault rate and latency bound is the higher of the request's profile and
+// degraded. The script is not followed, and its requests are not counted.
+func (c *Controller) DecideDegraded(method, requestPath string, degraded Profile) Decision {
+	r := c.runs.Current()
+	profile := raise(r.settings.profileFor(method, requestPath), degraded)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Decision{
+		Latency: r.latency(profile),
+		Fault:   pickFault(profile, r.random.Float64()),
+	}
+}
+
+// raise returns profile with every rate and latency bound raised to at least floor's
+func raise(profile, floor Profile) Profile {
+	raised := Profile{
+		FaultRates:   maps.Clone(profile.FaultRates),
+		MinLatencyMS: max(profile.MinLatencyMS, floor.MinLatencyMS),
+		MaxLatencyMS: max(profile.MaxLatencyMS, floor.MaxLatencyMS),
+	}
+	if raised.FaultRates == nil {
+		raised.FaultRates = map[string]float64{}
+	}
+	for kind, rate := range floor.FaultRates {
+		raised.FaultRates[kind] = max(raised.FaultRates[kind], rate)
+	}
+	return raised
+}
+
+func (r *run) latency(profile Profile) time.Duration {
+	latencyMS := profile.MinLatencyMS
+	if rangeMS := profile.MaxLatencyMS - profile.MinLatencyMS; rangeMS > 0 {
+		latencyMS += r.random.IntN(rangeMS)
+	}
+	return time.Duration(latencyMS) * time.Millisecond
+}
+
+// scriptedFault counts the request against every script step it matches and returns
+// the fault of the first step whose turn it is
+func (r *run) scriptedFault(method, requestPath string) string {
+	fault := ""
+	for i, step := range r.settings.Script {
+		if !matches(step.Method, step.Path, method, requestPath) {
+			continue
+		}
+		r.seen[i]++
+		if fault == "" && r.seen[i] == step.Request {
+			fault = step.Fault
+		}
+	}
+	return fault
+}
+
+// pickFault returns the fault kind to inject for a draw in [0, 1), or "" for none.
+// The draw is compared against the rates laid end to end in Kinds order, so each
+// kind is picked at its own rate.
+func pickFault(profile Profile, draw float64) string {
+	for _, kind := range Kinds {
+		rate := profile.FaultRates[kind]
+		if rate <= 0 {
+			continue
+		}
+		if draw < rate {
+			return kind
+		}
+		draw -= rate
+	}
+	return ""
+}
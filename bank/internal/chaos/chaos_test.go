@@ -0,0 +1,376 @@
+package chaos
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromConfig(t *testing.T) {
+	settings := FromConfig(&config.AppConfig{
+		FaultRates:            map[string]float64{config.FaultHang: 0.1},
+		FailureRate:           0.2,
+		PostCommitFailureRate: 0.05,
+		MinLatencyMS:          10,
+		MaxLatencyMS:          50,
+	})
+
+	assert.Equal(t, Settings{
+		Default: Profile{
+			FaultRates: map[string]float64{
+				config.FaultHang:             0.1,
+				config.FaultError:            0.2,
+				config.FaultErrorAfterCommit: 0.05,
+			},
+			MinLatencyMS: 10,
+			MaxLatencyMS: 50,
+		},
+	}, settings)
+}
+
+func TestSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		settings Settings
+	}{
+		{
+			name: "valid",
+			settings: Settings{
+				Routes: []Rule{
+					{Method: http.MethodPost, Path: "/api/v1/captures", Profile: Profile{FaultRates: map[string]float64{config.FaultError: 0.5}}},
+					{Path: "/api/v1/authorizations/*", Profile: Profile{MinLatencyMS: 100, MaxLatencyMS: 200}},
+				},
+				Default: Profile{FaultRates: map[string]float64{config.FaultError: 0.1}},
+			},
+		},
+		{
+			name:     "unknown fault kind",
+			settings: Settings{Default: Profile{FaultRates: map[string]float64{"explode": 0.1}}},
+			wantErr:  "default: invalid fault kind: explode",
+		},
+		{
+			name:     "rate above one",
+			settings: Settings{Default: Profile{FaultRates: map[string]float64{config.FaultError: 1.5}}},
+			wantErr:  "default: error fault rate must be between 0 and 1",
+		},
+		{
+			name: "rates add up to more than one",
+			settings: Settings{Default: Profile{FaultRates: map[string]float64{
+				config.FaultError: 0.6,
+				config.FaultHang:  0.6,
+			}}},
+			wantErr: "default: fault rates add up to",
+		},
+		{
+			name:     "max latency below min",
+			settings: Settings{Default: Profile{MinLatencyMS: 200, MaxLatencyMS: 100}},
+			wantErr:  "default: max latency (100) must be >= min latency (200)",
+		},
+		{
+			name:     "relative path",
+			settings: Settings{Routes: []Rule{{Path: "api/v1/captures"}}},
+			wantErr:  "route 0: path must start with /",
+		},
+		{
+			name:     "bad pattern",
+			settings: Settings{Routes: []Rule{{Path: "/api/v1/[captures"}}},
+			wantErr:  "route 0: invalid path pattern",
+		},
+		{
+			name:     "bad method",
+			settings: Settings{Routes: []Rule{{Method: "FETCH", Path: "/api/v1/captures"}}},
+			wantErr:  `route 0: invalid method "FETCH"`,
+		},
+		{
+			name:     "bad script fault",
+			settings: Settings{Script: []config.FaultStep{{Request: 1, Fault: "explode"}}},
+			wantErr:  "script step 1: invalid fault kind: explode",
+		},
+		{
+			name:     "bad script request number",
+			settings: Settings{Script: []config.FaultStep{{Fault: config.FaultError}}},
+			wantErr:  "script step 1: request number must be at least 1",
+		},
+		{
+			name:     "bad script method",
+			settings: Settings{Script: []config.FaultStep{{Method: "FETCH", Request: 1, Fault: config.FaultError}}},
+			wantErr:  `script step 1: invalid method "FETCH"`,
+		},
+		{
+			name: "bad route profile",
+			settings: Settings{Routes: []Rule{
+				{Path: "/api/v1/captures"},
+				{Path: "/api/v1/voids", Profile: Profile{MinLatencyMS: -1}},
+			}},
+			wantErr: "route 1: min latency cannot be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestController_ProfileFor(t *testing.T) {
+	captures := Profile{FaultRates: map[string]float64{config.FaultError: 0.5}}
+	increments := Profile{FaultRates: map[string]float64{config.FaultHang: 0.2}, MinLatencyMS: 100, MaxLatencyMS: 200}
+	authorizations := Profile{FaultRates: map[string]float64{}, MinLatencyMS: 500, MaxLatencyMS: 500}
+	defaults := Profile{FaultRates: map[string]float64{config.FaultError: 0.1}}
+
+	controller := NewController(Settings{
+		Routes: []Rule{
+			{Method: http.MethodPost, Path: "/api/v1/captures", Profile: captures},
+			{Path: "/api/v1/authorizations/*/increments", Profile: increments},
+			{Path: "/api/v1/authorizations*", Profile: authorizations},
+		},
+		Default: defaults,
+	}, Limits{})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		want   Profile
+	}{
+		{name: "method and path match", method: http.MethodPost, path: "/api/v1/captures", want: captures},
+		{name: "other method falls back to default", method: http.MethodGet, path: "/api/v1/captures", want: defaults},
+		{name: "pattern matches", method: http.MethodPost, path: "/api/v1/authorizations/auth_1/increments", want: increments},
+		{name: "first match wins", method: http.MethodPost, path: "/api/v1/authorizations", want: authorizations},
+		{name: "no match uses default", method: http.MethodPost, path: "/api/v1/refunds", want: defaults},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, controller.ProfileFor(tt.method, tt.path))
+		})
+	}
+}
+
+func TestController_UpdateAndReset(t *testing.T) {
+	initial := Settings{Routes: []Rule{}, Default: Profile{FaultRates: map[string]float64{config.FaultError: 0.1}}, Seed: 7}
+	controller := NewController(initial, Limits{})
+
+	updated := Settings{
+		Routes:  []Rule{{Method: http.MethodPost, Path: "/api/v1/captures", Profile: Profile{MinLatencyMS: 100, MaxLatencyMS: 100}}},
+		Default: Profile{FaultRates: map[string]float64{config.FaultError: 0.3}},
+	}
+	require.NoError(t, controller.Update(updated))
+
+	// Changing the caller's settings afterwards must not change the stored ones
+	updated.Default.FaultRates[config.FaultError] = 0.9
+	assert.InDelta(t, 0.3, controller.Settings().Default.FaultRates[config.FaultError], 1e-9)
+	assert.Len(t, controller.Settings().Routes, 1)
+
+	err := controller.Update(Settings{Default: Profile{MinLatencyMS: -1}})
+	require.Error(t, err)
+	assert.InDelta(t, 0.3, controller.Settings().Default.FaultRates[config.FaultError], 1e-9, "invalid settings must not be applied")
+
+	reset := controller.Reset()
+	assert.Equal(t, initial, reset)
+	assert.Equal(t, initial, controller.Settings())
+}
+
+func TestController_ConcurrentUpdates(t *testing.T) {
+	controller := NewController(Settings{}, Limits{})
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				rate := float64((i+j)%10) / 10
+				//nolint:errcheck // Every update here is valid
+				controller.Update(Settings{
+					Routes:  []Rule{{Path: "/api/v1/captures", Profile: Profile{FaultRates: map[string]float64{config.FaultError: rate}}}},
+					Default: Profile{FaultRates: map[string]float64{config.FaultError: rate}},
+				})
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				profile := controller.ProfileFor(http.MethodPost, "/api/v1/captures")
+				rate := profile.FaultRates[config.FaultError]
+				assert.True(t, rate >= 0 && rate < 1)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestPickFault(t *testing.T) {
+	tests := []struct {
+		rates map[string]float64
+		name  string
+		want  string
+		draw  float64
+	}{
+		{name: "no rates", rates: nil, want: ""},
+		{name: "zero rates", rates: map[string]float64{config.FaultError: 0, config.FaultReset: 0}, want: ""},
+		{name: "certain fault", rates: map[string]float64{config.FaultError: 0, config.FaultReset: 1}, draw: 0.99, want: config.FaultReset},
+		{name: "earlier kinds come first", rates: map[string]float64{config.FaultError: 0.5, config.FaultHang: 0.5}, draw: 0.2, want: config.FaultError},
+		{name: "draw past the first rate", rates: map[string]float64{config.FaultError: 0.5, config.FaultHang: 0.5}, draw: 0.7, want: config.FaultHang},
+		{name: "draw past every rate", rates: map[string]float64{config.FaultError: 0.2, config.FaultHang: 0.3}, draw: 0.6, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pickFault(Profile{FaultRates: tt.rates}, tt.draw))
+		})
+	}
+}
+
+func TestController_Decide_EachKindAtItsOwnRate(t *testing.T) {
+	controller := NewController(Settings{
+		Default: Profile{FaultRates: map[string]float64{config.FaultError: 0.2, config.FaultReset: 0.3, config.FaultHang: 0.1}},
+	}, Limits{})
+
+	const draws = 20000
+	counts := map[string]int{}
+	for range draws {
+		counts[controller.Decide(http.MethodPost, "/api/v1/captures").Fault]++
+	}
+
+	assert.InDelta(t, 0.2, float64(counts[config.FaultError])/draws, 0.02)
+	assert.InDelta(t, 0.3, float64(counts[config.FaultReset])/draws, 0.02)
+	assert.InDelta(t, 0.1, float64(counts[config.FaultHang])/draws, 0.02)
+	assert.InDelta(t, 0.4, float64(counts[""])/draws, 0.02)
+}
+
+func TestController_Decide_SameSeedSameChaos(t *testing.T) {
+	settings := Settings{
+		Default: Profile{
+			FaultRates:   map[string]float64{config.FaultError: 0.3, config.FaultReset: 0.2},
+			MinLatencyMS: 10,
+			MaxLatencyMS: 500,
+		},
+		Seed: 42,
+	}
+	decide := func(controller *Controller) []Decision {
+		decisions := make([]Decision, 50)
+		for i := range decisions {
+			decisions[i] = controller.Decide(http.MethodPost, "/api/v1/authorizations")
+		}
+		return decisions
+	}
+
+	first := decide(NewController(settings, Limits{}))
+	assert.Equal(t, first, decide(NewController(settings, Limits{})))
+
+	controller := NewController(settings, Limits{})
+	decide(controller)
+	require.NoError(t, controller.Update(settings))
+	assert.Equal(t, first, decide(controller), "updating the settings restarts the sequence")
+
+	settings.Seed = 43
+	assert.NotEqual(t, first, decide(NewController(settings, Limits{})))
+}
+
+func TestController_RandomSeedIsReported(t *testing.T) {
+	controller := NewController(Settings{Default: Profile{FaultRates: map[string]float64{config.FaultError: 0.5}}}, Limits{})
+
+	seed := controller.Settings().Seed
+	require.NotZero(t, seed)
+
+	var first []string
+	for range 20 {
+		first = append(first, controller.Decide(http.MethodGet, "/api/v1/transactions").Fault)
+	}
+
+	settings := controller.Settings()
+	require.NoError(t, controller.Update(settings))
+	assert.Equal(t, seed, controller.Settings().Seed)
+	for i := range 20 {
+		assert.Equal(t, first[i], controller.Decide(http.MethodGet, "/api/v1/transactions").Fault)
+	}
+}
+
+func TestController_Decide_Script(t *testing.T) {
+	controller := NewController(Settings{
+		Script: []config.FaultStep{
+			{Method: http.MethodPost, Path: "/api/v1/captures", Request: 3, Fault: config.FaultError},
+			{Request: 5, Fault: config.FaultHang},
+		},
+		Default: Profile{
+			FaultRates:   map[string]float64{config.FaultReset: 1},
+			MinLatencyMS: 20,
+			MaxLatencyMS: 20,
+		},
+	}, Limits{})
+
+	requests := []struct {
+		method string
+		path   string
+		want   string
+	}{
+		{method: http.MethodPost, path: "/api/v1/captures"},
+		{method: http.MethodPost, path: "/api/v1/authorizations"},
+		{method: http.MethodGet, path: "/api/v1/captures/cap_1"},
+		{method: http.MethodPost, path: "/api/v1/captures"},
+		{method: http.MethodPost, path: "/api/v1/voids", want: config.FaultHang},
+		{method: http.MethodPost, path: "/api/v1/captures", want: config.FaultError},
+		{method: http.MethodPost, path: "/api/v1/captures"},
+		{method: http.MethodPost, path: "/api/v1/refunds"},
+	}
+
+	for i, req := range requests {
+		decision := controller.Decide(req.method, req.path)
+		assert.Equal(t, req.want, decision.Fault, "request %d", i+1)
+		assert.Equal(t, 20*time.Millisecond, decision.Latency, "latency still follows the profile")
+	}
+}
+
+func TestController_DecideDegraded(t *testing.T) {
+	controller := NewController(Settings{
+		Routes: []Rule{{Path: "/api/v1/captures", Profile: Profile{
+			FaultRates:   map[string]float64{config.FaultError: 0.8, config.FaultReset: 0.1},
+			MinLatencyMS: 3000,
+			MaxLatencyMS: 3000,
+		}}},
+		Script: []config.FaultStep{{Request: 1, Fault: config.FaultHang}},
+		Default: Profile{
+			FaultRates:   map[string]float64{config.FaultError: 0.05},
+			MinLatencyMS: 10,
+			MaxLatencyMS: 20,
+		},
+		Seed: 42,
+	}, Limits{})
+	degraded := Profile{FaultRates: map[string]float64{config.FaultError: 0.5}, MinLatencyMS: 1000, MaxLatencyMS: 1000}
+
+	const draws = 10000
+	counts := map[string]int{}
+	for range draws {
+		decision := controller.DecideDegraded(http.MethodGet, "/api/v1/transactions", degraded)
+		counts[decision.Fault]++
+		assert.Equal(t, time.Second, decision.Latency)
+	}
+	assert.InDelta(t, 0.5, float64(counts[config.FaultError])/draws, 0.02, "the degraded rate is higher than the default")
+	assert.Zero(t, counts[config.FaultHang], "the script is not followed")
+
+	counts = map[string]int{}
+	for range draws {
+		decision := controller.DecideDegraded(http.MethodPost, "/api/v1/captures", degraded)
+		counts[decision.Fault]++
+		assert.Equal(t, 3*time.Second, decision.Latency, "the route's latency is higher than the degraded one")
+	}
+	assert.InDelta(t, 0.8, float64(counts[config.FaultError])/draws, 0.02, "the route's rate is higher than the degraded one")
+	assert.InDelta(t, 0.1, float64(counts[config.FaultReset])/draws, 0.02)
+
+	assert.Equal(t, config.FaultHang, controller.Decide(http.MethodGet, "/api/v1/transactions").Fault,
+		"degraded requests do not count against the script")
+}
@@ -0,0 +1,18 @@
+// Package clock tells the bank what time it is, so tests can control it.
+package clock
+
+import "time"
+
+// Clock returns the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the Clock backed by time.Now
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
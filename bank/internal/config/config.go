@@ -3,17 +3,88 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/cron"
+)
+
+// Storage backends selectable with STORAGE_BACKEND
+const (
+	StorageBackendPostgres = "postgres"
+	StorageBackendMemory   = "memory"
+)
+
+// Fault kinds FailureInjection can inject, each with its own rate in FAULT_RATES.
+// FAILURE_RATE and POST_COMMIT_FAILURE_RATE set the rates of FaultError and
+// FaultErrorAfterCommit.
+const (
+	FaultError            = "error"              // JSON 500 before the request runs
+	FaultErrorAfterCommit = "error_after_commit" // JSON 500 after the request committed
+	FaultHang             = "hang"               // no response until the client gives up, then the connection drops
+	FaultReset            = "reset"              // TCP reset instead of a response
+	FaultTruncatedBody    = "truncated_body"     // 200 whose JSON body is cut off mid-way
+	FaultMalformedBody    = "malformed_body"     // complete 200 whose body is not JSON
+	FaultBadGateway       = "bad_gateway"        // 502 from a proxy
+	FaultUnavailable      = "unavailable"        // 503 from a proxy
+	FaultGatewayTimeout   = "gateway_timeout"    // 504 from a proxy
+
+	// The same proxy errors with a Retry-After header
+	FaultBadGatewayRetryAfter     = "bad_gateway_retry_after"
+	FaultUnavailableRetryAfter    = "unavailable_retry_after"
+	FaultGatewayTimeoutRetryAfter = "gateway_timeout_retry_after"
 )
 
+// FaultKinds lists the fault kinds FAULT_RATES accepts
+var FaultKinds = []string{
+	FaultHang,
+	FaultReset,
+	FaultTruncatedBody,
+	FaultMalformedBody,
+	FaultBadGateway,
+	FaultBadGatewayRetryAfter,
+	FaultUnavailable,
+	FaultUnavailableRetryAfter,
+	FaultGatewayTimeout,
+	FaultGatewayTimeoutRetryAfter,
+}
+
+// Outage modes an OUTAGE_WINDOWS window puts the bank in
+const (
+	OutageMaintenance = "maintenance" // every API call gets 503
+	OutageDegraded    = "degraded"    // failure rate and latency rise to the DEGRADED_* settings
+)
+
+// OutageWindow is a period the bank spends in Mode. It starts once, After the bank
+// starts, or, when Cron is set, every time the cron schedule fires in UTC.
+type OutageWindow struct {
+	Cron     *cron.Schedule
+	Mode     string
+	After    time.Duration
+	Duration time.Duration
+}
+
+// FaultStep is one entry of a chaos script: the Request-th API request matching
+// Method and Path gets Fault. An empty Method or Path matches any.
+type FaultStep struct {
+	Method  string
+	Path    string
+	Fault   string
+	Request int
+}
+
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig
-	Logger   LoggerConfig
-	Database DatabaseConfig
-	App      AppConfig
+	Server    ServerConfig
+	Logger    LoggerConfig
+	Storage   StorageConfig
+	RateLimit RateLimitConfig
+	Database  DatabaseConfig
+	App       AppConfig
 }
 
 // ServerConfig holds HTTP server configuration
@@ -24,6 +95,33 @@ type ServerConfig struct {
 	IdleTimeout  time.Duration
 }
 
+// StorageConfig selects where accounts, transactions and idempotency keys are kept
+type StorageConfig struct {
+	// Backend is "postgres", or "memory" to run without a database. The memory
+	// backend starts with the test accounts and loses all data on restart.
+	Backend string
+}
+
+// RateLimitConfig holds how fast each client may send requests. Every client, told
+// apart by X-API-Key or else its IP, gets a token bucket refilled at RequestsPerSecond
+// and holding up to Burst requests. A zero rate means no limit.
+type RateLimitConfig struct {
+	// Endpoints replace the default limit for the requests they match, each with its
+	// own bucket per client
+	Endpoints         []EndpointRateLimit
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// EndpointRateLimit limits the requests matching Method and Path, a path.Match
+// pattern. An empty Method matches any.
+type EndpointRateLimit struct {
+	Method            string
+	Path              string
+	RequestsPerSecond float64
+	Burst             int
+}
+
 // DatabaseConfig holds database connection configuration
 type DatabaseConfig struct {
 	Host            string
@@ -39,11 +137,50 @@ type DatabaseConfig struct {
 
 // AppConfig holds application-specific configuration
 type AppConfig struct {
-	FailureRate        float64
-	MinLatencyMS       int
-	MaxLatencyMS       int
-	AuthExpiryHours    int
-	AuthExpiryDuration time.Duration
+	// FaultRates is the fraction of requests that get each of the other fault kinds
+	// (FaultHang, FaultReset, ...). All rates together cannot exceed 1.
+	FaultRates map[string]float64
+	// ChaosScript, when set, replaces the random faults: only the requests it lists fail
+	ChaosScript []FaultStep
+	// OutageWindows schedule the periods the bank is down for maintenance or degraded
+	OutageWindows []OutageWindow
+	// IdempotencyCachedErrorStatuses lists the 4xx statuses treated as final outcomes and
+	// replayed for a reused key, e.g. declines. Successful responses are always replayed.
+	IdempotencyCachedErrorStatuses []int
+	FailureRate                    float64
+	// PostCommitFailureRate is the fraction of requests that run and commit normally
+	// but whose response is replaced by a 500, so the client cannot tell they succeeded
+	PostCommitFailureRate float64
+	// FaultHangDuration is how long a FaultHang request is held before its connection is dropped
+	FaultHangDuration time.Duration
+	// FaultRetryAfterSeconds is the Retry-After sent by the *_retry_after gateway faults
+	FaultRetryAfterSeconds int
+	// ChaosSeed seeds the random chaos so a run can be repeated; 0 picks a random seed
+	ChaosSeed uint64
+	// ChaosHeadersEnabled lets a request force its own fault and latency with the
+	// X-Mock-Fault and X-Mock-Latency-Ms headers. They are ignored otherwise.
+	ChaosHeadersEnabled bool
+	// DegradedFailureRate, DegradedMinLatencyMS and DegradedMaxLatencyMS are the least
+	// failure rate and latency every API request gets during a degraded window
+	DegradedFailureRate  float64
+	DegradedMinLatencyMS int
+	DegradedMaxLatencyMS int
+	MinLatencyMS         int
+	MaxLatencyMS         int
+	AuthExpiryHours      int
+	AuthExpiryDuration   time.Duration
+	ExpirySweepInterval  time.Duration
+	ExpirySweepBatchSize int
+	// IdempotencyLockTimeout is how long a request may hold an Idempotency-Key before the
+	// reservation is considered abandoned and another request may take it over
+	IdempotencyLockTimeout time.Duration
+	// IdempotencyWaitTimeout is how long a concurrent duplicate waits for the original
+	// request to finish before getting 409 request_in_progress
+	IdempotencyWaitTimeout time.Duration
+	// IdempotencyRetention is how long stored keys are kept before the sweeper deletes them
+	IdempotencyRetention time.Duration
+	// IdempotencySweepInterval is how often expired keys are deleted
+	IdempotencySweepInterval time.Duration
 }
 
 // LoggerConfig holds logging configuration
@@ -53,35 +190,86 @@ type LoggerConfig struct {
 
 // Load loads configuration from environment variables with sensible defaults
 func Load() (*Config, error) {
-	authExpiryHours := getEnvAsInt("AUTH_EXPIRY_HOURS", 168) // 7 days default
+	return load(os.LookupEnv)
+}
+
+// Default returns the configuration the bank has when no environment variable is set
+func Default() (*Config, error) {
+	return load(func(string) (string, bool) { return "", false })
+}
+
+func load(e env) (*Config, error) {
+	authExpiryHours := e.getEnvAsInt("AUTH_EXPIRY_HOURS", 168) // 7 days default
+
+	chaosScript, err := ParseFaultScript(e.get("CHAOS_SCRIPT"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: CHAOS_SCRIPT: %w", err)
+	}
+
+	endpointRateLimits, err := ParseEndpointRateLimits(e.get("RATE_LIMIT_ENDPOINTS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: RATE_LIMIT_ENDPOINTS: %w", err)
+	}
+	rateLimit := e.getEnvAsFloat("RATE_LIMIT_RPS", 0)
+
+	outageWindows, err := ParseOutageWindows(e.get("OUTAGE_WINDOWS"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: OUTAGE_WINDOWS: %w", err)
+	}
 
 	cfg := &Config{
 		Server: ServerConfig{
-			Port:         getEnv("PORT", "8080"),
-			ReadTimeout:  getEnvAsDuration("SERVER_READ_TIMEOUT", "15s"),
-			WriteTimeout: getEnvAsDuration("SERVER_WRITE_TIMEOUT", "15s"),
-			IdleTimeout:  getEnvAsDuration("SERVER_IDLE_TIMEOUT", "60s"),
+			Port:         e.getEnv("PORT", "8080"),
+			ReadTimeout:  e.getEnvAsDuration("SERVER_READ_TIMEOUT", "15s"),
+			WriteTimeout: e.getEnvAsDuration("SERVER_WRITE_TIMEOUT", "15s"),
+			IdleTimeout:  e.getEnvAsDuration("SERVER_IDLE_TIMEOUT", "60s"),
+		},
+		Storage: StorageConfig{
+			Backend: e.getEnv("STORAGE_BACKEND", StorageBackendPostgres),
+		},
+		RateLimit: RateLimitConfig{
+			RequestsPerSecond: rateLimit,
+			Burst:             e.getEnvAsInt("RATE_LIMIT_BURST", DefaultBurst(rateLimit)),
+			Endpoints:         endpointRateLimits,
 		},
 		Database: DatabaseConfig{
-			Host:            getEnv("DB_HOST", "localhost"),
-			Port:            getEnv("DB_PORT", "5432"),
-			User:            getEnv("DB_USER", "postgres"),
-			Password:        getEnv("DB_PASSWORD", "postgres"),
-			DBName:          getEnv("DB_NAME", "mockbank"),
-			SSLMode:         getEnv("DB_SSLMODE", "disable"),
-			MaxOpenConns:    getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
-			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
-			ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", "5m"),
+			Host:            e.getEnv("DB_HOST", "localhost"),
+			Port:            e.getEnv("DB_PORT", "5432"),
+			User:            e.getEnv("DB_USER", "postgres"),
+			Password:        e.getEnv("DB_PASSWORD", "postgres"),
+			DBName:          e.getEnv("DB_NAME", "mockbank"),
+			SSLMode:         e.getEnv("DB_SSLMODE", "disable"),
+			MaxOpenConns:    e.getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:    e.getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
+			ConnMaxLifetime: e.getEnvAsDuration("DB_CONN_MAX_LIFETIME", "5m"),
 		},
 		App: AppConfig{
-			FailureRate:        getEnvAsFloat("FAILURE_RATE", 0.05),
-			MinLatencyMS:       getEnvAsInt("MIN_LATENCY_MS", 100),
-			MaxLatencyMS:       getEnvAsInt("MAX_LATENCY_MS", 2000),
-			AuthExpiryHours:    authExpiryHours,
-			AuthExpiryDuration: time.Duration(authExpiryHours) * time.Hour,
+			FailureRate:                    e.getEnvAsFloat("FAILURE_RATE", 0.05),
+			PostCommitFailureRate:          e.getEnvAsFloat("POST_COMMIT_FAILURE_RATE", 0),
+			FaultRates:                     e.getEnvAsRates("FAULT_RATES"),
+			FaultHangDuration:              e.getEnvAsDuration("FAULT_HANG_DURATION", "20s"),
+			FaultRetryAfterSeconds:         e.getEnvAsInt("FAULT_RETRY_AFTER_SECONDS", 1),
+			ChaosSeed:                      e.getEnvAsUint64("CHAOS_SEED", 0),
+			ChaosScript:                    chaosScript,
+			ChaosHeadersEnabled:            e.getEnvAsBool("CHAOS_HEADERS_ENABLED", false),
+			OutageWindows:                  outageWindows,
+			DegradedFailureRate:            e.getEnvAsFloat("DEGRADED_FAILURE_RATE", 0.5),
+			DegradedMinLatencyMS:           e.getEnvAsInt("DEGRADED_MIN_LATENCY_MS", 2000),
+			DegradedMaxLatencyMS:           e.getEnvAsInt("DEGRADED_MAX_LATENCY_MS", 5000),
+			MinLatencyMS:                   e.getEnvAsInt("MIN_LATENCY_MS", 100),
+			MaxLatencyMS:                   e.getEnvAsInt("MAX_LATENCY_MS", 2000),
+			AuthExpiryHours:                authExpiryHours,
+			AuthExpiryDuration:             time.Duration(authExpiryHours) * time.Hour,
+			ExpirySweepInterval:            e.getEnvAsDuration("EXPIRY_SWEEP_INTERVAL", "1m"),
+			ExpirySweepBatchSize:           e.getEnvAsInt("EXPIRY_SWEEP_BATCH_SIZE", 100),
+			IdempotencyLockTimeout:         e.getEnvAsDuration("IDEMPOTENCY_LOCK_TIMEOUT", "30s"),
+			IdempotencyWaitTimeout:         e.getEnvAsDuration("IDEMPOTENCY_WAIT_TIMEOUT", "5s"),
+			IdempotencyRetention:           e.getEnvAsDuration("IDEMPOTENCY_RETENTION", "24h"),
+			IdempotencySweepInterval:       e.getEnvAsDuration("IDEMPOTENCY_SWEEP_INTERVAL", "1h"),
+			IdempotencyCachedErrorStatuses: e.getEnvAsIntSlice("IDEMPOTENCY_CACHED_ERROR_STATUSES", []int{400, 402, 409, 422}),
 		},
 		Logger: LoggerConfig{
-			Level: getEnv("LOG_LEVEL", "info"),
+			Level: e.getEnv("LOG_LEVEL", "info"),
 		},
 	}
 
@@ -98,17 +286,80 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("server port cannot be empty")
 	}
 
-	if c.Database.Host == "" {
-		return fmt.Errorf("database host cannot be empty")
+	switch c.Storage.Backend {
+	case StorageBackendPostgres:
+		if c.Database.Host == "" {
+			return fmt.Errorf("database host cannot be empty")
+		}
+		if c.Database.DBName == "" {
+			return fmt.Errorf("database name cannot be empty")
+		}
+	case StorageBackendMemory:
+	default:
+		return fmt.Errorf("invalid storage backend: %s (must be %s or %s)", c.Storage.Backend, StorageBackendPostgres, StorageBackendMemory)
+	}
+
+	if err := validateRateLimit(c.RateLimit.RequestsPerSecond, c.RateLimit.Burst); err != nil {
+		return err
 	}
-	if c.Database.DBName == "" {
-		return fmt.Errorf("database name cannot be empty")
+	for _, limit := range c.RateLimit.Endpoints {
+		if !strings.HasPrefix(limit.Path, "/") {
+			return fmt.Errorf("rate limit path must start with /, got %q", limit.Path)
+		}
+		if err := validateRateLimit(limit.RequestsPerSecond, limit.Burst); err != nil {
+			return fmt.Errorf("%s: %w", limit.Path, err)
+		}
 	}
 
 	if c.App.FailureRate < 0 || c.App.FailureRate > 1 {
 		return fmt.Errorf("failure rate must be between 0 and 1, got %f", c.App.FailureRate)
 	}
 
+	if c.App.PostCommitFailureRate < 0 || c.App.PostCommitFailureRate > 1 {
+		return fmt.Errorf("post-commit failure rate must be between 0 and 1, got %f", c.App.PostCommitFailureRate)
+	}
+
+	totalFaultRate := c.App.FailureRate + c.App.PostCommitFailureRate
+	for kind, rate := range c.App.FaultRates {
+		if !slices.Contains(FaultKinds, kind) {
+			return fmt.Errorf("invalid fault kind: %s (must be one of %s)", kind, strings.Join(FaultKinds, ", "))
+		}
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("%s fault rate must be between 0 and 1, got %f", kind, rate)
+		}
+		totalFaultRate += rate
+	}
+	if totalFaultRate > 1+1e-9 {
+		return fmt.Errorf("failure rates add up to %f, must be at most 1", totalFaultRate)
+	}
+	if c.App.FaultHangDuration <= 0 {
+		return fmt.Errorf("fault hang duration must be positive")
+	}
+	if c.App.FaultRetryAfterSeconds < 0 {
+		return fmt.Errorf("fault retry after cannot be negative")
+	}
+
+	for i, step := range c.App.ChaosScript {
+		if err := step.Validate(); err != nil {
+			return fmt.Errorf("chaos script step %d: %w", i+1, err)
+		}
+	}
+
+	for i, window := range c.App.OutageWindows {
+		if err := window.Validate(); err != nil {
+			return fmt.Errorf("outage window %d: %w", i+1, err)
+		}
+	}
+	if c.App.DegradedFailureRate < 0 || c.App.DegradedFailureRate > 1 {
+		return fmt.Errorf("degraded failure rate must be between 0 and 1, got %f", c.App.DegradedFailureRate)
+	}
+	if c.App.DegradedMinLatencyMS < 0 {
+		return fmt.Errorf("degraded min latency cannot be negative")
+	}
+	if c.App.DegradedMaxLatencyMS < c.App.DegradedMinLatencyMS {
+		return fmt.Errorf("degraded max latency (%d) must be >= degraded min latency (%d)", c.App.DegradedMaxLatencyMS, c.App.DegradedMinLatencyMS)
+	}
+
 	if c.App.MinLatencyMS < 0 {
 		return fmt.Errorf("min latency cannot be negative")
 	}
@@ -116,6 +367,45 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("max latency (%d) must be >= min latency (%d)", c.App.MaxLatencyMS, c.App.MinLatencyMS)
 	}
 
+	if c.App.ExpirySweepInterval <= 0 {
+		return fmt.Errorf("expiry sweep interval must be positive")
+	}
+	if c.App.ExpirySweepBatchSize <= 0 {
+		return fmt.Errorf("expiry sweep batch size must be positive, got %d", c.App.ExpirySweepBatchSize)
+	}
+
+	if c.App.IdempotencyLockTimeout <= 0 {
+		return fmt.Errorf("idempotency lock timeout must be positive")
+	}
+	// A request held past the lock timeout loses its key to a retry while it still runs
+	lockTimeoutMS := int(c.App.IdempotencyLockTimeout.Milliseconds())
+	if c.App.MaxLatencyMS >= lockTimeoutMS {
+		return fmt.Errorf("max latency (%d) must be less than the idempotency lock timeout (%s)", c.App.MaxLatencyMS, c.App.IdempotencyLockTimeout)
+	}
+	if c.App.DegradedMaxLatencyMS >= lockTimeoutMS {
+		return fmt.Errorf("degraded max latency (%d) must be less than the idempotency lock timeout (%s)", c.App.DegradedMaxLatencyMS, c.App.IdempotencyLockTimeout)
+	}
+	// A hang starts once the injected latency is over
+	hangHold := c.App.FaultHangDuration + time.Duration(max(c.App.MaxLatencyMS, c.App.DegradedMaxLatencyMS))*time.Millisecond
+	if hangHold >= c.App.IdempotencyLockTimeout {
+		return fmt.Errorf("fault hang duration (%s) plus max latency must be less than the idempotency lock timeout (%s)", c.App.FaultHangDuration, c.App.IdempotencyLockTimeout)
+	}
+	if c.App.IdempotencyWaitTimeout < 0 {
+		return fmt.Errorf("idempotency wait timeout cannot be negative")
+	}
+	if c.App.IdempotencyRetention <= 0 {
+		return fmt.Errorf("idempotency retention must be positive")
+	}
+	if c.App.IdempotencySweepInterval <= 0 {
+		return fmt.Errorf("idempotency sweep interval must be positive")
+	}
+
+	for _, status := range c.App.IdempotencyCachedErrorStatuses {
+		if status < 400 || status > 499 {
+			return fmt.Errorf("idempotency cached error statuses must be 4xx, got %d", status)
+		}
+	}
+
 	validLevels := map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
 	if !validLevels[c.Logger.Level] {
 		return fmt.Errorf("invalid log level: %s (must be debug, info, warn, or error)", c.Logger.Level)
@@ -124,6 +414,16 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func validateRateLimit(requestsPerSecond float64, burst int) error {
+	if requestsPerSecond < 0 {
+		return fmt.Errorf("rate limit cannot be negative, got %f", requestsPerSecond)
+	}
+	if requestsPerSecond > 0 && burst < 1 {
+		return fmt.Errorf("rate limit burst must be at least 1, got %d", burst)
+	}
+	return nil
+}
+
 // DSN returns the PostgreSQL connection string
 func (c *DatabaseConfig) DSN() string {
 	return fmt.Sprintf(
@@ -132,15 +432,23 @@ func (c *DatabaseConfig) DSN() string {
 	)
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+// env looks up configuration variables, as os.LookupEnv does
+type env func(key string) (string, bool)
+
+func (e env) get(key string) string {
+	value, _ := e(key)
+	return value
+}
+
+func (e env) getEnv(key, defaultValue string) string {
+	if value := e.get(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
+func (e env) getEnvAsInt(key string, defaultValue int) int {
+	valueStr := e.get(key)
 	if valueStr == "" {
 		return defaultValue
 	}
@@ -151,8 +459,32 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return value
 }
 
-func getEnvAsFloat(key string, defaultValue float64) float64 {
-	valueStr := os.Getenv(key)
+func (e env) getEnvAsBool(key string, defaultValue bool) bool {
+	valueStr := e.get(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseBool(valueStr)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (e env) getEnvAsUint64(key string, defaultValue uint64) uint64 {
+	valueStr := e.get(key)
+	if valueStr == "" {
+		return defaultValue
+	}
+	value, err := strconv.ParseUint(valueStr, 10, 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+func (e env) getEnvAsFloat(key string, defaultValue float64) float64 {
+	valueStr := e.get(key)
 	if valueStr == "" {
 		return defaultValue
 	}
@@ -163,8 +495,48 @@ func getEnvAsFloat(key string, defaultValue float64) float64 {
 	return value
 }
 
-func getEnvAsDuration(key, defaultValue string) time.Duration {
-	valueStr := getEnv(key, defaultValue)
+// getEnvAsIntSlice parses a comma-separated list of integers. An empty value
+// (e.g. "IDEMPOTENCY_CACHED_ERROR_STATUSES=") is honoured as an empty list.
+func (e env) getEnvAsIntSlice(key string, defaultValue []int) []int {
+	valueStr, ok := e(key)
+	if !ok {
+		return defaultValue
+	}
+
+	values := []int{}
+	for _, part := range strings.Split(valueStr, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return defaultValue
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// getEnvAsRates parses comma-separated kind=rate pairs, e.g. "hang=0.01,reset=0.02"
+func (e env) getEnvAsRates(key string) map[string]float64 {
+	rates := map[string]float64{}
+	for _, part := range strings.Split(e.get(key), ",") {
+		kind, rateStr, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil {
+			continue
+		}
+		rates[strings.TrimSpace(kind)] = rate
+	}
+	return rates
+}
+
+func (e env) getEnvAsDuration(key, defaultValue string) time.Duration {
+	valueStr := e.getEnv(key, defaultValue)
 	duration, err := time.ParseDuration(valueStr)
 	if err != nil {
 		// Fallback to parsing the default if provided value is invalid
@@ -175,3 +547,160 @@ func getEnvAsDuration(key, defaultValue string) time.Duration {
 	}
 	return duration
 }
+
+// ParseFaultScript parses a chaos script: comma-separated steps of the form
+// [METHOD] [PATH]#N=fault. "POST /api/v1/captures#3=error,#5=hang" fails the 3rd
+// capture and hangs the 5th request of any kind.
+func ParseFaultScript(script string) ([]FaultStep, error) {
+	var steps []FaultStep
+	for _, part := range strings.Split(script, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		target, fault, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("step %q: missing =fault", part)
+		}
+		i := strings.LastIndex(target, "#")
+		if i < 0 {
+			return nil, fmt.Errorf("step %q: missing #N request number", part)
+		}
+		request, err := strconv.Atoi(strings.TrimSpace(target[i+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("step %q: invalid request number", part)
+		}
+
+		step := FaultStep{Fault: strings.TrimSpace(fault), Request: request}
+		switch match := strings.Fields(target[:i]); len(match) {
+		case 0:
+		case 1:
+			if strings.HasPrefix(match[0], "/") {
+				step.Path = match[0]
+			} else {
+				step.Method = strings.ToUpper(match[0])
+			}
+		case 2:
+			step.Method = strings.ToUpper(match[0])
+			step.Path = match[1]
+		default:
+			return nil, fmt.Errorf("step %q: expected [METHOD] [PATH]#N=fault", part)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// Validate checks the step names a fault kind and a request that can happen
+func (s FaultStep) Validate() error {
+	if s.Fault != FaultError && s.Fault != FaultErrorAfterCommit && !slices.Contains(FaultKinds, s.Fault) {
+		return fmt.Errorf("invalid fault kind: %s", s.Fault)
+	}
+	if s.Request < 1 {
+		return fmt.Errorf("request number must be at least 1, got %d", s.Request)
+	}
+	if s.Path != "" && !strings.HasPrefix(s.Path, "/") {
+		return fmt.Errorf("path must start with /, got %q", s.Path)
+	}
+	return nil
+}
+
+// DefaultBurst is the burst used with a rate that has none: one second's worth of
+// requests, and at least one
+func DefaultBurst(requestsPerSecond float64) int {
+	return max(1, int(math.Ceil(requestsPerSecond)))
+}
+
+// ParseEndpointRateLimits parses comma-separated limits of the form
+// [METHOD] PATH=RATE[:BURST], e.g. "POST /api/v1/captures=5:10,/api/v1/transactions=1".
+// RATE is in requests per second; BURST defaults to DefaultBurst(RATE).
+func ParseEndpointRateLimits(limits string) ([]EndpointRateLimit, error) {
+	var parsed []EndpointRateLimit
+	for _, part := range strings.Split(limits, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		target, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("limit %q: missing =rate", part)
+		}
+		rateStr, burstStr, hasBurst := strings.Cut(strings.TrimSpace(value), ":")
+		rate, err := strconv.ParseFloat(rateStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("limit %q: invalid rate", part)
+		}
+
+		limit := EndpointRateLimit{RequestsPerSecond: rate, Burst: DefaultBurst(rate)}
+		if hasBurst {
+			if limit.Burst, err = strconv.Atoi(burstStr); err != nil {
+				return nil, fmt.Errorf("limit %q: invalid burst", part)
+			}
+		}
+
+		switch match := strings.Fields(target); len(match) {
+		case 1:
+			limit.Path = match[0]
+		case 2:
+			limit.Method = strings.ToUpper(match[0])
+			limit.Path = match[1]
+		default:
+			return nil, fmt.Errorf("limit %q: expected [METHOD] PATH=RATE[:BURST]", part)
+		}
+		parsed = append(parsed, limit)
+	}
+	return parsed, nil
+}
+
+// ParseOutageWindows parses semicolon-separated windows of the form
+// MODE at START for DURATION. START is either +DURATION after the bank starts or a
+// cron expression in UTC: "maintenance at +5m for 10m; degraded at */15 * * * * for 2m"
+// takes the bank down 5 minutes after it starts, and degrades it for the first 2
+// minutes of every quarter hour.
+func ParseOutageWindows(windows string) ([]OutageWindow, error) {
+	var parsed []OutageWindow
+	for _, part := range strings.Split(windows, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		mode, rest, ok := strings.Cut(part, " at ")
+		i := strings.LastIndex(rest, " for ")
+		if !ok || i < 0 {
+			return nil, fmt.Errorf("window %q: expected MODE at START for DURATION", part)
+		}
+		start := strings.TrimSpace(rest[:i])
+
+		window := OutageWindow{Mode: strings.TrimSpace(mode)}
+		var err error
+		if window.Duration, err = time.ParseDuration(strings.TrimSpace(rest[i+len(" for "):])); err != nil {
+			return nil, fmt.Errorf("window %q: invalid duration", part)
+		}
+		if after, relative := strings.CutPrefix(start, "+"); relative {
+			if window.After, err = time.ParseDuration(after); err != nil {
+				return nil, fmt.Errorf("window %q: invalid start", part)
+			}
+		} else if window.Cron, err = cron.Parse(start); err != nil {
+			return nil, fmt.Errorf("window %q: %w", part, err)
+		}
+		parsed = append(parsed, window)
+	}
+	return parsed, nil
+}
+
+// Validate checks the window has a known mode and a start and duration that can happen
+func (w OutageWindow) Validate() error {
+	if w.Mode != OutageMaintenance && w.Mode != OutageDegraded {
+		return fmt.Errorf("invalid outage mode: %s (must be %s or %s)", w.Mode, OutageMaintenance, OutageDegraded)
+	}
+	if w.After < 0 {
+		return fmt.Errorf("start cannot be negative")
+	}
+	if w.Duration <= 0 {
+		return fmt.Errorf("duration must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFaultScript(t *testing.T) {
+	tests := []struct {
+		name    string
+		script  string
+		wantErr string
+		want    []FaultStep
+	}{
+		{name: "empty", script: "", want: nil},
+		{
+			name:   "method and path",
+			script: "POST /api/v1/captures#3=error",
+			want:   []FaultStep{{Method: "POST", Path: "/api/v1/captures", Request: 3, Fault: FaultError}},
+		},
+		{
+			name:   "any request",
+			script: "#5=hang",
+			want:   []FaultStep{{Request: 5, Fault: FaultHang}},
+		},
+		{
+			name:   "path or method alone",
+			script: " /api/v1/voids#1=reset , get#2=bad_gateway ",
+			want: []FaultStep{
+				{Path: "/api/v1/voids", Request: 1, Fault: FaultReset},
+				{Method: "GET", Request: 2, Fault: FaultBadGateway},
+			},
+		},
+		{name: "missing fault", script: "#5", wantErr: "missing =fault"},
+		{name: "missing request number", script: "POST=error", wantErr: "missing #N"},
+		{name: "bad request number", script: "#five=error", wantErr: "invalid request number"},
+		{name: "too many fields", script: "POST /a /b#1=error", wantErr: "expected [METHOD] [PATH]#N=fault"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			steps, err := ParseFaultScript(tt.script)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, steps)
+		})
+	}
+}
+
+func TestParseEndpointRateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  string
+		wantErr string
+		want    []EndpointRateLimit
+	}{
+		{name: "empty", limits: "", want: nil},
+		{
+			name:   "method, path, rate and burst",
+			limits: "post /api/v1/captures=5:10",
+			want:   []EndpointRateLimit{{Method: "POST", Path: "/api/v1/captures", RequestsPerSecond: 5, Burst: 10}},
+		},
+		{
+			name:   "burst defaults to one second of requests",
+			limits: "/api/v1/transactions=2.5, /api/v1/refunds=0.2",
+			want: []EndpointRateLimit{
+				{Path: "/api/v1/transactions", RequestsPerSecond: 2.5, Burst: 3},
+				{Path: "/api/v1/refunds", RequestsPerSecond: 0.2, Burst: 1},
+			},
+		},
+		{name: "missing rate", limits: "/api/v1/captures", wantErr: "missing =rate"},
+		{name: "bad rate", limits: "/api/v1/captures=fast", wantErr: "invalid rate"},
+		{name: "bad burst", limits: "/api/v1/captures=1:many", wantErr: "invalid burst"},
+		{name: "missing path", limits: "=1", wantErr: "expected [METHOD] PATH=RATE[:BURST]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limits, err := ParseEndpointRateLimits(tt.limits)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, limits)
+		})
+	}
+}
+
+func TestParseOutageWindows(t *testing.T) {
+	windows, err := ParseOutageWindows("maintenance at +5m for 10m; degraded at */15 * * * * for 2m;")
+	require.NoError(t, err)
+	require.Len(t, windows, 2)
+
+	assert.Equal(t, OutageWindow{Mode: OutageMaintenance, After: 5 * time.Minute, Duration: 10 * time.Minute}, windows[0])
+
+	assert.Equal(t, OutageDegraded, windows[1].Mode)
+	assert.Equal(t, 2*time.Minute, windows[1].Duration)
+	require.NotNil(t, windows[1].Cron)
+	assert.Equal(t, "*/15 * * * *", windows[1].Cron.String())
+
+	empty, err := ParseOutageWindows("")
+	require.NoError(t, err)
+	assert.Nil(t, empty)
+
+	tests := []struct {
+		windows string
+		wantErr string
+	}{
+		{windows: "maintenance for 10m", wantErr: "expected MODE at START for DURATION"},
+		{windows: "maintenance at +5m", wantErr: "expected MODE at START for DURATION"},
+		{windows: "maintenance at +5m for ever", wantErr: "invalid duration"},
+		{windows: "maintenance at +soon for 10m", wantErr: "invalid start"},
+		{windows: "maintenance at 0 2 * * for 10m", wantErr: "expected 5 fields"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.windows, func(t *testing.T) {
+			_, err := ParseOutageWindows(tt.windows)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOutageWindow_Validate(t *testing.T) {
+	assert.NoError(t, OutageWindow{Mode: OutageDegraded, Duration: time.Minute}.Validate())
+	assert.ErrorContains(t, OutageWindow{Mode: "offline", Duration: time.Minute}.Validate(), "invalid outage mode: offline")
+	assert.ErrorContains(t, OutageWindow{Mode: OutageMaintenance, After: -time.Minute, Duration: time.Minute}.Validate(), "start cannot be negative")
+	assert.ErrorContains(t, OutageWindow{Mode: OutageMaintenance}.Validate(), "duration must be positive")
+}
+
+func TestLoad_HeldRequestsStayUnderLockTimeout(t *testing.T) {
+	_, err := Load()
+	require.NoError(t, err, "the defaults must be valid")
+
+	tests := []struct {
+		env     map[string]string
+		wantErr string
+	}{
+		{
+			env:     map[string]string{"MAX_LATENCY_MS": "30000"},
+			wantErr: "max latency (30000) must be less than the idempotency lock timeout (30s)",
+		},
+		{
+			env:     map[string]string{"DEGRADED_MAX_LATENCY_MS": "45000"},
+			wantErr: "degraded max latency (45000) must be less than the idempotency lock timeout (30s)",
+		},
+		{
+			env:     map[string]string{"FAULT_HANG_DURATION": "60s"},
+			wantErr: "fault hang duration (1m0s) plus max latency must be less than the idempotency lock timeout (30s)",
+		},
+		{
+			env:     map[string]string{"FAULT_HANG_DURATION": "26s"},
+			wantErr: "fault hang duration (26s) plus max latency must be less than the idempotency lock timeout (30s)",
+		},
+		{
+			env:     map[string]string{"FAULT_HANG_DURATION": "60s", "IDEMPOTENCY_LOCK_TIMEOUT": "2m"},
+			wantErr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.env), func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			_, err := Load()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
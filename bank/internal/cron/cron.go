@@ -0,0 +1,147 @@
+// Package cron parses five-field cron expressions for scheduling recurring events.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression: minute, hour, day of month, month and day
+// of week. Each field accepts *, numbers, ranges (1-5), steps (*/15, 0-30/10) and
+// comma-separated lists of those. As in classic cron, when both day fields are
+// restricted a time matches if either one does.
+type Schedule struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	// Sunday is 0, and also 7
+	dowBounds = bounds{0, 7}
+)
+
+// Parse parses a cron expression such as "*/15 * * * *" or "0 2 * * 1-5"
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		expr:    strings.Join(fields, " "),
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	targets := []struct {
+		bits   *uint64
+		name   string
+		bounds bounds
+	}{
+		{&s.minute, "minute", minuteBounds},
+		{&s.hour, "hour", hourBounds},
+		{&s.dom, "day of month", domBounds},
+		{&s.month, "month", monthBounds},
+		{&s.dow, "day of week", dowBounds},
+	}
+	for i, target := range targets {
+		bits, err := parseField(fields[i], target.bounds)
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %s: %w", expr, target.name, err)
+		}
+		*target.bits = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// parseField returns the values a field allows as a bit set
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		low, high := b.min, b.max
+		if rangePart != "*" {
+			lowStr, highStr, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = parseValue(lowStr, b); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if high, err = parseValue(highStr, b); err != nil {
+					return 0, err
+				}
+				if high < low {
+					return 0, fmt.Errorf("range %q ends before it starts", rangePart)
+				}
+			case !hasStep:
+				// A single value; with a step, e.g. 5/15, it runs to the end of the field
+				high = low
+			}
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(s string, b bounds) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, b.min, b.max)
+	}
+	return v, nil
+}
+
+// Matches reports whether the schedule fires in the minute containing t, in t's location
+func (s *Schedule) Matches(t time.Time) bool {
+	if s.minute&(1<<t.Minute()) == 0 ||
+		s.hour&(1<<t.Hour()) == 0 ||
+		s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.dom&(1<<t.Day()) != 0
+	dowMatch := s.dow&(1<<int(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// String returns the expression the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.expr
+}
@@ -0,0 +1,79 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "* * * *", wantErr: "expected 5 fields, got 4"},
+		{expr: "60 * * * *", wantErr: "minute: value 60 out of range 0-59"},
+		{expr: "* 24 * * *", wantErr: "hour: value 24 out of range 0-23"},
+		{expr: "* * 0 * *", wantErr: "day of month: value 0 out of range 1-31"},
+		{expr: "* * * 13 *", wantErr: "month: value 13 out of range 1-12"},
+		{expr: "* * * * 8", wantErr: "day of week: value 8 out of range 0-7"},
+		{expr: "*/0 * * * *", wantErr: `minute: invalid step "0"`},
+		{expr: "30-10 * * * *", wantErr: `minute: range "30-10" ends before it starts`},
+		{expr: "MON * * * *", wantErr: `minute: invalid value "MON"`},
+		{expr: "1,,2 * * * *", wantErr: `minute: invalid value ""`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	// 2026-03-02 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 3, day, hour, minute, 30, 0, time.UTC)
+	}
+
+	tests := []struct {
+		expr    string
+		matches []time.Time
+		misses  []time.Time
+	}{
+		{expr: "* * * * *", matches: []time.Time{at(2, 0, 0), at(7, 23, 59)}},
+		{expr: "*/15 * * * *", matches: []time.Time{at(2, 10, 0), at(2, 10, 45)}, misses: []time.Time{at(2, 10, 5)}},
+		{expr: "5/20 * * * *", matches: []time.Time{at(2, 10, 5), at(2, 10, 45)}, misses: []time.Time{at(2, 10, 0)}},
+		{expr: "0 2 * * *", matches: []time.Time{at(2, 2, 0)}, misses: []time.Time{at(2, 2, 1), at(2, 3, 0)}},
+		{expr: "0-10/5,30 9-17 * * *", matches: []time.Time{at(2, 9, 10), at(2, 17, 30)}, misses: []time.Time{at(2, 9, 15), at(2, 18, 0)}},
+		{expr: "0 0 * * 1-5", matches: []time.Time{at(2, 0, 0), at(6, 0, 0)}, misses: []time.Time{at(7, 0, 0), at(8, 0, 0)}},
+		{expr: "0 0 * * 7", matches: []time.Time{at(8, 0, 0)}, misses: []time.Time{at(2, 0, 0)}},
+		{expr: "0 0 1 * *", matches: []time.Time{at(1, 0, 0)}, misses: []time.Time{at(2, 0, 0)}},
+		// Both day fields restricted: either one matches
+		{expr: "0 0 1 * 1", matches: []time.Time{at(1, 0, 0), at(2, 0, 0)}, misses: []time.Time{at(3, 0, 0)}},
+		{expr: "0 0 * 4 *", misses: []time.Time{at(2, 0, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			for _, tm := range tt.matches {
+				assert.True(t, s.Matches(tm), "should fire at %s", tm)
+			}
+			for _, tm := range tt.misses {
+				assert.False(t, s.Matches(tm), "should not fire at %s", tm)
+			}
+		})
+	}
+}
+
+func TestSchedule_String(t *testing.T) {
+	s, err := Parse("  */15   *  * * * ")
+	require.NoError(t, err)
+	assert.Equal(t, "*/15 * * * *", s.String())
+}
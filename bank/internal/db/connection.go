@@ -28,7 +28,9 @@ type DB struct {
 // Tx wraps a database transaction
 type Tx struct {
 	*sql.Tx
-	logger *slog.Logger
+	logger  *slog.Logger
+	pending *PendingTx // set when Commit and Rollback are deferred to a PendingTx
+	done    bool
 }
 
 // Connect establishes a connection to the database
@@ -73,7 +75,32 @@ func (db *DB) Close() error {
 }
 
 // BeginTx starts a new database transaction with the specified isolation level
+// If ctx comes from WithPendingTx, the transaction is shared with the rest of the
+// request and only committed by the PendingTx.
 func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	if pending, ok := PendingFromContext(ctx); ok {
+		shared, err := pending.Join(func() (Committer, error) {
+			return db.begin(ctx, opts)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		sqlTx, ok := shared.(*Tx)
+		if !ok {
+			return nil, errors.New("pending transaction belongs to another storage backend")
+		}
+		return &Tx{
+			Tx:      sqlTx.Tx,
+			logger:  db.logger,
+			pending: pending,
+		}, nil
+	}
+
+	return db.begin(ctx, opts)
+}
+
+func (db *DB) begin(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 	tx, err := db.DB.BeginTx(ctx, opts)
 	if err != nil {
 		db.logger.Error("failed to begin transaction", "error", err)
@@ -88,7 +115,14 @@ func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
 }
 
 // Commit commits the transaction
+// A transaction from a pending context is left open for the PendingTx to commit.
 func (tx *Tx) Commit() error {
+	if tx.pending != nil {
+		tx.done = true
+		tx.logger.Debug("transaction commit deferred")
+		return nil
+	}
+
 	if err := tx.Tx.Commit(); err != nil {
 		tx.logger.Error("failed to commit transaction", "error", err)
 		return fmt.Errorf("failed to commit transaction: %w", err)
@@ -99,7 +133,18 @@ func (tx *Tx) Commit() error {
 }
 
 // Rollback rolls back the transaction
+// For a transaction from a pending context, rolling back before Commit marks
+// the whole pending transaction to be rolled back.
 func (tx *Tx) Rollback() error {
+	if tx.pending != nil {
+		if !tx.done {
+			tx.done = true
+			tx.pending.MarkRollbackOnly()
+			tx.logger.Debug("pending transaction marked for rollback")
+		}
+		return nil
+	}
+
 	if err := tx.Tx.Rollback(); err != nil {
 		if errors.Is(err, sql.ErrTxDone) {
 			tx.logger.Debug("transaction already closed, ignoring rollback")
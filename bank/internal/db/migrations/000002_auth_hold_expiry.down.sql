@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_transactions_active_holds_expires_at;
@@ -0,0 +1,3 @@
+-- Support the expiry sweeper's lookup of active holds past expires_at
+CREATE INDEX IF NOT EXISTS idx_transactions_active_holds_expires_at ON transactions(expires_at)
+WHERE type = 'AUTH_HOLD' AND status = 'ACTIVE';
@@ -0,0 +1,20 @@
+-- Irreversible once an authorization has been captured more than once: the old unique
+-- index allows one capture per authorization, and dropping captures would leave balances
+-- that no longer match the ledger. Refuse instead of failing halfway or losing data.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM transactions
+        WHERE type = 'CAPTURE' AND reference_id IS NOT NULL
+        GROUP BY reference_id
+        HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'cannot roll back partial captures: some authorizations have more than one capture';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_transactions_reference_type_unique;
+CREATE UNIQUE INDEX idx_transactions_reference_type_unique ON transactions(reference_id, type)
+WHERE type IN ('CAPTURE', 'VOID', 'REFUND') AND reference_id IS NOT NULL;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS captured_amount_cents;
@@ -0,0 +1,14 @@
+-- Track the running capture total on each authorization hold
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS captured_amount_cents BIGINT NOT NULL DEFAULT 0;
+
+UPDATE transactions AS auth
+SET captured_amount_cents = cap.amount_cents
+FROM transactions AS cap
+WHERE cap.reference_id = auth.id
+  AND cap.type = 'CAPTURE'
+  AND auth.type = 'AUTH_HOLD';
+
+-- Allow several captures per authorization; voids and refunds stay unique
+DROP INDEX IF EXISTS idx_transactions_reference_type_unique;
+CREATE UNIQUE INDEX idx_transactions_reference_type_unique ON transactions(reference_id, type)
+WHERE type IN ('VOID', 'REFUND') AND reference_id IS NOT NULL;
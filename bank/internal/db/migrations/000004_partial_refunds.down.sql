@@ -0,0 +1,20 @@
+-- Irreversible once a capture has been refunded more than once: the old unique index
+-- allows one refund per capture, and dropping refunds would leave balances that no
+-- longer match the ledger. Refuse instead of failing halfway or losing data.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM transactions
+        WHERE type = 'REFUND' AND reference_id IS NOT NULL
+        GROUP BY reference_id
+        HAVING COUNT(*) > 1
+    ) THEN
+        RAISE EXCEPTION 'cannot roll back partial refunds: some captures have more than one refund';
+    END IF;
+END $$;
+
+DROP INDEX IF EXISTS idx_transactions_reference_type_unique;
+CREATE UNIQUE INDEX idx_transactions_reference_type_unique ON transactions(reference_id, type)
+WHERE type IN ('VOID', 'REFUND') AND reference_id IS NOT NULL;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS refunded_amount_cents;
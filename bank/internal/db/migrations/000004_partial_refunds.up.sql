@@ -0,0 +1,14 @@
+-- Track the running refund total on each capture
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS refunded_amount_cents BIGINT NOT NULL DEFAULT 0;
+
+UPDATE transactions AS cap
+SET refunded_amount_cents = ref.amount_cents
+FROM transactions AS ref
+WHERE ref.reference_id = cap.id
+  AND ref.type = 'REFUND'
+  AND cap.type = 'CAPTURE';
+
+-- Allow several refunds per capture; only voids stay unique
+DROP INDEX IF EXISTS idx_transactions_reference_type_unique;
+CREATE UNIQUE INDEX idx_transactions_reference_type_unique ON transactions(reference_id, type)
+WHERE type = 'VOID' AND reference_id IS NOT NULL;
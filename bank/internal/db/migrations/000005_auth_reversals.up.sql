@@ -0,0 +1,2 @@
+-- Track the running total released from each authorization hold by partial reversals
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversed_amount_cents BIGINT NOT NULL DEFAULT 0;
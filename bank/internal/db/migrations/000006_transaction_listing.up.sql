@@ -0,0 +1,2 @@
+-- Keyset pagination for transaction listings walks (created_at, id) in order
+CREATE INDEX IF NOT EXISTS idx_transactions_created_at_id ON transactions(created_at, id);
@@ -0,0 +1,2 @@
+-- Lets listings find transactions by merchant metadata such as order_id
+CREATE INDEX IF NOT EXISTS idx_transactions_metadata ON transactions USING GIN (metadata);
@@ -0,0 +1,2 @@
+-- Fingerprint of the request body so a reused key with a different payload can be rejected
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS request_hash VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DELETE FROM idempotency_keys WHERE locked_at IS NOT NULL;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS locked_at;
@@ -0,0 +1,2 @@
+-- Set while a request holding the key is still being processed, NULL once its response is stored
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS locked_at TIMESTAMP;
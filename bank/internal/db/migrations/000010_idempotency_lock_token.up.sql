@@ -0,0 +1,5 @@
+-- Identifies the request holding a reservation, so a request whose reservation was
+-- taken over cannot store or release the new holder's key. Reservations made before
+-- tokens existed can never be completed, so they are dropped.
+DELETE FROM idempotency_keys WHERE locked_at IS NOT NULL;
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS lock_token TEXT;
@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"errors"
+)
+
+type pendingTxKey struct{}
+
+// Committer is a transaction whose outcome a PendingTx decides
+type Committer interface {
+	Commit() error
+	Rollback() error
+}
+
+// PendingTx holds back the commit of the transaction used by a request, so the
+// caller can add its own writes before the request's changes become visible.
+//
+// BeginTx on a context returned by WithPendingTx starts a single transaction on
+// first use and hands it to every later BeginTx with that context. Commit and
+// Rollback on the returned Tx are deferred; the owner of the PendingTx decides
+// the outcome. A PendingTx is not safe for concurrent use.
+//
+// Storage backends other than Postgres take part through Join and MarkRollbackOnly.
+type PendingTx struct {
+	tx           Committer
+	rollbackOnly bool
+}
+
+// WithPendingTx returns a context whose transactions are committed by the returned PendingTx
+func WithPendingTx(ctx context.Context) (context.Context, *PendingTx) {
+	pending := &PendingTx{}
+	return context.WithValue(ctx, pendingTxKey{}, pending), pending
+}
+
+// PendingFromContext returns the PendingTx carried by ctx, if any
+func PendingFromContext(ctx context.Context) (*PendingTx, bool) {
+	pending, ok := ctx.Value(pendingTxKey{}).(*PendingTx)
+	return pending, ok
+}
+
+// TxFromContext returns the open pending transaction carried by ctx, or nil
+// when there is none
+func TxFromContext(ctx context.Context) Committer {
+	pending, ok := PendingFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return pending.tx
+}
+
+// ExecutorFromContext returns the open pending transaction carried by ctx,
+// or fallback when there is none
+func ExecutorFromContext(ctx context.Context, fallback Executor) Executor {
+	if exec, ok := TxFromContext(ctx).(Executor); ok {
+		return exec
+	}
+	return fallback
+}
+
+// Join returns the transaction shared through p, starting it with begin on first use.
+// Callers must not commit the returned transaction themselves; a user that gives up
+// on it reports so with MarkRollbackOnly.
+func (p *PendingTx) Join(begin func() (Committer, error)) (Committer, error) {
+	if p.tx == nil {
+		tx, err := begin()
+		if err != nil {
+			return nil, err
+		}
+		p.tx = tx
+	}
+	return p.tx, nil
+}
+
+// MarkRollbackOnly makes Commit roll the pending transaction back instead
+func (p *PendingTx) MarkRollbackOnly() {
+	p.rollbackOnly = true
+}
+
+// Started reports whether a transaction has been opened through the pending context
+func (p *PendingTx) Started() bool {
+	return p.tx != nil
+}
+
+// Commit commits the pending transaction. It fails without committing if any
+// user of the transaction rolled it back. Without a transaction it does nothing.
+func (p *PendingTx) Commit() error {
+	if p.tx == nil {
+		return nil
+	}
+	if p.rollbackOnly {
+		if err := p.Rollback(); err != nil {
+			return err
+		}
+		return errors.New("transaction was rolled back by its caller")
+	}
+
+	tx := p.tx
+	p.tx = nil
+	return tx.Commit()
+}
+
+// Rollback rolls back the pending transaction. Without a transaction it does nothing.
+func (p *PendingTx) Rollback() error {
+	if p.tx == nil {
+		return nil
+	}
+
+	tx := p.tx
+	p.tx = nil
+	return tx.Rollback()
+}
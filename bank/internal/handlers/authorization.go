@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/models"
 )
 
 // CreateAuthorization handles POST /api/v1/authorizations
@@ -16,7 +17,10 @@ func (h *Handler) CreateAuthorization(
 		ctx,
 		request.Body.CardNumber,
 		request.Body.Cvv,
+		request.Body.ExpiryMonth,
+		request.Body.ExpiryYear,
 		request.Body.Amount,
+		request.Body.Metadata,
 	)
 
 	if err != nil {
@@ -27,9 +31,15 @@ func (h *Handler) CreateAuthorization(
 		AuthorizationId: formatAuthorizationID(txn.ID),
 		Status:          api.Approved,
 		Amount:          txn.AmountCents,
+		CapturedAmount:  txn.CapturedAmountCents,
+		ReversedAmount:  txn.ReversedAmountCents,
+		RemainingAmount: txn.RemainingAmountCents(),
 		Currency:        txn.Currency,
 		ExpiresAt:       *txn.ExpiresAt,
 		CreatedAt:       txn.CreatedAt,
+		CaptureIds:      []string{},
+		History:         []api.AuthorizationEvent{},
+		Metadata:        txn.Metadata,
 	}, nil
 }
 
@@ -60,21 +70,139 @@ func (h *Handler) GetAuthorization(
 		}, nil
 	}
 
+	history, err := h.authService.GetAuthorizationHistory(ctx, authID)
+	if err != nil {
+		h.logger.Error("failed to load authorization history", "error", err)
+		return api.GetAuthorization500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error:   api.ErrorCodeInternalError,
+				Message: "internal error",
+			},
+		}, nil
+	}
+
 	expiresAt := time.Time{}
 	if txn.ExpiresAt != nil {
 		expiresAt = *txn.ExpiresAt
 	}
 
+	captureIDs := []string{}
+	voidID := ""
+	for _, child := range history {
+		switch child.Type {
+		case models.TransactionTypeCapture:
+			captureIDs = append(captureIDs, formatCaptureID(child.ID))
+		case models.TransactionTypeVoid:
+			voidID = formatVoidID(child.ID)
+		}
+	}
+
 	return api.GetAuthorization200JSONResponse{
 		AuthorizationId: formatAuthorizationID(txn.ID),
-		Status:          api.Approved,
+		Status:          authorizationLifecycleStatus(txn, len(captureIDs) > 0, h.clock.Now()),
 		Amount:          txn.AmountCents,
+		CapturedAmount:  txn.CapturedAmountCents,
+		ReversedAmount:  txn.ReversedAmountCents,
+		RemainingAmount: txn.RemainingAmountCents(),
 		Currency:        txn.Currency,
 		ExpiresAt:       expiresAt,
 		CreatedAt:       txn.CreatedAt,
+		CaptureIds:      captureIDs,
+		VoidId:          voidID,
+		History:         toAuthorizationEvents(history),
+		Metadata:        txn.Metadata,
+	}, nil
+}
+
+// CreateAuthorizationIncrement handles POST /api/v1/authorizations/{authorizationId}/increments
+func (h *Handler) CreateAuthorizationIncrement(
+	ctx context.Context,
+	request api.CreateAuthorizationIncrementRequestObject,
+) (api.CreateAuthorizationIncrementResponseObject, error) {
+	authID, err := parseAuthorizationID(request.AuthorizationId)
+	if err != nil {
+		//nolint:nilerr // Returning 400 response object, not propagating error
+		return api.CreateAuthorizationIncrement400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error:   api.ErrorCodeAuthorizationNotFound,
+				Message: "invalid authorization ID format",
+			},
+		}, nil
+	}
+
+	incrementTxn, authTxn, err := h.authService.IncrementAuthorization(ctx, authID, request.Body.Amount)
+	if err != nil {
+		return h.handleIncrementError(err)
+	}
+
+	expiresAt := time.Time{}
+	if authTxn.ExpiresAt != nil {
+		expiresAt = *authTxn.ExpiresAt
+	}
+
+	return api.CreateAuthorizationIncrement200JSONResponse{
+		IncrementId:      formatIncrementID(incrementTxn.ID),
+		AuthorizationId:  formatAuthorizationID(authTxn.ID),
+		Status:           api.Incremented,
+		Amount:           incrementTxn.AmountCents,
+		AuthorizedAmount: authTxn.AmountCents,
+		RemainingAmount:  authTxn.RemainingAmountCents(),
+		Currency:         incrementTxn.Currency,
+		ExpiresAt:        expiresAt,
+		CreatedAt:        incrementTxn.CreatedAt,
 	}, nil
 }
 
+// authorizationLifecycleStatus reports where an authorization hold is in its lifecycle.
+// A hold past its expiry that the sweeper has not reached yet already counts as expired,
+// since it can no longer be captured. A completed hold without any capture was released
+// by a void or by reversing all of it.
+func authorizationLifecycleStatus(
+	txn *models.Transaction,
+	captured bool,
+	now time.Time,
+) api.AuthorizationResponseStatus {
+	switch txn.Status {
+	case models.TransactionStatusExpired:
+		return api.Expired
+	case models.TransactionStatusCompleted:
+		if captured {
+			return api.AuthorizationCaptured
+		}
+		return api.AuthorizationVoided
+	default:
+		if txn.ExpiresAt != nil && now.After(*txn.ExpiresAt) {
+			return api.Expired
+		}
+		return api.Active
+	}
+}
+
+// toAuthorizationEvents converts the ledger entries recorded against an
+// authorization into history events, skipping types that do not belong there
+func toAuthorizationEvents(txns []*models.Transaction) []api.AuthorizationEvent {
+	events := make([]api.AuthorizationEvent, 0, len(txns))
+	for _, txn := range txns {
+		var event api.AuthorizationEvent
+		switch txn.Type {
+		case models.TransactionTypeAuthIncrement:
+			event = api.AuthorizationEvent{Id: formatIncrementID(txn.ID), Type: api.Increment}
+		case models.TransactionTypeCapture:
+			event = api.AuthorizationEvent{Id: formatCaptureID(txn.ID), Type: api.Capture}
+		case models.TransactionTypeAuthReversal:
+			event = api.AuthorizationEvent{Id: formatReversalID(txn.ID), Type: api.Reversal}
+		case models.TransactionTypeVoid:
+			event = api.AuthorizationEvent{Id: formatVoidID(txn.ID), Type: api.Void}
+		default:
+			continue
+		}
+		event.Amount = txn.AmountCents
+		event.CreatedAt = txn.CreatedAt
+		events = append(events, event)
+	}
+	return events
+}
+
 // handleAuthorizationError maps service errors to appropriate HTTP responses
 func (h *Handler) handleAuthorizationError(
 	err error,
@@ -108,3 +236,37 @@ func (h *Handler) handleAuthorizationError(
 		},
 	}, nil
 }
+
+// handleIncrementError maps service errors to appropriate HTTP responses
+func (h *Handler) handleIncrementError(
+	err error,
+) (api.CreateAuthorizationIncrementResponseObject, error) {
+	svcErr := extractServiceError(err)
+	if svcErr == nil {
+		h.logger.Error("unexpected error during authorization increment", "error", err)
+		return api.CreateAuthorizationIncrement500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error:   api.ErrorCodeInternalError,
+				Message: "internal error",
+			},
+		}, nil
+	}
+
+	errorCode := mapServiceErrorToCode(svcErr.Code)
+
+	if isPaymentRequiredError(svcErr.Code) {
+		return api.CreateAuthorizationIncrement402JSONResponse{
+			PaymentRequiredJSONResponse: api.PaymentRequiredJSONResponse{
+				Error:   errorCode,
+				Message: svcErr.Message,
+			},
+		}, nil
+	}
+
+	return api.CreateAuthorizationIncrement400JSONResponse{
+		BadRequestJSONResponse: api.BadRequestJSONResponse{
+			Error:   errorCode,
+			Message: svcErr.Message,
+		},
+	}, nil
+}
@@ -23,25 +23,31 @@ func testLogger() *slog.Logger {
 
 func TestCreateAuthorization_Success(t *testing.T) {
 	mockAuth := mocks.NewMockAuthorizer(t)
-	handler := NewHandler(mockAuth, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
 
 	txnID := uuid.New()
 	expiresAt := time.Now().Add(24 * time.Hour)
 
-	mockAuth.On("Authorize", mock.Anything, "4111111111111111", "123", int64(10000)).
+	metadata := map[string]string{"order_id": "ord_123"}
+
+	mockAuth.On("Authorize", mock.Anything, "4111111111111111", "123", 12, 2030, int64(10000), metadata).
 		Return(&models.Transaction{
 			ID:          txnID,
 			AmountCents: 10000,
 			Currency:    "USD",
 			ExpiresAt:   &expiresAt,
+			Metadata:    metadata,
 			CreatedAt:   time.Now(),
 		}, nil)
 
 	req := api.CreateAuthorizationRequestObject{
 		Body: &api.CreateAuthorizationJSONRequestBody{
-			CardNumber: "4111111111111111",
-			Cvv:        "123",
-			Amount:     10000,
+			CardNumber:  "4111111111111111",
+			Cvv:         "123",
+			ExpiryMonth: 12,
+			ExpiryYear:  2030,
+			Amount:      10000,
+			Metadata:    metadata,
 		},
 	}
 
@@ -52,6 +58,7 @@ func TestCreateAuthorization_Success(t *testing.T) {
 	require.True(t, ok, "expected 200 response")
 	assert.Equal(t, api.Approved, successResp.Status)
 	assert.Equal(t, int64(10000), successResp.Amount)
+	assert.Equal(t, api.Metadata(metadata), successResp.Metadata)
 }
 
 func TestCreateAuthorization_ServiceErrors(t *testing.T) {
@@ -67,6 +74,18 @@ func TestCreateAuthorization_ServiceErrors(t *testing.T) {
 			expectedStatus: 400,
 			expectedCode:   api.ErrorCodeInvalidCard,
 		},
+		{
+			name:           "invalid expiry returns 400",
+			serviceErr:     &service.ServiceError{Code: service.ErrCodeInvalidExpiry, Message: "expiry date does not match"},
+			expectedStatus: 400,
+			expectedCode:   api.ErrorCodeInvalidExpiry,
+		},
+		{
+			name:           "invalid metadata returns 400",
+			serviceErr:     &service.ServiceError{Code: service.ErrCodeInvalidMetadata, Message: "too many keys"},
+			expectedStatus: 400,
+			expectedCode:   api.ErrorCodeInvalidMetadata,
+		},
 		{
 			name:           "insufficient funds returns 402",
 			serviceErr:     &service.ServiceError{Code: service.ErrCodeInsufficientFunds, Message: "insufficient"},
@@ -78,9 +97,9 @@ func TestCreateAuthorization_ServiceErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAuth := mocks.NewMockAuthorizer(t)
-			handler := NewHandler(mockAuth, nil, nil, nil, nil, testLogger())
+			handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
 
-			mockAuth.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			mockAuth.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil, tt.serviceErr)
 
 			req := api.CreateAuthorizationRequestObject{
@@ -110,18 +129,59 @@ func TestCreateAuthorization_ServiceErrors(t *testing.T) {
 
 func TestGetAuthorization_Success(t *testing.T) {
 	mockAuth := mocks.NewMockAuthorizer(t)
-	handler := NewHandler(mockAuth, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
 
 	txnID := uuid.New()
 	expiresAt := time.Now().Add(24 * time.Hour)
 
 	mockAuth.On("GetAuthorization", mock.Anything, txnID).
 		Return(&models.Transaction{
-			ID:          txnID,
-			AmountCents: 10000,
-			Currency:    "USD",
-			ExpiresAt:   &expiresAt,
-			CreatedAt:   time.Now(),
+			ID:                  txnID,
+			AmountCents:         10000,
+			CapturedAmountCents: 4000,
+			Currency:            "USD",
+			Status:              models.TransactionStatusActive,
+			ExpiresAt:           &expiresAt,
+			CreatedAt:           time.Now(),
+		}, nil)
+	mockAuth.On("GetAuthorizationHistory", mock.Anything, txnID).Return([]*models.Transaction{}, nil)
+
+	req := api.GetAuthorizationRequestObject{
+		AuthorizationId: "auth_" + txnID.String(),
+	}
+
+	resp, err := handler.GetAuthorization(context.Background(), req)
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.GetAuthorization200JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, int64(4000), successResp.CapturedAmount)
+	assert.Equal(t, int64(6000), successResp.RemainingAmount)
+}
+
+func TestGetAuthorization_History(t *testing.T) {
+	mockAuth := mocks.NewMockAuthorizer(t)
+	handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
+
+	txnID := uuid.New()
+	captureID := uuid.New()
+	reversalID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	mockAuth.On("GetAuthorization", mock.Anything, txnID).
+		Return(&models.Transaction{
+			ID:                  txnID,
+			AmountCents:         10000,
+			CapturedAmountCents: 4000,
+			ReversedAmountCents: 1500,
+			Currency:            "USD",
+			Status:              models.TransactionStatusActive,
+			ExpiresAt:           &expiresAt,
+		}, nil)
+	mockAuth.On("GetAuthorizationHistory", mock.Anything, txnID).
+		Return([]*models.Transaction{
+			{ID: captureID, Type: models.TransactionTypeCapture, AmountCents: 4000},
+			{ID: reversalID, Type: models.TransactionTypeAuthReversal, AmountCents: 1500},
 		}, nil)
 
 	req := api.GetAuthorizationRequestObject{
@@ -131,13 +191,24 @@ func TestGetAuthorization_Success(t *testing.T) {
 	resp, err := handler.GetAuthorization(context.Background(), req)
 
 	require.NoError(t, err)
-	_, ok := resp.(api.GetAuthorization200JSONResponse)
+	successResp, ok := resp.(api.GetAuthorization200JSONResponse)
 	require.True(t, ok)
+	assert.Equal(t, api.Active, successResp.Status)
+	assert.Equal(t, int64(1500), successResp.ReversedAmount)
+	assert.Equal(t, int64(4500), successResp.RemainingAmount)
+	assert.Equal(t, []string{"cap_" + captureID.String()}, successResp.CaptureIds)
+	assert.Empty(t, successResp.VoidId)
+	require.Len(t, successResp.History, 2)
+	assert.Equal(t, "cap_"+captureID.String(), successResp.History[0].Id)
+	assert.Equal(t, api.Capture, successResp.History[0].Type)
+	assert.Equal(t, "rev_"+reversalID.String(), successResp.History[1].Id)
+	assert.Equal(t, api.Reversal, successResp.History[1].Type)
+	assert.Equal(t, int64(1500), successResp.History[1].Amount)
 }
 
 func TestGetAuthorization_NotFound(t *testing.T) {
 	mockAuth := mocks.NewMockAuthorizer(t)
-	handler := NewHandler(mockAuth, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
 
 	txnID := uuid.New()
 	mockAuth.On("GetAuthorization", mock.Anything, txnID).
@@ -156,7 +227,7 @@ func TestGetAuthorization_NotFound(t *testing.T) {
 }
 
 func TestGetAuthorization_InvalidIDFormat(t *testing.T) {
-	handler := NewHandler(nil, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Logger: testLogger()})
 
 	req := api.GetAuthorizationRequestObject{
 		AuthorizationId: "invalid-format",
@@ -168,3 +239,164 @@ func TestGetAuthorization_InvalidIDFormat(t *testing.T) {
 	_, ok := resp.(api.GetAuthorization404JSONResponse)
 	require.True(t, ok, "invalid ID format should return 404")
 }
+
+func TestCreateAuthorizationIncrement_Success(t *testing.T) {
+	mockAuth := mocks.NewMockAuthorizer(t)
+	handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
+
+	authID := uuid.New()
+	incrementID := uuid.New()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	mockAuth.On("IncrementAuthorization", mock.Anything, authID, int64(2500)).
+		Return(&models.Transaction{
+			ID:          incrementID,
+			ReferenceID: &authID,
+			AmountCents: 2500,
+			Currency:    "USD",
+			CreatedAt:   time.Now(),
+		}, &models.Transaction{
+			ID:                  authID,
+			AmountCents:         12500,
+			CapturedAmountCents: 4000,
+			Currency:            "USD",
+			Status:              models.TransactionStatusActive,
+			ExpiresAt:           &expiresAt,
+		}, nil)
+
+	req := api.CreateAuthorizationIncrementRequestObject{
+		AuthorizationId: "auth_" + authID.String(),
+		Body:            &api.CreateAuthorizationIncrementJSONRequestBody{Amount: 2500},
+	}
+
+	resp, err := handler.CreateAuthorizationIncrement(context.Background(), req)
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.CreateAuthorizationIncrement200JSONResponse)
+	require.True(t, ok, "expected 200 response")
+	assert.Equal(t, "inc_"+incrementID.String(), successResp.IncrementId)
+	assert.Equal(t, api.Incremented, successResp.Status)
+	assert.Equal(t, int64(2500), successResp.Amount)
+	assert.Equal(t, int64(12500), successResp.AuthorizedAmount)
+	assert.Equal(t, int64(8500), successResp.RemainingAmount)
+	assert.Equal(t, expiresAt, successResp.ExpiresAt)
+}
+
+func TestCreateAuthorizationIncrement_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		serviceErr     *service.ServiceError
+		name           string
+		expectedCode   api.ErrorCode
+		expectedStatus int
+	}{
+		{
+			name:           "used authorization returns 400",
+			serviceErr:     &service.ServiceError{Code: service.ErrCodeAuthAlreadyUsed, Message: "used"},
+			expectedStatus: 400,
+			expectedCode:   api.ErrorCodeAuthorizationAlreadyUsed,
+		},
+		{
+			name:           "insufficient funds returns 402",
+			serviceErr:     &service.ServiceError{Code: service.ErrCodeInsufficientFunds, Message: "insufficient"},
+			expectedStatus: 402,
+			expectedCode:   api.ErrorCodeInsufficientFunds,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := mocks.NewMockAuthorizer(t)
+			handler := NewHandler(Dependencies{Auth: mockAuth, Logger: testLogger()})
+
+			mockAuth.On("IncrementAuthorization", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, nil, tt.serviceErr)
+
+			req := api.CreateAuthorizationIncrementRequestObject{
+				AuthorizationId: "auth_" + uuid.New().String(),
+				Body:            &api.CreateAuthorizationIncrementJSONRequestBody{Amount: 2500},
+			}
+
+			resp, err := handler.CreateAuthorizationIncrement(context.Background(), req)
+			require.NoError(t, err)
+
+			switch tt.expectedStatus {
+			case 400:
+				badResp, ok := resp.(api.CreateAuthorizationIncrement400JSONResponse)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, badResp.Error)
+			case 402:
+				payResp, ok := resp.(api.CreateAuthorizationIncrement402JSONResponse)
+				require.True(t, ok)
+				assert.Equal(t, tt.expectedCode, payResp.Error)
+			}
+		})
+	}
+}
+
+func TestCreateAuthorizationIncrement_InvalidIDFormat(t *testing.T) {
+	handler := NewHandler(Dependencies{Logger: testLogger()})
+
+	req := api.CreateAuthorizationIncrementRequestObject{
+		AuthorizationId: "invalid-id",
+		Body:            &api.CreateAuthorizationIncrementJSONRequestBody{Amount: 2500},
+	}
+
+	resp, err := handler.CreateAuthorizationIncrement(context.Background(), req)
+
+	require.NoError(t, err)
+	badResp, ok := resp.(api.CreateAuthorizationIncrement400JSONResponse)
+	require.True(t, ok, "expected 400 response")
+	assert.Equal(t, api.ErrorCodeAuthorizationNotFound, badResp.Error)
+}
+
+func TestAuthorizationLifecycleStatus(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		txn      *models.Transaction
+		name     string
+		expected api.AuthorizationResponseStatus
+		captured bool
+	}{
+		{
+			name:     "active hold",
+			txn:      &models.Transaction{Status: models.TransactionStatusActive, ExpiresAt: &future},
+			expected: api.Active,
+		},
+		{
+			name:     "partially captured hold is still active",
+			txn:      &models.Transaction{Status: models.TransactionStatusActive, ExpiresAt: &future},
+			captured: true,
+			expected: api.Active,
+		},
+		{
+			name:     "active hold past expiry not yet swept",
+			txn:      &models.Transaction{Status: models.TransactionStatusActive, ExpiresAt: &past},
+			expected: api.Expired,
+		},
+		{
+			name:     "swept hold",
+			txn:      &models.Transaction{Status: models.TransactionStatusExpired, ExpiresAt: &past},
+			expected: api.Expired,
+		},
+		{
+			name:     "completed with capture",
+			txn:      &models.Transaction{Status: models.TransactionStatusCompleted, ExpiresAt: &future},
+			captured: true,
+			expected: api.AuthorizationCaptured,
+		},
+		{
+			name:     "completed without capture",
+			txn:      &models.Transaction{Status: models.TransactionStatusCompleted, ExpiresAt: &future},
+			expected: api.AuthorizationVoided,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, authorizationLifecycleStatus(tt.txn, tt.captured, now))
+		})
+	}
+}
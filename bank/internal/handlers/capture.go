@@ -22,18 +22,21 @@ func (h *Handler) CreateCapture(
 		}, nil
 	}
 
-	txn, err := h.captureService.Capture(ctx, authID, request.Body.Amount)
+	txn, err := h.captureService.Capture(ctx, authID, request.Body.Amount, request.Body.FinalCapture, request.Body.Metadata)
 	if err != nil {
 		return h.handleCaptureError(err)
 	}
 
 	return api.CreateCapture200JSONResponse{
-		CaptureId:       formatCaptureID(txn.ID),
-		AuthorizationId: formatAuthorizationID(*txn.ReferenceID),
-		Status:          api.Captured,
-		Amount:          txn.AmountCents,
-		Currency:        txn.Currency,
-		CapturedAt:      txn.CreatedAt,
+		CaptureId:        formatCaptureID(txn.ID),
+		AuthorizationId:  formatAuthorizationID(*txn.ReferenceID),
+		Status:           api.Captured,
+		Amount:           txn.AmountCents,
+		RefundedAmount:   txn.RefundedAmountCents,
+		RefundableAmount: txn.RefundableAmountCents(),
+		Currency:         txn.Currency,
+		CapturedAt:       txn.CreatedAt,
+		Metadata:         txn.Metadata,
 	}, nil
 }
 
@@ -65,12 +68,15 @@ func (h *Handler) GetCapture(
 	}
 
 	return api.GetCapture200JSONResponse{
-		CaptureId:       formatCaptureID(txn.ID),
-		AuthorizationId: formatAuthorizationID(*txn.ReferenceID),
-		Status:          api.Captured,
-		Amount:          txn.AmountCents,
-		Currency:        txn.Currency,
-		CapturedAt:      txn.CreatedAt,
+		CaptureId:        formatCaptureID(txn.ID),
+		AuthorizationId:  formatAuthorizationID(*txn.ReferenceID),
+		Status:           api.Captured,
+		Amount:           txn.AmountCents,
+		RefundedAmount:   txn.RefundedAmountCents,
+		RefundableAmount: txn.RefundableAmountCents(),
+		Currency:         txn.Currency,
+		CapturedAt:       txn.CreatedAt,
+		Metadata:         txn.Metadata,
 	}, nil
 }
 
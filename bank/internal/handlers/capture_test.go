@@ -17,12 +17,12 @@ import (
 
 func TestCreateCapture_Success(t *testing.T) {
 	mockCapture := mocks.NewMockCapturer(t)
-	handler := NewHandler(nil, mockCapture, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Capture: mockCapture, Logger: testLogger()})
 
 	authID := uuid.New()
 	captureID := uuid.New()
 
-	mockCapture.On("Capture", mock.Anything, authID, int64(10000)).
+	mockCapture.On("Capture", mock.Anything, authID, int64(10000), false, map[string]string(nil)).
 		Return(&models.Transaction{
 			ID:          captureID,
 			ReferenceID: &authID,
@@ -55,14 +55,15 @@ func TestCreateCapture_ServiceErrors(t *testing.T) {
 		{"auth not found", &service.ServiceError{Code: service.ErrCodeAuthNotFound}, api.ErrorCodeAuthorizationNotFound},
 		{"auth expired", &service.ServiceError{Code: service.ErrCodeAuthExpired}, api.ErrorCodeAuthorizationExpired},
 		{"already captured", &service.ServiceError{Code: service.ErrCodeAlreadyCaptured}, api.ErrorCodeAlreadyCaptured},
+		{"exceeds authorization", &service.ServiceError{Code: service.ErrCodeCaptureExceedsAuth}, api.ErrorCodeCaptureExceedsAuthorization},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockCapture := mocks.NewMockCapturer(t)
-			handler := NewHandler(nil, mockCapture, nil, nil, nil, testLogger())
+			handler := NewHandler(Dependencies{Capture: mockCapture, Logger: testLogger()})
 
-			mockCapture.On("Capture", mock.Anything, mock.Anything, mock.Anything).
+			mockCapture.On("Capture", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil, tt.serviceErr)
 
 			req := api.CreateCaptureRequestObject{
@@ -82,8 +83,39 @@ func TestCreateCapture_ServiceErrors(t *testing.T) {
 	}
 }
 
+func TestCreateCapture_FinalCapturePassedThrough(t *testing.T) {
+	mockCapture := mocks.NewMockCapturer(t)
+	handler := NewHandler(Dependencies{Capture: mockCapture, Logger: testLogger()})
+
+	authID := uuid.New()
+
+	mockCapture.On("Capture", mock.Anything, authID, int64(4000), true, map[string]string(nil)).
+		Return(&models.Transaction{
+			ID:          uuid.New(),
+			ReferenceID: &authID,
+			AmountCents: 4000,
+			Currency:    "USD",
+			CreatedAt:   time.Now(),
+		}, nil)
+
+	req := api.CreateCaptureRequestObject{
+		Body: &api.CreateCaptureJSONRequestBody{
+			AuthorizationId: "auth_" + authID.String(),
+			Amount:          4000,
+			FinalCapture:    true,
+		},
+	}
+
+	resp, err := handler.CreateCapture(context.Background(), req)
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.CreateCapture200JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, int64(4000), successResp.Amount)
+}
+
 func TestCreateCapture_InvalidIDFormat(t *testing.T) {
-	handler := NewHandler(nil, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Logger: testLogger()})
 
 	req := api.CreateCaptureRequestObject{
 		Body: &api.CreateCaptureJSONRequestBody{
@@ -102,31 +134,34 @@ func TestCreateCapture_InvalidIDFormat(t *testing.T) {
 
 func TestGetCapture_Success(t *testing.T) {
 	mockCapture := mocks.NewMockCapturer(t)
-	handler := NewHandler(nil, mockCapture, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Capture: mockCapture, Logger: testLogger()})
 
 	authID := uuid.New()
 	captureID := uuid.New()
 
 	mockCapture.On("GetCapture", mock.Anything, captureID).
 		Return(&models.Transaction{
-			ID:          captureID,
-			ReferenceID: &authID,
-			AmountCents: 10000,
-			Currency:    "USD",
-			CreatedAt:   time.Now(),
+			ID:                  captureID,
+			ReferenceID:         &authID,
+			AmountCents:         10000,
+			RefundedAmountCents: 2500,
+			Currency:            "USD",
+			CreatedAt:           time.Now(),
 		}, nil)
 
 	req := api.GetCaptureRequestObject{CaptureId: "cap_" + captureID.String()}
 	resp, err := handler.GetCapture(context.Background(), req)
 
 	require.NoError(t, err)
-	_, ok := resp.(api.GetCapture200JSONResponse)
+	successResp, ok := resp.(api.GetCapture200JSONResponse)
 	require.True(t, ok)
+	assert.Equal(t, int64(2500), successResp.RefundedAmount)
+	assert.Equal(t, int64(7500), successResp.RefundableAmount)
 }
 
 func TestGetCapture_NotFound(t *testing.T) {
 	mockCapture := mocks.NewMockCapturer(t)
-	handler := NewHandler(nil, mockCapture, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Capture: mockCapture, Logger: testLogger()})
 
 	captureID := uuid.New()
 	mockCapture.On("GetCapture", mock.Anything, captureID).
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+)
+
+// GetChaosSettings handles GET /admin/chaos
+func (h *Handler) GetChaosSettings(
+	_ context.Context,
+	_ api.GetChaosSettingsRequestObject,
+) (api.GetChaosSettingsResponseObject, error) {
+	return api.GetChaosSettings200JSONResponse(toAPIChaosSettings(h.chaosAdmin.Settings())), nil
+}
+
+// UpdateChaosSettings handles PUT /admin/chaos
+func (h *Handler) UpdateChaosSettings(
+	_ context.Context,
+	request api.UpdateChaosSettingsRequestObject,
+) (api.UpdateChaosSettingsResponseObject, error) {
+	settings := fromAPIChaosSettings(*request.Body)
+	if err := h.chaosAdmin.Update(settings); err != nil {
+		//nolint:nilerr // Returning 400 response object, not propagating error
+		return api.UpdateChaosSettings400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error:   api.ErrorCodeInvalidChaosSettings,
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	applied := h.chaosAdmin.Settings()
+	h.logger.Info("updated chaos settings",
+		"default_fault_rates", applied.Default.FaultRates,
+		"routes", len(applied.Routes),
+		"script_steps", len(applied.Script),
+		"seed", applied.Seed,
+	)
+	return api.UpdateChaosSettings200JSONResponse(toAPIChaosSettings(applied)), nil
+}
+
+// ResetChaosSettings handles DELETE /admin/chaos
+func (h *Handler) ResetChaosSettings(
+	_ context.Context,
+	_ api.ResetChaosSettingsRequestObject,
+) (api.ResetChaosSettingsResponseObject, error) {
+	settings := h.chaosAdmin.Reset()
+
+	h.logger.Info("reset chaos settings", "seed", settings.Seed)
+	return api.ResetChaosSettings200JSONResponse(toAPIChaosSettings(settings)), nil
+}
+
+func toAPIChaosSettings(settings chaos.Settings) api.ChaosSettings {
+	routes := make([]api.ChaosRoute, 0, len(settings.Routes))
+	for _, rule := range settings.Routes {
+		routes = append(routes, api.ChaosRoute{
+			Method:       rule.Method,
+			Path:         rule.Path,
+			FaultRates:   rule.FaultRates,
+			MinLatencyMs: rule.MinLatencyMS,
+			MaxLatencyMs: rule.MaxLatencyMS,
+		})
+	}
+
+	var script []api.ChaosScriptStep
+	for _, step := range settings.Script {
+		script = append(script, api.ChaosScriptStep{
+			Method:  step.Method,
+			Path:    step.Path,
+			Request: step.Request,
+			Fault:   step.Fault,
+		})
+	}
+
+	return api.ChaosSettings{
+		Default: api.ChaosProfile{
+			FaultRates:   settings.Default.FaultRates,
+			MinLatencyMs: settings.Default.MinLatencyMS,
+			MaxLatencyMs: settings.Default.MaxLatencyMS,
+		},
+		Routes: routes,
+		Script: script,
+		Seed:   settings.Seed,
+	}
+}
+
+func fromAPIChaosSettings(settings api.ChaosSettings) chaos.Settings {
+	rules := make([]chaos.Rule, 0, len(settings.Routes))
+	for _, route := range settings.Routes {
+		rules = append(rules, chaos.Rule{
+			Method: strings.ToUpper(route.Method),
+			Path:   route.Path,
+			Profile: chaos.Profile{
+				FaultRates:   route.FaultRates,
+				MinLatencyMS: route.MinLatencyMs,
+				MaxLatencyMS: route.MaxLatencyMs,
+			},
+		})
+	}
+
+	var script []config.FaultStep
+	for _, step := range settings.Script {
+		script = append(script, config.FaultStep{
+			Method:  strings.ToUpper(step.Method),
+			Path:    step.Path,
+			Request: step.Request,
+			Fault:   step.Fault,
+		})
+	}
+
+	return chaos.Settings{
+		Default: chaos.Profile{
+			FaultRates:   settings.Default.FaultRates,
+			MinLatencyMS: settings.Default.MinLatencyMs,
+			MaxLatencyMS: settings.Default.MaxLatencyMs,
+		},
+		Routes: rules,
+		Script: script,
+		Seed:   settings.Seed,
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetChaosSettings(t *testing.T) {
+	mockAdmin := mocks.NewMockChaosAdmin(t)
+	handler := NewHandler(Dependencies{Chaos: mockAdmin, Logger: testLogger()})
+
+	mockAdmin.On("Settings").Return(chaos.Settings{
+		Routes: []chaos.Rule{{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/captures",
+			Profile: chaos.Profile{FaultRates: map[string]float64{config.FaultError: 0.5}},
+		}},
+		Default: chaos.Profile{MinLatencyMS: 100, MaxLatencyMS: 200},
+	})
+
+	resp, err := handler.GetChaosSettings(context.Background(), api.GetChaosSettingsRequestObject{})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.GetChaosSettings200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	assert.Equal(t, api.ChaosSettings{
+		Routes: []api.ChaosRoute{{
+			Method:     http.MethodPost,
+			Path:       "/api/v1/captures",
+			FaultRates: map[string]float64{config.FaultError: 0.5},
+		}},
+		Default: api.ChaosProfile{MinLatencyMs: 100, MaxLatencyMs: 200},
+	}, api.ChaosSettings(okResp))
+}
+
+func TestUpdateChaosSettings_Success(t *testing.T) {
+	mockAdmin := mocks.NewMockChaosAdmin(t)
+	handler := NewHandler(Dependencies{Chaos: mockAdmin, Logger: testLogger()})
+
+	want := chaos.Settings{
+		Routes: []chaos.Rule{{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/authorizations",
+			Profile: chaos.Profile{MinLatencyMS: 1000, MaxLatencyMS: 3000},
+		}},
+		Script:  []config.FaultStep{{Method: http.MethodPost, Path: "/api/v1/captures", Request: 3, Fault: config.FaultError}},
+		Default: chaos.Profile{FaultRates: map[string]float64{config.FaultError: 0.1}},
+		Seed:    42,
+	}
+	mockAdmin.On("Update", want).Return(nil)
+	mockAdmin.On("Settings").Return(want)
+
+	resp, err := handler.UpdateChaosSettings(context.Background(), api.UpdateChaosSettingsRequestObject{
+		Body: &api.ChaosSettings{
+			Routes: []api.ChaosRoute{{
+				Method:       "post",
+				Path:         "/api/v1/authorizations",
+				MinLatencyMs: 1000,
+				MaxLatencyMs: 3000,
+			}},
+			Script:  []api.ChaosScriptStep{{Method: "post", Path: "/api/v1/captures", Request: 3, Fault: config.FaultError}},
+			Default: api.ChaosProfile{FaultRates: map[string]float64{config.FaultError: 0.1}},
+			Seed:    42,
+		},
+	})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.UpdateChaosSettings200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	assert.Equal(t, http.MethodPost, okResp.Routes[0].Method)
+	assert.Equal(t, []api.ChaosScriptStep{{Method: http.MethodPost, Path: "/api/v1/captures", Request: 3, Fault: config.FaultError}}, okResp.Script)
+	assert.Equal(t, uint64(42), okResp.Seed)
+}
+
+func TestUpdateChaosSettings_Invalid(t *testing.T) {
+	mockAdmin := mocks.NewMockChaosAdmin(t)
+	handler := NewHandler(Dependencies{Chaos: mockAdmin, Logger: testLogger()})
+
+	mockAdmin.On("Update", chaos.Settings{
+		Routes:  []chaos.Rule{},
+		Default: chaos.Profile{FaultRates: map[string]float64{config.FaultError: 2}},
+	}).Return(errors.New("default: error fault rate must be between 0 and 1, got 2.000000"))
+
+	resp, err := handler.UpdateChaosSettings(context.Background(), api.UpdateChaosSettingsRequestObject{
+		Body: &api.ChaosSettings{
+			Default: api.ChaosProfile{FaultRates: map[string]float64{config.FaultError: 2}},
+		},
+	})
+
+	require.NoError(t, err)
+	badRequest, ok := resp.(api.UpdateChaosSettings400JSONResponse)
+	require.True(t, ok, "expected 400 response, got %T", resp)
+	assert.Equal(t, api.ErrorCodeInvalidChaosSettings, badRequest.Error)
+	assert.Contains(t, badRequest.Message, "fault rate must be between 0 and 1")
+}
+
+func TestUpdateChaosSettings_LatencyPastLockTimeout(t *testing.T) {
+	initial := chaos.Settings{Default: chaos.Profile{MaxLatencyMS: 100}}
+	controller := chaos.NewController(initial, chaos.Limits{
+		IdempotencyLockTimeout: 30 * time.Second,
+		FaultHangDuration:      20 * time.Second,
+	})
+	handler := NewHandler(Dependencies{Chaos: controller, Logger: testLogger()})
+
+	tests := []struct {
+		name     string
+		wantErr  string
+		settings api.ChaosSettings
+	}{
+		{
+			name:     "default",
+			settings: api.ChaosSettings{Default: api.ChaosProfile{MaxLatencyMs: 30000}},
+			wantErr:  "default: max latency (30000) must be less than the idempotency lock timeout (30s)",
+		},
+		{
+			name: "route with a hang",
+			settings: api.ChaosSettings{Routes: []api.ChaosRoute{{
+				Path:         "/api/v1/captures",
+				MaxLatencyMs: 10000,
+			}}},
+			wantErr: "route 0: max latency (10000) plus the fault hang duration (20s) must be less than the idempotency lock timeout (30s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := handler.UpdateChaosSettings(context.Background(), api.UpdateChaosSettingsRequestObject{Body: &tt.settings})
+
+			require.NoError(t, err)
+			badRequest, ok := resp.(api.UpdateChaosSettings400JSONResponse)
+			require.True(t, ok, "expected 400 response, got %T", resp)
+			assert.Equal(t, api.ErrorCodeInvalidChaosSettings, badRequest.Error)
+			assert.Equal(t, tt.wantErr, badRequest.Message)
+			assert.Equal(t, 100, controller.Settings().Default.MaxLatencyMS, "the settings were not applied")
+		})
+	}
+}
+
+func TestResetChaosSettings(t *testing.T) {
+	controller := chaos.NewController(chaos.Settings{
+		Default: chaos.Profile{FaultRates: map[string]float64{config.FaultError: 0.05}},
+		Seed:    7,
+	}, chaos.Limits{})
+	require.NoError(t, controller.Update(chaos.Settings{
+		Default: chaos.Profile{FaultRates: map[string]float64{config.FaultError: 0.9}},
+	}))
+	handler := NewHandler(Dependencies{Chaos: controller, Logger: testLogger()})
+
+	resp, err := handler.ResetChaosSettings(context.Background(), api.ResetChaosSettingsRequestObject{})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.ResetChaosSettings200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	assert.InDelta(t, 0.05, okResp.Default.FaultRates[config.FaultError], 1e-9)
+	assert.InDelta(t, 0.05, controller.Settings().Default.FaultRates[config.FaultError], 1e-9)
+	assert.Equal(t, uint64(7), okResp.Seed)
+}
@@ -4,34 +4,93 @@ package handlers
 import (
 	"log/slog"
 
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
+	"github.com/benx421/payment-gateway/bank/internal/ratelimit"
 	"github.com/benx421/payment-gateway/bank/internal/service"
 )
 
+// ChaosAdmin reads and replaces the failure injection settings while the bank runs
+type ChaosAdmin interface {
+	Settings() chaos.Settings
+	Update(settings chaos.Settings) error
+	Reset() chaos.Settings
+}
+
+// RateLimitAdmin reads and replaces the per-client request limits while the bank runs
+type RateLimitAdmin interface {
+	Settings() ratelimit.Settings
+	Update(settings ratelimit.Settings) error
+	Reset() ratelimit.Settings
+}
+
+// OutageReporter tells which scheduled outage window, if any, the bank is in
+type OutageReporter interface {
+	Status() outage.Status
+}
+
+// Ensure concrete types implement interfaces
+var (
+	_ ChaosAdmin     = (*chaos.Controller)(nil)
+	_ RateLimitAdmin = (*ratelimit.Limiter)(nil)
+	_ OutageReporter = (*outage.Schedule)(nil)
+)
+
 // Handler implements the api.StrictServerInterface for all endpoints
 type Handler struct {
-	authService    service.Authorizer
-	captureService service.Capturer
-	voidService    service.Voider
-	refundService  service.Refunder
-	healthChecker  service.HealthChecker
-	logger         *slog.Logger
+	authService        service.Authorizer
+	captureService     service.Capturer
+	voidService        service.Voider
+	refundService      service.Refunder
+	transactionService service.TransactionLister
+	idempotencyService service.IdempotencyKeyAdmin
+	chaosAdmin         ChaosAdmin
+	rateLimitAdmin     RateLimitAdmin
+	outages            OutageReporter
+	healthChecker      service.HealthChecker
+	clock              clock.Clock
+	logger             *slog.Logger
+}
+
+// Dependencies are what a Handler serves requests with. Services an endpoint does not
+// call may be left nil. Clock defaults to clock.System and Logger to slog.Default().
+type Dependencies struct {
+	Auth            service.Authorizer
+	Capture         service.Capturer
+	Void            service.Voider
+	Refund          service.Refunder
+	Transactions    service.TransactionLister
+	IdempotencyKeys service.IdempotencyKeyAdmin
+	Chaos           ChaosAdmin
+	RateLimits      RateLimitAdmin
+	Outages         OutageReporter
+	Health          service.HealthChecker
+	Clock           clock.Clock
+	Logger          *slog.Logger
 }
 
 // NewHandler creates a new Handler with injected service dependencies.
-func NewHandler(
-	authService service.Authorizer,
-	captureService service.Capturer,
-	voidService service.Voider,
-	refundService service.Refunder,
-	healthChecker service.HealthChecker,
-	logger *slog.Logger,
-) *Handler {
+func NewHandler(deps Dependencies) *Handler {
+	if deps.Clock == nil {
+		deps.Clock = clock.System
+	}
+	if deps.Logger == nil {
+		deps.Logger = slog.Default()
+	}
+
 	return &Handler{
-		authService:    authService,
-		captureService: captureService,
-		voidService:    voidService,
-		refundService:  refundService,
-		healthChecker:  healthChecker,
-		logger:         logger,
+		authService:        deps.Auth,
+		captureService:     deps.Capture,
+		voidService:        deps.Void,
+		refundService:      deps.Refund,
+		transactionService: deps.Transactions,
+		idempotencyService: deps.IdempotencyKeys,
+		chaosAdmin:         deps.Chaos,
+		rateLimitAdmin:     deps.RateLimits,
+		outages:            deps.Outages,
+		healthChecker:      deps.Health,
+		clock:              deps.Clock,
+		logger:             deps.Logger,
 	}
 }
@@ -5,9 +5,11 @@ import (
 	"time"
 
 	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/config"
 )
 
-// GetHealth handles GET /health
+// GetHealth handles GET /health. During a scheduled outage window it reports
+// maintenance with 503 or degraded with 200, and when the window ends.
 func (h *Handler) GetHealth(
 	ctx context.Context,
 	request api.GetHealthRequestObject,
@@ -18,11 +20,24 @@ func (h *Handler) GetHealth(
 	if err := h.healthChecker.PingContext(pingCtx); err != nil {
 		h.logger.Error("health check failed: database unreachable", "error", err)
 		return api.GetHealth503JSONResponse{
-			Status: api.Unhealthy,
+			Status: api.HealthResponseStatusUnhealthy,
+		}, nil
+	}
+
+	switch status := h.outages.Status(); status.Mode {
+	case config.OutageMaintenance:
+		return api.GetHealth503JSONResponse{
+			Status: api.HealthResponseStatusMaintenance,
+			Until:  status.Until,
+		}, nil
+	case config.OutageDegraded:
+		return api.GetHealth200JSONResponse{
+			Status: api.HealthResponseStatusDegraded,
+			Until:  status.Until,
 		}, nil
 	}
 
 	return api.GetHealth200JSONResponse{
-		Status: api.Healthy,
+		Status: api.HealthResponseStatusHealthy,
 	}, nil
 }
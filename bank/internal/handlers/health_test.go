@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
+	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetHealth(t *testing.T) {
+	until := time.Date(2026, 1, 1, 12, 10, 0, 0, time.UTC)
+
+	tests := []struct {
+		pingErr error
+		want    api.GetHealthResponseObject
+		name    string
+		outage  outage.Status
+	}{
+		{
+			name: "healthy",
+			want: api.GetHealth200JSONResponse{Status: api.HealthResponseStatusHealthy},
+		},
+		{
+			name:    "database unreachable",
+			pingErr: errors.New("connection refused"),
+			want:    api.GetHealth503JSONResponse{Status: api.HealthResponseStatusUnhealthy},
+		},
+		{
+			name:   "maintenance window",
+			outage: outage.Status{Mode: config.OutageMaintenance, Until: until, Remaining: time.Minute},
+			want:   api.GetHealth503JSONResponse{Status: api.HealthResponseStatusMaintenance, Until: until},
+		},
+		{
+			name:   "degraded window",
+			outage: outage.Status{Mode: config.OutageDegraded, Until: until, Remaining: time.Minute},
+			want:   api.GetHealth200JSONResponse{Status: api.HealthResponseStatusDegraded, Until: until},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChecker := mocks.NewMockHealthChecker(t)
+			mockOutages := mocks.NewMockOutageReporter(t)
+			handler := NewHandler(Dependencies{Outages: mockOutages, Health: mockChecker, Logger: testLogger()})
+
+			mockChecker.On("PingContext", mock.Anything).Return(tt.pingErr)
+			if tt.pingErr == nil {
+				mockOutages.On("Status").Return(tt.outage)
+			}
+
+			resp, err := handler.GetHealth(context.Background(), api.GetHealthRequestObject{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, resp)
+		})
+	}
+}
@@ -13,8 +13,10 @@ import (
 // ID prefixes for API responses
 const (
 	PrefixAuthorization = "auth_"
+	PrefixIncrement     = "inc_"
 	PrefixCapture       = "cap_"
 	PrefixVoid          = "void_"
+	PrefixReversal      = "rev_"
 	PrefixRefund        = "ref_"
 )
 
@@ -22,6 +24,10 @@ func formatAuthorizationID(id uuid.UUID) string {
 	return PrefixAuthorization + id.String()
 }
 
+func formatIncrementID(id uuid.UUID) string {
+	return PrefixIncrement + id.String()
+}
+
 func formatCaptureID(id uuid.UUID) string {
 	return PrefixCapture + id.String()
 }
@@ -30,6 +36,10 @@ func formatVoidID(id uuid.UUID) string {
 	return PrefixVoid + id.String()
 }
 
+func formatReversalID(id uuid.UUID) string {
+	return PrefixReversal + id.String()
+}
+
 func formatRefundID(id uuid.UUID) string {
 	return PrefixRefund + id.String()
 }
@@ -42,6 +52,10 @@ func parseCaptureID(id string) (uuid.UUID, error) {
 	return parseIDWithPrefix(id, PrefixCapture, "capture")
 }
 
+func parseVoidID(id string) (uuid.UUID, error) {
+	return parseIDWithPrefix(id, PrefixVoid, "void")
+}
+
 func parseRefundID(id string) (uuid.UUID, error) {
 	return parseIDWithPrefix(id, PrefixRefund, "refund")
 }
@@ -66,6 +80,8 @@ func mapServiceErrorToCode(code string) api.ErrorCode {
 		return api.ErrorCodeInvalidCard
 	case service.ErrCodeInvalidCVV:
 		return api.ErrorCodeInvalidCvv
+	case service.ErrCodeInvalidExpiry:
+		return api.ErrorCodeInvalidExpiry
 	case service.ErrCodeInvalidAmount:
 		return api.ErrorCodeInvalidAmount
 	case service.ErrCodeCardExpired:
@@ -86,8 +102,18 @@ func mapServiceErrorToCode(code string) api.ErrorCode {
 		return api.ErrorCodeAlreadyRefunded
 	case service.ErrCodeAmountMismatch:
 		return api.ErrorCodeAmountMismatch
+	case service.ErrCodeCaptureExceedsAuth:
+		return api.ErrorCodeCaptureExceedsAuthorization
 	case service.ErrCodeCaptureNotFound:
 		return api.ErrorCodeCaptureNotFound
+	case service.ErrCodeRefundExceedsCapture:
+		return api.ErrorCodeRefundExceedsCapture
+	case service.ErrCodeReversalExceedsAuth:
+		return api.ErrorCodeReversalExceedsAuthorization
+	case service.ErrCodeInvalidQuery:
+		return api.ErrorCodeInvalidQuery
+	case service.ErrCodeInvalidMetadata:
+		return api.ErrorCodeInvalidMetadata
 	default:
 		return api.ErrorCodeInternalError
 	}
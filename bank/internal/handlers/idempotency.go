@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/service"
+)
+
+// GetIdempotencyKey handles GET /admin/idempotency-keys/{idempotencyKey}
+func (h *Handler) GetIdempotencyKey(
+	ctx context.Context,
+	request api.GetIdempotencyKeyRequestObject,
+) (api.GetIdempotencyKeyResponseObject, error) {
+	keys, err := h.idempotencyService.GetIdempotencyKey(ctx, request.IdempotencyKey)
+	if err != nil {
+		svcErr := extractServiceError(err)
+		if svcErr != nil && svcErr.Code == service.ErrCodeIdempotencyKeyNotFound {
+			return api.GetIdempotencyKey404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error:   api.ErrorCodeNotFound,
+					Message: "idempotency key not found",
+				},
+			}, nil
+		}
+
+		h.logger.Error("failed to look up idempotency key", "error", err)
+		return api.GetIdempotencyKey500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error:   api.ErrorCodeInternalError,
+				Message: "internal error",
+			},
+		}, nil
+	}
+
+	data := make([]api.IdempotencyKeyRecord, 0, len(keys))
+	for _, key := range keys {
+		data = append(data, api.IdempotencyKeyRecord{
+			Key:            key.Key,
+			RequestPath:    key.RequestPath,
+			RequestHash:    key.RequestHash,
+			InProgress:     key.InProgress(),
+			ResponseStatus: key.ResponseStatus,
+			ResponseBody:   key.ResponseBody,
+			CreatedAt:      key.CreatedAt,
+		})
+	}
+
+	return api.GetIdempotencyKey200JSONResponse{Data: data}, nil
+}
+
+// DeleteIdempotencyKey handles DELETE /admin/idempotency-keys/{idempotencyKey}
+func (h *Handler) DeleteIdempotencyKey(
+	ctx context.Context,
+	request api.DeleteIdempotencyKeyRequestObject,
+) (api.DeleteIdempotencyKeyResponseObject, error) {
+	err := h.idempotencyService.PurgeIdempotencyKey(ctx, request.IdempotencyKey, request.Params.RequestPath)
+	if err != nil {
+		svcErr := extractServiceError(err)
+		if svcErr != nil && svcErr.Code == service.ErrCodeIdempotencyKeyNotFound {
+			return api.DeleteIdempotencyKey404JSONResponse{
+				NotFoundJSONResponse: api.NotFoundJSONResponse{
+					Error:   api.ErrorCodeNotFound,
+					Message: "idempotency key not found",
+				},
+			}, nil
+		}
+
+		h.logger.Error("failed to purge idempotency key", "error", err)
+		return api.DeleteIdempotencyKey500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error:   api.ErrorCodeInternalError,
+				Message: "internal error",
+			},
+		}, nil
+	}
+
+	h.logger.Info("purged idempotency key",
+		"key", request.IdempotencyKey,
+		"path", request.Params.RequestPath,
+	)
+	return api.DeleteIdempotencyKey204Response{}, nil
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/service"
+	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetIdempotencyKey_Success(t *testing.T) {
+	mockAdmin := mocks.NewMockIdempotencyKeyAdmin(t)
+	handler := NewHandler(Dependencies{IdempotencyKeys: mockAdmin, Logger: testLogger()})
+
+	createdAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	lockedAt := createdAt.Add(time.Minute)
+	mockAdmin.On("GetIdempotencyKey", mock.Anything, "support-key").
+		Return([]*models.IdempotencyKey{
+			{
+				Key:            "support-key",
+				RequestPath:    "/api/v1/captures",
+				RequestHash:    "abc123",
+				ResponseStatus: 200,
+				ResponseBody:   `{"capture_id":"cap_1"}`,
+				CreatedAt:      createdAt,
+			},
+			{
+				Key:         "support-key",
+				RequestPath: "/api/v1/voids",
+				CreatedAt:   lockedAt,
+				LockedAt:    &lockedAt,
+			},
+		}, nil)
+
+	resp, err := handler.GetIdempotencyKey(context.Background(), api.GetIdempotencyKeyRequestObject{
+		IdempotencyKey: "support-key",
+	})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.GetIdempotencyKey200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	require.Len(t, okResp.Data, 2)
+
+	assert.Equal(t, api.IdempotencyKeyRecord{
+		Key:            "support-key",
+		RequestPath:    "/api/v1/captures",
+		RequestHash:    "abc123",
+		ResponseStatus: 200,
+		ResponseBody:   `{"capture_id":"cap_1"}`,
+		CreatedAt:      createdAt,
+	}, okResp.Data[0])
+	assert.True(t, okResp.Data[1].InProgress)
+}
+
+func TestGetIdempotencyKey_NotFound(t *testing.T) {
+	mockAdmin := mocks.NewMockIdempotencyKeyAdmin(t)
+	handler := NewHandler(Dependencies{IdempotencyKeys: mockAdmin, Logger: testLogger()})
+
+	mockAdmin.On("GetIdempotencyKey", mock.Anything, "missing-key").
+		Return(nil, &service.ServiceError{Code: service.ErrCodeIdempotencyKeyNotFound, Message: "idempotency key not found"})
+
+	resp, err := handler.GetIdempotencyKey(context.Background(), api.GetIdempotencyKeyRequestObject{
+		IdempotencyKey: "missing-key",
+	})
+
+	require.NoError(t, err)
+	notFound, ok := resp.(api.GetIdempotencyKey404JSONResponse)
+	require.True(t, ok, "expected 404 response, got %T", resp)
+	assert.Equal(t, api.ErrorCodeNotFound, notFound.Error)
+}
+
+func TestDeleteIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		serviceErr  error
+		wantType    any
+		name        string
+		requestPath string
+	}{
+		{
+			name:     "purges every path",
+			wantType: api.DeleteIdempotencyKey204Response{},
+		},
+		{
+			name:        "purges one path",
+			requestPath: "/api/v1/captures",
+			wantType:    api.DeleteIdempotencyKey204Response{},
+		},
+		{
+			name:       "unknown key",
+			serviceErr: &service.ServiceError{Code: service.ErrCodeIdempotencyKeyNotFound, Message: "idempotency key not found"},
+			wantType:   api.DeleteIdempotencyKey404JSONResponse{},
+		},
+		{
+			name:       "repository failure",
+			serviceErr: &service.ServiceError{Code: service.ErrCodeInternalError, Message: "failed to purge idempotency key"},
+			wantType:   api.DeleteIdempotencyKey500JSONResponse{},
+		},
+		{
+			name:       "unexpected error",
+			serviceErr: errors.New("boom"),
+			wantType:   api.DeleteIdempotencyKey500JSONResponse{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAdmin := mocks.NewMockIdempotencyKeyAdmin(t)
+			handler := NewHandler(Dependencies{IdempotencyKeys: mockAdmin, Logger: testLogger()})
+
+			mockAdmin.On("PurgeIdempotencyKey", mock.Anything, "purge-key", tt.requestPath).Return(tt.serviceErr)
+
+			resp, err := handler.DeleteIdempotencyKey(context.Background(), api.DeleteIdempotencyKeyRequestObject{
+				IdempotencyKey: "purge-key",
+				Params:         api.DeleteIdempotencyKeyParams{RequestPath: tt.requestPath},
+			})
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, resp)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/ratelimit"
+)
+
+// GetRateLimits handles GET /admin/rate-limits
+func (h *Handler) GetRateLimits(
+	_ context.Context,
+	_ api.GetRateLimitsRequestObject,
+) (api.GetRateLimitsResponseObject, error) {
+	return api.GetRateLimits200JSONResponse(toAPIRateLimitSettings(h.rateLimitAdmin.Settings())), nil
+}
+
+// UpdateRateLimits handles PUT /admin/rate-limits
+func (h *Handler) UpdateRateLimits(
+	_ context.Context,
+	request api.UpdateRateLimitsRequestObject,
+) (api.UpdateRateLimitsResponseObject, error) {
+	settings := fromAPIRateLimitSettings(*request.Body)
+	if err := h.rateLimitAdmin.Update(settings); err != nil {
+		//nolint:nilerr // Returning 400 response object, not propagating error
+		return api.UpdateRateLimits400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error:   api.ErrorCodeInvalidRateLimitSettings,
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	h.logger.Info("updated rate limits",
+		"default_requests_per_second", settings.Default.RequestsPerSecond,
+		"default_burst", settings.Default.Burst,
+		"routes", len(settings.Routes),
+	)
+	return api.UpdateRateLimits200JSONResponse(toAPIRateLimitSettings(h.rateLimitAdmin.Settings())), nil
+}
+
+// ResetRateLimits handles DELETE /admin/rate-limits
+func (h *Handler) ResetRateLimits(
+	_ context.Context,
+	_ api.ResetRateLimitsRequestObject,
+) (api.ResetRateLimitsResponseObject, error) {
+	settings := h.rateLimitAdmin.Reset()
+
+	h.logger.Info("reset rate limits")
+	return api.ResetRateLimits200JSONResponse(toAPIRateLimitSettings(settings)), nil
+}
+
+func toAPIRateLimitSettings(settings ratelimit.Settings) api.RateLimitSettings {
+	routes := make([]api.RateLimitRoute, 0, len(settings.Routes))
+	for _, rule := range settings.Routes {
+		routes = append(routes, api.RateLimitRoute{
+			Method:            rule.Method,
+			Path:              rule.Path,
+			RequestsPerSecond: rule.RequestsPerSecond,
+			Burst:             rule.Burst,
+		})
+	}
+
+	return api.RateLimitSettings{
+		Default: api.RateLimit{
+			RequestsPerSecond: settings.Default.RequestsPerSecond,
+			Burst:             settings.Default.Burst,
+		},
+		Routes: routes,
+	}
+}
+
+func fromAPIRateLimitSettings(settings api.RateLimitSettings) ratelimit.Settings {
+	rules := make([]ratelimit.Rule, 0, len(settings.Routes))
+	for _, route := range settings.Routes {
+		rules = append(rules, ratelimit.Rule{
+			Method: strings.ToUpper(route.Method),
+			Path:   route.Path,
+			Limit: ratelimit.Limit{
+				RequestsPerSecond: route.RequestsPerSecond,
+				Burst:             route.Burst,
+			},
+		})
+	}
+
+	return ratelimit.Settings{
+		Default: ratelimit.Limit{
+			RequestsPerSecond: settings.Default.RequestsPerSecond,
+			Burst:             settings.Default.Burst,
+		},
+		Routes: rules,
+	}
+}
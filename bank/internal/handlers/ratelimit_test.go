@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/ratelimit"
+	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRateLimits(t *testing.T) {
+	mockAdmin := mocks.NewMockRateLimitAdmin(t)
+	handler := NewHandler(Dependencies{RateLimits: mockAdmin, Logger: testLogger()})
+
+	mockAdmin.On("Settings").Return(ratelimit.Settings{
+		Routes:  []ratelimit.Rule{{Method: http.MethodPost, Path: "/api/v1/captures", Limit: ratelimit.Limit{RequestsPerSecond: 1, Burst: 2}}},
+		Default: ratelimit.Limit{RequestsPerSecond: 10, Burst: 20},
+	})
+
+	resp, err := handler.GetRateLimits(context.Background(), api.GetRateLimitsRequestObject{})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.GetRateLimits200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	assert.Equal(t, api.RateLimitSettings{
+		Routes:  []api.RateLimitRoute{{Method: http.MethodPost, Path: "/api/v1/captures", RequestsPerSecond: 1, Burst: 2}},
+		Default: api.RateLimit{RequestsPerSecond: 10, Burst: 20},
+	}, api.RateLimitSettings(okResp))
+}
+
+func TestUpdateRateLimits_Success(t *testing.T) {
+	mockAdmin := mocks.NewMockRateLimitAdmin(t)
+	handler := NewHandler(Dependencies{RateLimits: mockAdmin, Logger: testLogger()})
+
+	want := ratelimit.Settings{
+		Routes:  []ratelimit.Rule{{Method: http.MethodPost, Path: "/api/v1/authorizations", Limit: ratelimit.Limit{RequestsPerSecond: 5, Burst: 5}}},
+		Default: ratelimit.Limit{},
+	}
+	mockAdmin.On("Update", want).Return(nil)
+	mockAdmin.On("Settings").Return(want)
+
+	resp, err := handler.UpdateRateLimits(context.Background(), api.UpdateRateLimitsRequestObject{
+		Body: &api.RateLimitSettings{
+			Routes: []api.RateLimitRoute{{Method: "post", Path: "/api/v1/authorizations", RequestsPerSecond: 5, Burst: 5}},
+		},
+	})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.UpdateRateLimits200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	assert.Equal(t, http.MethodPost, okResp.Routes[0].Method)
+}
+
+func TestUpdateRateLimits_Invalid(t *testing.T) {
+	mockAdmin := mocks.NewMockRateLimitAdmin(t)
+	handler := NewHandler(Dependencies{RateLimits: mockAdmin, Logger: testLogger()})
+
+	mockAdmin.On("Update", ratelimit.Settings{
+		Routes:  []ratelimit.Rule{},
+		Default: ratelimit.Limit{RequestsPerSecond: 5},
+	}).Return(errors.New("default: burst must be at least 1, got 0"))
+
+	resp, err := handler.UpdateRateLimits(context.Background(), api.UpdateRateLimitsRequestObject{
+		Body: &api.RateLimitSettings{Default: api.RateLimit{RequestsPerSecond: 5}},
+	})
+
+	require.NoError(t, err)
+	badRequest, ok := resp.(api.UpdateRateLimits400JSONResponse)
+	require.True(t, ok, "expected 400 response, got %T", resp)
+	assert.Equal(t, api.ErrorCodeInvalidRateLimitSettings, badRequest.Error)
+	assert.Contains(t, badRequest.Message, "burst must be at least 1")
+}
+
+func TestResetRateLimits(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Settings{Default: ratelimit.Limit{RequestsPerSecond: 10, Burst: 10}}, clock.System)
+	require.NoError(t, limiter.Update(ratelimit.Settings{Default: ratelimit.Limit{RequestsPerSecond: 1, Burst: 1}}))
+	handler := NewHandler(Dependencies{RateLimits: limiter, Logger: testLogger()})
+
+	resp, err := handler.ResetRateLimits(context.Background(), api.ResetRateLimitsRequestObject{})
+
+	require.NoError(t, err)
+	okResp, ok := resp.(api.ResetRateLimits200JSONResponse)
+	require.True(t, ok, "expected 200 response, got %T", resp)
+	assert.Equal(t, api.RateLimit{RequestsPerSecond: 10, Burst: 10}, okResp.Default)
+	assert.Equal(t, ratelimit.Limit{RequestsPerSecond: 10, Burst: 10}, limiter.Settings().Default)
+}
@@ -22,7 +22,7 @@ func (h *Handler) CreateRefund(
 		}, nil
 	}
 
-	txn, err := h.refundService.Refund(ctx, captureID, request.Body.Amount)
+	txn, err := h.refundService.Refund(ctx, captureID, request.Body.Amount, request.Body.Metadata)
 	if err != nil {
 		return h.handleRefundError(err)
 	}
@@ -34,6 +34,7 @@ func (h *Handler) CreateRefund(
 		Amount:     txn.AmountCents,
 		Currency:   txn.Currency,
 		RefundedAt: txn.CreatedAt,
+		Metadata:   txn.Metadata,
 	}, nil
 }
 
@@ -71,6 +72,7 @@ func (h *Handler) GetRefund(
 		Amount:     txn.AmountCents,
 		Currency:   txn.Currency,
 		RefundedAt: txn.CreatedAt,
+		Metadata:   txn.Metadata,
 	}, nil
 }
 
@@ -17,12 +17,12 @@ import (
 
 func TestCreateRefund_Success(t *testing.T) {
 	mockRefund := mocks.NewMockRefunder(t)
-	handler := NewHandler(nil, nil, nil, mockRefund, nil, testLogger())
+	handler := NewHandler(Dependencies{Refund: mockRefund, Logger: testLogger()})
 
 	captureID := uuid.New()
 	refundID := uuid.New()
 
-	mockRefund.On("Refund", mock.Anything, captureID, int64(5000)).
+	mockRefund.On("Refund", mock.Anything, captureID, int64(5000), map[string]string(nil)).
 		Return(&models.Transaction{
 			ID:          refundID,
 			ReferenceID: &captureID,
@@ -60,9 +60,9 @@ func TestCreateRefund_ServiceErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRefund := mocks.NewMockRefunder(t)
-			handler := NewHandler(nil, nil, nil, mockRefund, nil, testLogger())
+			handler := NewHandler(Dependencies{Refund: mockRefund, Logger: testLogger()})
 
-			mockRefund.On("Refund", mock.Anything, mock.Anything, mock.Anything).
+			mockRefund.On("Refund", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 				Return(nil, tt.serviceErr)
 
 			req := api.CreateRefundRequestObject{
@@ -83,7 +83,7 @@ func TestCreateRefund_ServiceErrors(t *testing.T) {
 }
 
 func TestCreateRefund_InvalidIDFormat(t *testing.T) {
-	handler := NewHandler(nil, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Logger: testLogger()})
 
 	req := api.CreateRefundRequestObject{
 		Body: &api.CreateRefundJSONRequestBody{CaptureId: "invalid", Amount: 5000},
@@ -99,7 +99,7 @@ func TestCreateRefund_InvalidIDFormat(t *testing.T) {
 
 func TestGetRefund_Success(t *testing.T) {
 	mockRefund := mocks.NewMockRefunder(t)
-	handler := NewHandler(nil, nil, nil, mockRefund, nil, testLogger())
+	handler := NewHandler(Dependencies{Refund: mockRefund, Logger: testLogger()})
 
 	captureID := uuid.New()
 	refundID := uuid.New()
@@ -123,7 +123,7 @@ func TestGetRefund_Success(t *testing.T) {
 
 func TestGetRefund_NotFound(t *testing.T) {
 	mockRefund := mocks.NewMockRefunder(t)
-	handler := NewHandler(nil, nil, nil, mockRefund, nil, testLogger())
+	handler := NewHandler(Dependencies{Refund: mockRefund, Logger: testLogger()})
 
 	refundID := uuid.New()
 	mockRefund.On("GetRefund", mock.Anything, refundID).
@@ -5,25 +5,54 @@ import (
 	"net/http"
 
 	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/config"
-	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/middleware"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
+	"github.com/benx421/payment-gateway/bank/internal/ratelimit"
 	"github.com/benx421/payment-gateway/bank/internal/repository"
 	"github.com/benx421/payment-gateway/bank/internal/service"
 )
 
 // NewRouter creates and configures the HTTP router with all routes and middleware.
+// The store is also used as the health check; clk is the time the services and handlers work with.
 func NewRouter(
-	database *db.DB,
+	store repository.Store,
+	clk clock.Clock,
 	cfg *config.Config,
 	logger *slog.Logger,
 ) http.Handler {
-	authService := service.NewAuthorizationService(database, cfg.App.AuthExpiryHours)
-	captureService := service.NewCaptureService(database)
-	voidService := service.NewVoidService(database)
-	refundService := service.NewRefundService(database)
+	authService := service.NewAuthorizationService(store, clk, cfg.App.AuthExpiryDuration)
+	captureService := service.NewCaptureService(store, clk)
+	voidService := service.NewVoidService(store, clk)
+	refundService := service.NewRefundService(store, clk)
+	transactionService := service.NewTransactionService(store)
+	idempotencyService := service.NewIdempotencyService(store)
+	chaosController := chaos.NewController(chaos.FromConfig(&cfg.App), chaos.LimitsFromConfig(&cfg.App))
+	chaosSettings := chaosController.Settings()
+	logger.Info("chaos settings loaded",
+		"seed", chaosSettings.Seed,
+		"script_steps", len(chaosSettings.Script),
+	)
 
-	handler := NewHandler(authService, captureService, voidService, refundService, database, logger)
+	rateLimiter := ratelimit.NewLimiter(ratelimit.FromConfig(&cfg.RateLimit), clk)
+	outages := outage.NewSchedule(cfg.App.OutageWindows, clk)
+
+	handler := NewHandler(Dependencies{
+		Auth:            authService,
+		Capture:         captureService,
+		Void:            voidService,
+		Refund:          refundService,
+		Transactions:    transactionService,
+		IdempotencyKeys: idempotencyService,
+		Chaos:           chaosController,
+		RateLimits:      rateLimiter,
+		Outages:         outages,
+		Health:          store,
+		Clock:           clk,
+		Logger:          logger,
+	})
 	strictHandler := api.NewStrictHandler(handler, nil)
 
 	mux := http.NewServeMux()
@@ -32,10 +61,13 @@ func NewRouter(
 
 	var finalHandler http.Handler = mux
 
-	finalHandler = middleware.FailureInjection(&cfg.App, logger)(finalHandler)
+	finalHandler = middleware.FailureInjection(chaosController, outages, &cfg.App, logger)(finalHandler)
+
+	finalHandler = middleware.Idempotency(store.IdempotencyKeys(), &cfg.App, logger)(finalHandler)
+
+	finalHandler = middleware.RateLimiting(rateLimiter, logger)(finalHandler)
 
-	idempotencyRepo := repository.NewIdempotencyRepository(database)
-	finalHandler = middleware.Idempotency(idempotencyRepo, logger)(finalHandler)
+	finalHandler = middleware.Maintenance(outages, logger)(finalHandler)
 
 	return finalHandler
 }
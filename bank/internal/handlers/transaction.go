@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/service"
+	"github.com/google/uuid"
+)
+
+var transactionTypesByAPI = map[api.TransactionType]models.TransactionType{
+	api.TransactionTypeAuthorization: models.TransactionTypeAuthHold,
+	api.TransactionTypeIncrement:     models.TransactionTypeAuthIncrement,
+	api.TransactionTypeCapture:       models.TransactionTypeCapture,
+	api.TransactionTypeReversal:      models.TransactionTypeAuthReversal,
+	api.TransactionTypeVoid:          models.TransactionTypeVoid,
+	api.TransactionTypeRefund:        models.TransactionTypeRefund,
+}
+
+var transactionStatusesByAPI = map[api.TransactionStatus]models.TransactionStatus{
+	api.TransactionStatusActive:    models.TransactionStatusActive,
+	api.TransactionStatusCompleted: models.TransactionStatusCompleted,
+	api.TransactionStatusExpired:   models.TransactionStatusExpired,
+}
+
+// ListTransactions returns one page of ledger entries matching the query filters
+func (h *Handler) ListTransactions(
+	ctx context.Context,
+	request api.ListTransactionsRequestObject,
+) (api.ListTransactionsResponseObject, error) {
+	query, err := toTransactionQuery(request.Params)
+	if err != nil {
+		//nolint:nilerr // Returning 400 response object, not propagating error
+		return api.ListTransactions400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error:   api.ErrorCodeInvalidQuery,
+				Message: err.Error(),
+			},
+		}, nil
+	}
+
+	page, err := h.transactionService.ListTransactions(ctx, query)
+	if err != nil {
+		svcErr := extractServiceError(err)
+		if svcErr == nil || svcErr.Code == service.ErrCodeInternalError {
+			h.logger.Error("failed to list transactions", "error", err)
+			return api.ListTransactions500JSONResponse{
+				InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+					Error:   api.ErrorCodeInternalError,
+					Message: "internal error",
+				},
+			}, nil
+		}
+
+		return api.ListTransactions400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error:   mapServiceErrorToCode(svcErr.Code),
+				Message: svcErr.Message,
+			},
+		}, nil
+	}
+
+	data := make([]api.TransactionResponse, 0, len(page.Transactions))
+	for _, txn := range page.Transactions {
+		data = append(data, toTransactionResponse(txn))
+	}
+
+	return api.ListTransactions200JSONResponse{
+		Data:       data,
+		HasMore:    page.NextCursor != "",
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// toTransactionQuery validates the query parameters and converts them to a service query
+func toTransactionQuery(params api.ListTransactionsParams) (service.TransactionQuery, error) {
+	query := service.TransactionQuery{
+		AccountNumber: params.AccountNumber,
+		MetadataKey:   params.MetadataKey,
+		MetadataValue: params.MetadataValue,
+		Cursor:        params.Cursor,
+		Limit:         params.Limit,
+	}
+
+	if params.Type != "" {
+		txnType, ok := transactionTypesByAPI[params.Type]
+		if !ok {
+			return query, fmt.Errorf("unknown transaction type %q", params.Type)
+		}
+		query.Type = txnType
+	}
+
+	if params.Status != "" {
+		status, ok := transactionStatusesByAPI[params.Status]
+		if !ok {
+			return query, fmt.Errorf("unknown transaction status %q", params.Status)
+		}
+		query.Status = status
+	}
+
+	if params.ReferenceId != "" {
+		refID, err := parseReferenceID(params.ReferenceId)
+		if err != nil {
+			return query, err
+		}
+		query.ReferenceID = refID
+	}
+
+	if !params.CreatedFrom.IsZero() {
+		query.CreatedFrom = &params.CreatedFrom
+	}
+	if !params.CreatedTo.IsZero() {
+		query.CreatedTo = &params.CreatedTo
+	}
+
+	return query, nil
+}
+
+// parseReferenceID accepts the IDs that other transactions can reference:
+// authorizations (for increments, captures, reversals and voids) and captures (for refunds)
+func parseReferenceID(id string) (uuid.UUID, error) {
+	if strings.HasPrefix(id, PrefixCapture) {
+		return parseCaptureID(id)
+	}
+	if strings.HasPrefix(id, PrefixAuthorization) {
+		return parseAuthorizationID(id)
+	}
+	return uuid.Nil, fmt.Errorf("invalid reference ID format: expected %s or %s prefix", PrefixAuthorization, PrefixCapture)
+}
+
+func toTransactionResponse(txn *models.Transaction) api.TransactionResponse {
+	resp := api.TransactionResponse{
+		Amount:    txn.AmountCents,
+		Currency:  txn.Currency,
+		CreatedAt: txn.CreatedAt,
+		Metadata:  txn.Metadata,
+	}
+
+	for apiType, txnType := range transactionTypesByAPI {
+		if txnType == txn.Type {
+			resp.Type = apiType
+		}
+	}
+	for apiStatus, status := range transactionStatusesByAPI {
+		if status == txn.Status {
+			resp.Status = apiStatus
+		}
+	}
+
+	switch txn.Type {
+	case models.TransactionTypeAuthHold:
+		resp.Id = formatAuthorizationID(txn.ID)
+	case models.TransactionTypeAuthIncrement:
+		resp.Id = formatIncrementID(txn.ID)
+	case models.TransactionTypeCapture:
+		resp.Id = formatCaptureID(txn.ID)
+	case models.TransactionTypeAuthReversal:
+		resp.Id = formatReversalID(txn.ID)
+	case models.TransactionTypeVoid:
+		resp.Id = formatVoidID(txn.ID)
+	case models.TransactionTypeRefund:
+		resp.Id = formatRefundID(txn.ID)
+	}
+
+	if txn.ReferenceID != nil {
+		if txn.Type == models.TransactionTypeRefund {
+			resp.ReferenceId = formatCaptureID(*txn.ReferenceID)
+		} else {
+			resp.ReferenceId = formatAuthorizationID(*txn.ReferenceID)
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/api"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/service"
+	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTransactions_Success(t *testing.T) {
+	mockLister := mocks.NewMockTransactionLister(t)
+	handler := NewHandler(Dependencies{Transactions: mockLister, Logger: testLogger()})
+
+	captureID := uuid.New()
+	refundID := uuid.New()
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	expectedQuery := service.TransactionQuery{
+		AccountNumber: "4111111111111111",
+		ReferenceID:   captureID,
+		Type:          models.TransactionTypeRefund,
+		Status:        models.TransactionStatusCompleted,
+		CreatedFrom:   &from,
+		CreatedTo:     &to,
+		Cursor:        "abc",
+		Limit:         10,
+	}
+	mockLister.On("ListTransactions", mock.Anything, expectedQuery).
+		Return(&service.TransactionPage{
+			Transactions: []*models.Transaction{
+				{
+					ID:          refundID,
+					Type:        models.TransactionTypeRefund,
+					Status:      models.TransactionStatusCompleted,
+					AmountCents: 2500,
+					Currency:    "USD",
+					ReferenceID: &captureID,
+					CreatedAt:   from.Add(time.Hour),
+				},
+			},
+			NextCursor: "next",
+		}, nil)
+
+	req := api.ListTransactionsRequestObject{Params: api.ListTransactionsParams{
+		AccountNumber: "4111111111111111",
+		Type:          api.TransactionTypeRefund,
+		Status:        api.TransactionStatusCompleted,
+		ReferenceId:   "cap_" + captureID.String(),
+		CreatedFrom:   from,
+		CreatedTo:     to,
+		Cursor:        "abc",
+		Limit:         10,
+	}}
+	resp, err := handler.ListTransactions(context.Background(), req)
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.ListTransactions200JSONResponse)
+	require.True(t, ok)
+	require.Len(t, successResp.Data, 1)
+	assert.Equal(t, "ref_"+refundID.String(), successResp.Data[0].Id)
+	assert.Equal(t, api.TransactionTypeRefund, successResp.Data[0].Type)
+	assert.Equal(t, api.TransactionStatusCompleted, successResp.Data[0].Status)
+	assert.Equal(t, "cap_"+captureID.String(), successResp.Data[0].ReferenceId)
+	assert.Equal(t, int64(2500), successResp.Data[0].Amount)
+	assert.True(t, successResp.HasMore)
+	assert.Equal(t, "next", successResp.NextCursor)
+}
+
+func TestListTransactions_LastPage(t *testing.T) {
+	mockLister := mocks.NewMockTransactionLister(t)
+	handler := NewHandler(Dependencies{Transactions: mockLister, Logger: testLogger()})
+
+	authID := uuid.New()
+	mockLister.On("ListTransactions", mock.Anything, service.TransactionQuery{}).
+		Return(&service.TransactionPage{
+			Transactions: []*models.Transaction{
+				{
+					ID:          authID,
+					Type:        models.TransactionTypeAuthHold,
+					Status:      models.TransactionStatusActive,
+					AmountCents: 10000,
+					Currency:    "USD",
+					CreatedAt:   time.Now(),
+				},
+			},
+		}, nil)
+
+	resp, err := handler.ListTransactions(context.Background(), api.ListTransactionsRequestObject{})
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.ListTransactions200JSONResponse)
+	require.True(t, ok)
+	require.Len(t, successResp.Data, 1)
+	assert.Equal(t, "auth_"+authID.String(), successResp.Data[0].Id)
+	assert.Equal(t, api.TransactionTypeAuthorization, successResp.Data[0].Type)
+	assert.Empty(t, successResp.Data[0].ReferenceId)
+	assert.False(t, successResp.HasMore)
+	assert.Empty(t, successResp.NextCursor)
+}
+
+func TestListTransactions_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params api.ListTransactionsParams
+	}{
+		{
+			name:   "unknown type",
+			params: api.ListTransactionsParams{Type: "chargeback"},
+		},
+		{
+			name:   "unknown status",
+			params: api.ListTransactionsParams{Status: "pending"},
+		},
+		{
+			name:   "reference ID without prefix",
+			params: api.ListTransactionsParams{ReferenceId: uuid.New().String()},
+		},
+		{
+			name:   "reference ID with unsupported prefix",
+			params: api.ListTransactionsParams{ReferenceId: "ref_" + uuid.New().String()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(Dependencies{Logger: testLogger()})
+
+			resp, err := handler.ListTransactions(context.Background(), api.ListTransactionsRequestObject{Params: tt.params})
+
+			require.NoError(t, err)
+			errResp, ok := resp.(api.ListTransactions400JSONResponse)
+			require.True(t, ok)
+			assert.Equal(t, api.ErrorCodeInvalidQuery, errResp.Error)
+		})
+	}
+}
+
+func TestListTransactions_ServiceErrors(t *testing.T) {
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockLister := mocks.NewMockTransactionLister(t)
+		handler := NewHandler(Dependencies{Transactions: mockLister, Logger: testLogger()})
+
+		mockLister.On("ListTransactions", mock.Anything, mock.Anything).
+			Return(nil, &service.ServiceError{Code: service.ErrCodeInvalidQuery, Message: "invalid cursor"})
+
+		resp, err := handler.ListTransactions(context.Background(), api.ListTransactionsRequestObject{})
+
+		require.NoError(t, err)
+		errResp, ok := resp.(api.ListTransactions400JSONResponse)
+		require.True(t, ok)
+		assert.Equal(t, api.ErrorCodeInvalidQuery, errResp.Error)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockLister := mocks.NewMockTransactionLister(t)
+		handler := NewHandler(Dependencies{Transactions: mockLister, Logger: testLogger()})
+
+		mockLister.On("ListTransactions", mock.Anything, mock.Anything).
+			Return(nil, errors.New("database down"))
+
+		resp, err := handler.ListTransactions(context.Background(), api.ListTransactionsRequestObject{})
+
+		require.NoError(t, err)
+		_, ok := resp.(api.ListTransactions500JSONResponse)
+		require.True(t, ok)
+	})
+}
@@ -22,7 +22,7 @@ func (h *Handler) CreateVoid(
 		}, nil
 	}
 
-	txn, err := h.voidService.Void(ctx, authID)
+	txn, err := h.voidService.Void(ctx, authID, request.Body.Metadata)
 	if err != nil {
 		return h.handleVoidError(err)
 	}
@@ -31,7 +31,80 @@ func (h *Handler) CreateVoid(
 		VoidId:          formatVoidID(txn.ID),
 		AuthorizationId: formatAuthorizationID(*txn.ReferenceID),
 		Status:          api.Voided,
+		Amount:          txn.AmountCents,
+		Currency:        txn.Currency,
 		VoidedAt:        txn.CreatedAt,
+		Metadata:        txn.Metadata,
+	}, nil
+}
+
+// GetVoid handles GET /api/v1/voids/{voidId}
+func (h *Handler) GetVoid(
+	ctx context.Context,
+	request api.GetVoidRequestObject,
+) (api.GetVoidResponseObject, error) {
+	voidID, err := parseVoidID(request.VoidId)
+	if err != nil {
+		//nolint:nilerr // Returning 404 response object, not propagating error
+		return api.GetVoid404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse{
+				Error:   api.ErrorCodeNotFound,
+				Message: "void not found",
+			},
+		}, nil
+	}
+
+	txn, err := h.voidService.GetVoid(ctx, voidID)
+	if err != nil {
+		//nolint:nilerr // Returning 404 response object, not propagating error
+		return api.GetVoid404JSONResponse{
+			NotFoundJSONResponse: api.NotFoundJSONResponse{
+				Error:   api.ErrorCodeNotFound,
+				Message: "void not found",
+			},
+		}, nil
+	}
+
+	return api.GetVoid200JSONResponse{
+		VoidId:          formatVoidID(txn.ID),
+		AuthorizationId: formatAuthorizationID(*txn.ReferenceID),
+		Status:          api.Voided,
+		Amount:          txn.AmountCents,
+		Currency:        txn.Currency,
+		VoidedAt:        txn.CreatedAt,
+		Metadata:        txn.Metadata,
+	}, nil
+}
+
+// CreateAuthorizationReversal handles POST /api/v1/authorizations/{authorizationId}/reversals
+func (h *Handler) CreateAuthorizationReversal(
+	ctx context.Context,
+	request api.CreateAuthorizationReversalRequestObject,
+) (api.CreateAuthorizationReversalResponseObject, error) {
+	authID, err := parseAuthorizationID(request.AuthorizationId)
+	if err != nil {
+		//nolint:nilerr // Returning 400 response object, not propagating error
+		return api.CreateAuthorizationReversal400JSONResponse{
+			BadRequestJSONResponse: api.BadRequestJSONResponse{
+				Error:   api.ErrorCodeAuthorizationNotFound,
+				Message: "invalid authorization ID format",
+			},
+		}, nil
+	}
+
+	reversalTxn, authTxn, err := h.voidService.Reverse(ctx, authID, request.Body.Amount)
+	if err != nil {
+		return h.handleReversalError(err)
+	}
+
+	return api.CreateAuthorizationReversal200JSONResponse{
+		ReversalId:      formatReversalID(reversalTxn.ID),
+		AuthorizationId: formatAuthorizationID(authTxn.ID),
+		Status:          api.Reversed,
+		Amount:          reversalTxn.AmountCents,
+		RemainingAmount: authTxn.RemainingAmountCents(),
+		Currency:        reversalTxn.Currency,
+		ReversedAt:      reversalTxn.CreatedAt,
 	}, nil
 }
 
@@ -56,3 +129,25 @@ func (h *Handler) handleVoidError(err error) (api.CreateVoidResponseObject, erro
 		},
 	}, nil
 }
+
+func (h *Handler) handleReversalError(err error) (api.CreateAuthorizationReversalResponseObject, error) {
+	svcErr := extractServiceError(err)
+	if svcErr == nil {
+		h.logger.Error("unexpected error during reversal", "error", err)
+		return api.CreateAuthorizationReversal500JSONResponse{
+			InternalErrorJSONResponse: api.InternalErrorJSONResponse{
+				Error:   api.ErrorCodeInternalError,
+				Message: "internal error",
+			},
+		}, nil
+	}
+
+	errorCode := mapServiceErrorToCode(svcErr.Code)
+
+	return api.CreateAuthorizationReversal400JSONResponse{
+		BadRequestJSONResponse: api.BadRequestJSONResponse{
+			Error:   errorCode,
+			Message: svcErr.Message,
+		},
+	}, nil
+}
@@ -17,12 +17,12 @@ import (
 
 func TestCreateVoid_Success(t *testing.T) {
 	mockVoid := mocks.NewMockVoider(t)
-	handler := NewHandler(nil, nil, mockVoid, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Void: mockVoid, Logger: testLogger()})
 
 	authID := uuid.New()
 	voidID := uuid.New()
 
-	mockVoid.On("Void", mock.Anything, authID).
+	mockVoid.On("Void", mock.Anything, authID, map[string]string(nil)).
 		Return(&models.Transaction{
 			ID:          voidID,
 			ReferenceID: &authID,
@@ -55,9 +55,9 @@ func TestCreateVoid_ServiceErrors(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockVoid := mocks.NewMockVoider(t)
-			handler := NewHandler(nil, nil, mockVoid, nil, nil, testLogger())
+			handler := NewHandler(Dependencies{Void: mockVoid, Logger: testLogger()})
 
-			mockVoid.On("Void", mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
+			mockVoid.On("Void", mock.Anything, mock.Anything, mock.Anything).Return(nil, tt.serviceErr)
 
 			req := api.CreateVoidRequestObject{
 				Body: &api.CreateVoidJSONRequestBody{AuthorizationId: "auth_" + uuid.New().String()},
@@ -74,7 +74,7 @@ func TestCreateVoid_ServiceErrors(t *testing.T) {
 }
 
 func TestCreateVoid_InvalidIDFormat(t *testing.T) {
-	handler := NewHandler(nil, nil, nil, nil, nil, testLogger())
+	handler := NewHandler(Dependencies{Logger: testLogger()})
 
 	req := api.CreateVoidRequestObject{
 		Body: &api.CreateVoidJSONRequestBody{AuthorizationId: "invalid"},
@@ -87,3 +87,127 @@ func TestCreateVoid_InvalidIDFormat(t *testing.T) {
 	require.True(t, ok)
 	assert.Equal(t, api.ErrorCodeAuthorizationNotFound, badResp.Error)
 }
+
+func TestCreateAuthorizationReversal_Success(t *testing.T) {
+	mockVoid := mocks.NewMockVoider(t)
+	handler := NewHandler(Dependencies{Void: mockVoid, Logger: testLogger()})
+
+	authID := uuid.New()
+	reversalID := uuid.New()
+
+	mockVoid.On("Reverse", mock.Anything, authID, int64(2500)).
+		Return(&models.Transaction{
+			ID:          reversalID,
+			ReferenceID: &authID,
+			AmountCents: 2500,
+			Currency:    "USD",
+			CreatedAt:   time.Now(),
+		}, &models.Transaction{
+			ID:                  authID,
+			AmountCents:         10000,
+			ReversedAmountCents: 2500,
+			Status:              models.TransactionStatusActive,
+		}, nil)
+
+	req := api.CreateAuthorizationReversalRequestObject{
+		AuthorizationId: "auth_" + authID.String(),
+		Body:            &api.CreateAuthorizationReversalJSONRequestBody{Amount: 2500},
+	}
+
+	resp, err := handler.CreateAuthorizationReversal(context.Background(), req)
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.CreateAuthorizationReversal200JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, "rev_"+reversalID.String(), successResp.ReversalId)
+	assert.Equal(t, api.Reversed, successResp.Status)
+	assert.Equal(t, int64(2500), successResp.Amount)
+	assert.Equal(t, int64(7500), successResp.RemainingAmount)
+}
+
+func TestCreateAuthorizationReversal_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   *service.ServiceError
+		expectedCode api.ErrorCode
+	}{
+		{"auth not found", &service.ServiceError{Code: service.ErrCodeAuthNotFound}, api.ErrorCodeAuthorizationNotFound},
+		{"exceeds remaining", &service.ServiceError{Code: service.ErrCodeReversalExceedsAuth}, api.ErrorCodeReversalExceedsAuthorization},
+		{"already used", &service.ServiceError{Code: service.ErrCodeAuthAlreadyUsed}, api.ErrorCodeAuthorizationAlreadyUsed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockVoid := mocks.NewMockVoider(t)
+			handler := NewHandler(Dependencies{Void: mockVoid, Logger: testLogger()})
+
+			mockVoid.On("Reverse", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, tt.serviceErr)
+
+			req := api.CreateAuthorizationReversalRequestObject{
+				AuthorizationId: "auth_" + uuid.New().String(),
+				Body:            &api.CreateAuthorizationReversalJSONRequestBody{Amount: 2500},
+			}
+
+			resp, err := handler.CreateAuthorizationReversal(context.Background(), req)
+			require.NoError(t, err)
+
+			badResp, ok := resp.(api.CreateAuthorizationReversal400JSONResponse)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedCode, badResp.Error)
+		})
+	}
+}
+
+func TestGetVoid_Success(t *testing.T) {
+	mockVoid := mocks.NewMockVoider(t)
+	handler := NewHandler(Dependencies{Void: mockVoid, Logger: testLogger()})
+
+	authID := uuid.New()
+	voidID := uuid.New()
+
+	mockVoid.On("GetVoid", mock.Anything, voidID).
+		Return(&models.Transaction{
+			ID:          voidID,
+			ReferenceID: &authID,
+			AmountCents: 10000,
+			Currency:    "USD",
+			CreatedAt:   time.Now(),
+		}, nil)
+
+	req := api.GetVoidRequestObject{VoidId: "void_" + voidID.String()}
+	resp, err := handler.GetVoid(context.Background(), req)
+
+	require.NoError(t, err)
+	successResp, ok := resp.(api.GetVoid200JSONResponse)
+	require.True(t, ok)
+	assert.Equal(t, "void_"+voidID.String(), successResp.VoidId)
+	assert.Equal(t, "auth_"+authID.String(), successResp.AuthorizationId)
+	assert.Equal(t, int64(10000), successResp.Amount)
+}
+
+func TestGetVoid_NotFound(t *testing.T) {
+	mockVoid := mocks.NewMockVoider(t)
+	handler := NewHandler(Dependencies{Void: mockVoid, Logger: testLogger()})
+
+	voidID := uuid.New()
+	mockVoid.On("GetVoid", mock.Anything, voidID).
+		Return(nil, &service.ServiceError{Code: service.ErrCodeVoidNotFound})
+
+	req := api.GetVoidRequestObject{VoidId: "void_" + voidID.String()}
+	resp, err := handler.GetVoid(context.Background(), req)
+
+	require.NoError(t, err)
+	_, ok := resp.(api.GetVoid404JSONResponse)
+	require.True(t, ok)
+}
+
+func TestGetVoid_InvalidIDFormat(t *testing.T) {
+	handler := NewHandler(Dependencies{Logger: testLogger()})
+
+	req := api.GetVoidRequestObject{VoidId: "auth_" + uuid.New().String()}
+	resp, err := handler.GetVoid(context.Background(), req)
+
+	require.NoError(t, err)
+	_, ok := resp.(api.GetVoid404JSONResponse)
+	require.True(t, ok)
+}
@@ -2,18 +2,19 @@
 package middleware
 
 import (
-	"crypto/rand"
+	"context"
 	"encoding/json"
 	"log/slog"
-	"math/big"
 	"net/http"
 	"strings"
 	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
 	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
 )
 
-type chaosErrorResponse struct {
+type errorResponse struct {
 	Error   string `json:"error"`
 	Message string `json:"message"`
 }
@@ -21,11 +22,23 @@ type chaosErrorResponse struct {
 var excludedPaths = []string{
 	"/health",
 	"/docs",
+	"/admin",
 }
 
 // FailureInjection creates middleware that injects latency and random failures
-// for testing resilience of client applications.
-func FailureInjection(cfg *config.AppConfig, logger *slog.Logger) func(http.Handler) http.Handler {
+// for testing resilience of client applications. Each request gets at most one
+// fault, picked according to the rates in the chaos profile for its route, or by
+// the chaos script when one is set. During a degraded outage window the rates and
+// latency are raised to at least the cfg.Degraded* settings and the script is set
+// aside. With cfg.ChaosHeadersEnabled, a request that sends X-Mock-Fault or
+// X-Mock-Latency-Ms gets exactly what they ask for instead.
+func FailureInjection(settings *chaos.Controller, outages *outage.Schedule, cfg *config.AppConfig, logger *slog.Logger) func(http.Handler) http.Handler {
+	degraded := chaos.Profile{
+		FaultRates:   map[string]float64{config.FaultError: cfg.DegradedFailureRate},
+		MinLatencyMS: cfg.DegradedMinLatencyMS,
+		MaxLatencyMS: cfg.DegradedMaxLatencyMS,
+	}
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if isExcludedPath(r.URL.Path) {
@@ -33,22 +46,66 @@ func FailureInjection(cfg *config.AppConfig, logger *slog.Logger) func(http.Hand
 				return
 			}
 
-			injectLatency(cfg.MinLatencyMS, cfg.MaxLatencyMS)
+			var decision chaos.Decision
+			forced := false
+			if cfg.ChaosHeadersEnabled {
+				var err error
+				decision, forced, err = forcedDecision(r, cfg)
+				if err != nil {
+					writeInvalidChaosHeaderResponse(w, err)
+					return
+				}
+			}
+			isDegraded := false
+			if !forced {
+				isDegraded = outages.Status().Mode == config.OutageDegraded
+				if isDegraded {
+					decision = settings.DecideDegraded(r.Method, r.URL.Path, degraded)
+				} else {
+					decision = settings.Decide(r.Method, r.URL.Path)
+				}
+			}
+			if !sleep(r.Context(), decision.Latency) {
+				// The client gave up during the delay, so the request never runs. Nothing is
+				// written, which must not pass for the API's answer.
+				markInjected(w)
+				return
+			}
 
-			if shouldInjectFailure(cfg.FailureRate) {
-				logger.Debug("injecting random failure",
-					"path", r.URL.Path,
-					"method", r.Method,
-				)
-				writeFailureResponse(w)
+			if decision.Fault == "" {
+				next.ServeHTTP(w, r)
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			logger.Info("injecting fault",
+				"fault", decision.Fault,
+				"path", r.URL.Path,
+				"method", r.Method,
+				"forced", forced,
+				"degraded", isDegraded,
+			)
+			injectFault(decision.Fault, w, r, next, cfg)
 		})
 	}
 }
 
+// sleep waits for d, returning false early if ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
 func isExcludedPath(path string) bool {
 	for _, excluded := range excludedPaths {
 		if strings.HasPrefix(path, excluded) {
@@ -58,54 +115,60 @@ func isExcludedPath(path string) bool {
 	return false
 }
 
-func injectLatency(minMS, maxMS int) {
-	if minMS <= 0 && maxMS <= 0 {
-		return
-	}
+// faultMarker is implemented by response writers that need to know a response
+// was injected rather than produced by the API, such as the idempotency cache
+type faultMarker interface {
+	markInjected()
+}
 
-	rangeMS := maxMS - minMS
-	if rangeMS <= 0 {
-		time.Sleep(time.Duration(minMS) * time.Millisecond)
-		return
-	}
+// postCommitFaultMarker is implemented by response writers that commit the request's
+// changes after the handler returns, such as the idempotency cache. They send the
+// fault in place of the response once everything is committed.
+type postCommitFaultMarker interface {
+	failAfterCommit()
+}
 
-	randomOffset, err := rand.Int(rand.Reader, big.NewInt(int64(rangeMS)))
-	if err != nil {
-		time.Sleep(time.Duration(minMS) * time.Millisecond)
+// failAfterCommit runs the request to completion and then discards its response, so
+// the client sees a 500 for an operation that actually happened
+func failAfterCommit(w http.ResponseWriter, r *http.Request, next http.Handler) {
+	if marker, ok := w.(postCommitFaultMarker); ok {
+		marker.failAfterCommit()
+		next.ServeHTTP(w, r)
 		return
 	}
 
-	sleepMS := minMS + int(randomOffset.Int64())
-	time.Sleep(time.Duration(sleepMS) * time.Millisecond)
+	next.ServeHTTP(&discardResponse{header: http.Header{}}, r)
+	writePostCommitFailureResponse(w)
 }
 
-func shouldInjectFailure(failureRate float64) bool {
-	if failureRate <= 0 {
-		return false
-	}
-	if failureRate >= 1 {
-		return true
-	}
+// discardResponse swallows a response that is never sent to the client
+type discardResponse struct {
+	header http.Header
+}
 
-	const precision = 1000000
-	randomNum, err := rand.Int(rand.Reader, big.NewInt(precision))
-	if err != nil {
-		return false
-	}
+func (d *discardResponse) Header() http.Header         { return d.header }
+func (d *discardResponse) Write(b []byte) (int, error) { return len(b), nil }
+func (d *discardResponse) WriteHeader(int)             {}
 
-	threshold := int64(failureRate * precision)
-	return randomNum.Int64() < threshold
+func writePostCommitFailureResponse(w http.ResponseWriter) {
+	writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "Failure injected after commit")
 }
 
 func writeFailureResponse(w http.ResponseWriter) {
+	markInjected(w)
+	writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "Random failure injection")
+}
+
+// writeErrorResponse writes a JSON error in the same shape as the API's ErrorResponse
+func writeErrorResponse(w http.ResponseWriter, status int, code, message string) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusInternalServerError)
+	w.WriteHeader(status)
 
-	resp := chaosErrorResponse{
-		Error:   "internal_error",
-		Message: "Random failure injection",
+	resp := errorResponse{
+		Error:   code,
+		Message: message,
 	}
 
-	//nolint:errcheck // Best effort response writing in chaos injection
+	//nolint:errcheck // Best effort response writing
 	json.NewEncoder(w).Encode(resp)
 }
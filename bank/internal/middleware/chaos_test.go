@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
+	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testFailureInjection(cfg *config.AppConfig) func(http.Handler) http.Handler {
+	return FailureInjection(chaos.NewController(chaos.FromConfig(cfg), chaos.LimitsFromConfig(cfg)), outage.NewSchedule(cfg.OutageWindows, clock.System), cfg, testLogger())
+}
+
+func TestFailureInjection_PostCommitFailure(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantBody   string
+		wantStatus int
+	}{
+		{name: "api request fails after running", path: "/api/v1/transactions", wantStatus: http.StatusInternalServerError, wantBody: "Failure injected after commit"},
+		{name: "excluded path is untouched", path: "/health", wantStatus: http.StatusOK, wantBody: `{"status":"ok"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.PostCommitFailureRate = 1
+
+			handlerCalled := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerCalled = true
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(`{"status":"ok"}`)) //nolint:errcheck // test helper
+			})
+
+			rec := httptest.NewRecorder()
+			testFailureInjection(cfg)(handler).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.True(t, handlerCalled)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+// faultServer serves a handler that always answers 200 behind the idempotency and
+// chaos middleware, with every request getting the given fault
+func faultServer(t *testing.T, fault string, configure func(cfg *config.AppConfig)) *httptest.Server {
+	t.Helper()
+
+	repo := mocks.NewMockIdempotencyRepository(t)
+	repo.On("Get", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	repo.On("Reserve", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Maybe()
+	repo.On("Release", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	cfg := testConfig()
+	cfg.FaultRates = map[string]float64{fault: 1}
+	cfg.FaultHangDuration = time.Minute
+	cfg.FaultRetryAfterSeconds = 3
+	if configure != nil {
+		configure(cfg)
+	}
+
+	handler := testHandler(http.StatusOK, `{"status":"approved"}`)
+	srv := httptest.NewServer(Idempotency(repo, cfg, testLogger())(testFailureInjection(cfg)(handler)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func postCapture(t *testing.T, client *http.Client, url string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url+"/api/v1/captures", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("Idempotency-Key", "fault-key")
+	return client.Do(req)
+}
+
+func TestFailureInjection_ResponseFaults(t *testing.T) {
+	tests := []struct {
+		fault          string
+		wantRetryAfter string
+		wantBody       string
+		wantStatus     int
+	}{
+		{fault: config.FaultMalformedBody, wantStatus: http.StatusOK, wantBody: "<html>"},
+		{fault: config.FaultBadGateway, wantStatus: http.StatusBadGateway, wantBody: "502 Bad Gateway"},
+		{fault: config.FaultBadGatewayRetryAfter, wantStatus: http.StatusBadGateway, wantRetryAfter: "3"},
+		{fault: config.FaultUnavailable, wantStatus: http.StatusServiceUnavailable, wantBody: "503 Service Unavailable"},
+		{fault: config.FaultUnavailableRetryAfter, wantStatus: http.StatusServiceUnavailable, wantRetryAfter: "3"},
+		{fault: config.FaultGatewayTimeout, wantStatus: http.StatusGatewayTimeout, wantBody: "504 Gateway Timeout"},
+		{fault: config.FaultGatewayTimeoutRetryAfter, wantStatus: http.StatusGatewayTimeout, wantRetryAfter: "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fault, func(t *testing.T) {
+			srv := faultServer(t, tt.fault, nil)
+
+			resp, err := postCapture(t, srv.Client(), srv.URL)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // test cleanup
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantRetryAfter, resp.Header.Get("Retry-After"))
+			assert.Contains(t, string(body), tt.wantBody)
+			assert.False(t, json.Valid(body), "the body cannot be parsed as JSON")
+		})
+	}
+}
+
+func TestFailureInjection_ConnectionFaults(t *testing.T) {
+	t.Run("reset", func(t *testing.T) {
+		srv := faultServer(t, config.FaultReset, nil)
+
+		_, err := postCapture(t, srv.Client(), srv.URL) //nolint:bodyclose // no response
+		require.Error(t, err)
+	})
+
+	t.Run("truncated body", func(t *testing.T) {
+		srv := faultServer(t, config.FaultTruncatedBody, nil)
+
+		resp, err := postCapture(t, srv.Client(), srv.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close() //nolint:errcheck // test cleanup
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		body, err := io.ReadAll(resp.Body)
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+		assert.Equal(t, truncatedBody, string(body))
+	})
+
+	t.Run("hang past the client timeout", func(t *testing.T) {
+		srv := faultServer(t, config.FaultHang, nil)
+		client := srv.Client()
+		client.Timeout = 50 * time.Millisecond
+
+		_, err := postCapture(t, client, srv.URL) //nolint:bodyclose // no response
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+	})
+
+	t.Run("hang then drop", func(t *testing.T) {
+		srv := faultServer(t, config.FaultHang, func(cfg *config.AppConfig) {
+			cfg.FaultHangDuration = 20 * time.Millisecond
+		})
+
+		_, err := postCapture(t, srv.Client(), srv.URL) //nolint:bodyclose // no response
+		require.Error(t, err)
+	})
+}
+
+func TestFailureInjection_RouteProfiles(t *testing.T) {
+	cfg := testConfig()
+	settings := chaos.NewController(chaos.Settings{
+		Routes: []chaos.Rule{{
+			Method:  http.MethodPost,
+			Path:    "/api/v1/captures",
+			Profile: chaos.Profile{FaultRates: map[string]float64{config.FaultError: 1}},
+		}},
+	}, chaos.Limits{})
+	handler := FailureInjection(settings, outage.NewSchedule(nil, clock.System), cfg, testLogger())(testHandler(http.StatusOK, `{"status":"ok"}`))
+
+	serve := func(method, path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, serve(http.MethodPost, "/api/v1/captures"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodGet, "/api/v1/captures"))
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, "/api/v1/authorizations"))
+
+	require.NoError(t, settings.Update(chaos.Settings{
+		Default: chaos.Profile{FaultRates: map[string]float64{config.FaultBadGateway: 1}},
+	}))
+	assert.Equal(t, http.StatusBadGateway, serve(http.MethodPost, "/api/v1/captures"))
+	assert.Equal(t, http.StatusBadGateway, serve(http.MethodPost, "/api/v1/authorizations"))
+}
@@ -0,0 +1,144 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/config"
+)
+
+// truncatedBody is the start of an authorization response, cut off mid-way
+const truncatedBody = `{"authorization_id":"auth_5f0c6b1e-8d2a-4c3b-9e7f-1a2b3c4d5e6f","status":"approved","amount":`
+
+// malformedBody is what a misconfigured proxy in front of the bank might return with a 200
+const malformedBody = `<html><body>OK</body></html>`
+
+// connectionTaker is implemented by response writers that buffer the response, such
+// as the idempotency cache. takeOver hands a fault the writer that reaches the client,
+// for faults that need the connection itself; the buffering writer then sends nothing.
+type connectionTaker interface {
+	takeOver() http.ResponseWriter
+}
+
+// injectFault answers the request with a fault of the given kind instead of the API's response
+func injectFault(kind string, w http.ResponseWriter, r *http.Request, next http.Handler, cfg *config.AppConfig) {
+	switch kind {
+	case config.FaultError:
+		writeFailureResponse(w)
+	case config.FaultErrorAfterCommit:
+		failAfterCommit(w, r, next)
+	case config.FaultHang:
+		hang(clientWriter(w), r, cfg.FaultHangDuration)
+	case config.FaultReset:
+		dropConnection(clientWriter(w), true)
+	case config.FaultTruncatedBody:
+		writeTruncatedBody(clientWriter(w))
+	case config.FaultMalformedBody:
+		writeMalformedBody(w)
+	case config.FaultBadGateway:
+		writeGatewayError(w, http.StatusBadGateway, 0)
+	case config.FaultBadGatewayRetryAfter:
+		writeGatewayError(w, http.StatusBadGateway, cfg.FaultRetryAfterSeconds)
+	case config.FaultUnavailable:
+		writeGatewayError(w, http.StatusServiceUnavailable, 0)
+	case config.FaultUnavailableRetryAfter:
+		writeGatewayError(w, http.StatusServiceUnavailable, cfg.FaultRetryAfterSeconds)
+	case config.FaultGatewayTimeout:
+		writeGatewayError(w, http.StatusGatewayTimeout, 0)
+	case config.FaultGatewayTimeoutRetryAfter:
+		writeGatewayError(w, http.StatusGatewayTimeout, cfg.FaultRetryAfterSeconds)
+	default:
+		next.ServeHTTP(w, r)
+	}
+}
+
+// clientWriter returns the writer that reaches the client, taking it over from any
+// writer that buffers the response
+func clientWriter(w http.ResponseWriter) http.ResponseWriter {
+	for {
+		taker, ok := w.(connectionTaker)
+		if !ok {
+			return w
+		}
+		w = taker.takeOver()
+	}
+}
+
+// markInjected tells a buffering writer the response is a fault, so it is never cached
+func markInjected(w http.ResponseWriter) {
+	if marker, ok := w.(faultMarker); ok {
+		marker.markInjected()
+	}
+}
+
+// hang sends nothing until the client gives up or the hang duration passes, then
+// drops the connection without a response
+func hang(w http.ResponseWriter, r *http.Request, duration time.Duration) {
+	var timeout <-chan time.Time
+	if duration > 0 {
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-r.Context().Done():
+	case <-timeout:
+		dropConnection(w, false)
+	}
+}
+
+// dropConnection closes the client's connection without finishing the response. With
+// reset, unsent data is discarded and the client gets a TCP RST instead of a FIN.
+func dropConnection(w http.ResponseWriter, reset bool) {
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		// Not an HTTP/1 connection that can be taken over: the server aborts it instead
+		panic(http.ErrAbortHandler)
+	}
+
+	if tcpConn, ok := conn.(*net.TCPConn); ok && reset {
+		//nolint:errcheck // Best effort, the connection is closed either way
+		tcpConn.SetLinger(0)
+	}
+	//nolint:errcheck // The client is meant to see a broken connection
+	conn.Close()
+}
+
+// writeTruncatedBody promises a longer JSON body than it sends, then closes the connection
+func writeTruncatedBody(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(2*len(truncatedBody)))
+	w.WriteHeader(http.StatusOK)
+	//nolint:errcheck // Best effort response writing
+	w.Write([]byte(truncatedBody))
+	//nolint:errcheck // Best effort, hijacking does not send buffered data
+	http.NewResponseController(w).Flush()
+
+	dropConnection(w, false)
+}
+
+func writeMalformedBody(w http.ResponseWriter) {
+	markInjected(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	//nolint:errcheck // Best effort response writing
+	w.Write([]byte(malformedBody))
+}
+
+// writeGatewayError writes the HTML error page a proxy in front of the bank would send
+func writeGatewayError(w http.ResponseWriter, status, retryAfterSeconds int) {
+	markInjected(w)
+	if retryAfterSeconds > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+
+	title := fmt.Sprintf("%d %s", status, http.StatusText(status))
+	//nolint:errcheck // Best effort response writing
+	fmt.Fprintf(w, "<html><head><title>%s</title></head><body><h1>%s</h1></body></html>", title, title)
+}
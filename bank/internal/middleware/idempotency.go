@@ -4,21 +4,41 @@ package middleware
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
 	"log/slog"
 	"net/http"
+	"path"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 )
 
 const idempotencyKeyHeader = "Idempotency-Key"
 
+const (
+	// inProgressPollInterval is how often a waiting duplicate re-checks the key
+	inProgressPollInterval = 50 * time.Millisecond
+	// retryAfterSeconds is sent with 409 request_in_progress
+	retryAfterSeconds = 1
+)
+
 // idempotentPaths defines which paths require idempotency handling
 //
-// Only mutating operations (POST) need idempotency
+// Only mutating operations (POST) need idempotency. Entries are path.Match
+// patterns, so "*" stands for a single path segment such as a resource ID.
 var idempotentPaths = []string{
 	"/api/v1/authorizations",
+	"/api/v1/authorizations/*/increments",
+	"/api/v1/authorizations/*/reversals",
 	"/api/v1/captures",
 	"/api/v1/voids",
 	"/api/v1/refunds",
@@ -28,12 +48,22 @@ var idempotentPaths = []string{
 type IdempotencyRepository interface {
 	Get(ctx context.Context, key, requestPath string) (*models.IdempotencyKey, error)
 	Store(ctx context.Context, idemKey *models.IdempotencyKey) error
+	Reserve(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration) (bool, error)
+	Release(ctx context.Context, key, requestPath, lockToken string) error
 }
 
+// responseCapture buffers the handler's response, so it is only sent to the
+// client once the request's changes and its idempotency key are committed
 type responseCapture struct {
 	http.ResponseWriter
 	body       bytes.Buffer
 	statusCode int
+	injected   bool // the response is a fault injected by FailureInjection
+	// failCommitted makes flush send an injected 500 instead of the response,
+	// after the request's changes and key are committed
+	failCommitted bool
+	// takenOver means a fault wrote to the client directly, so flush sends nothing
+	takenOver bool
 }
 
 func newResponseCapture(w http.ResponseWriter) *responseCapture {
@@ -45,16 +75,46 @@ func newResponseCapture(w http.ResponseWriter) *responseCapture {
 
 func (rc *responseCapture) WriteHeader(code int) {
 	rc.statusCode = code
-	rc.ResponseWriter.WriteHeader(code)
 }
 
 func (rc *responseCapture) Write(b []byte) (int, error) {
-	rc.body.Write(b) // Capture for caching
-	return rc.ResponseWriter.Write(b)
+	return rc.body.Write(b)
+}
+
+func (rc *responseCapture) markInjected() {
+	rc.injected = true
+}
+
+func (rc *responseCapture) failAfterCommit() {
+	rc.failCommitted = true
+}
+
+func (rc *responseCapture) takeOver() http.ResponseWriter {
+	rc.injected = true
+	rc.takenOver = true
+	return rc.ResponseWriter
+}
+
+// flush sends the buffered response to the client
+func (rc *responseCapture) flush() {
+	if rc.takenOver {
+		return
+	}
+	if rc.failCommitted {
+		writePostCommitFailureResponse(rc.ResponseWriter)
+		return
+	}
+	rc.ResponseWriter.WriteHeader(rc.statusCode)
+	//nolint:errcheck // Best effort response writing
+	rc.ResponseWriter.Write(rc.body.Bytes())
 }
 
 // Idempotency creates middleware that handles idempotent request caching.
-func Idempotency(repo IdempotencyRepository, logger *slog.Logger) func(http.Handler) http.Handler {
+//
+// A request reserves its key before it runs. A concurrent request with the same key
+// waits up to cfg.IdempotencyWaitTimeout for the stored response and otherwise gets
+// 409 request_in_progress with Retry-After.
+func Idempotency(repo IdempotencyRepository, cfg *config.AppConfig, logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if !requiresIdempotency(r) {
@@ -69,60 +129,253 @@ func Idempotency(repo IdempotencyRepository, logger *slog.Logger) func(http.Hand
 				return
 			}
 
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				logger.Error("failed to read request body", "error", err)
+				writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "failed to read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
 			requestPath := normalizeRequestPath(r.URL.Path)
+			requestHash := fingerprintRequest(body)
 			ctx := r.Context()
 
-			cached, err := repo.Get(ctx, idempotencyKey, requestPath)
-			if err != nil {
-				logger.Error("failed to check idempotency cache", "error", err)
-				next.ServeHTTP(w, r)
-				return
+			reservation := &models.IdempotencyKey{
+				Key:         idempotencyKey,
+				RequestPath: requestPath,
+				RequestHash: requestHash,
 			}
+			deadline := time.Now().Add(cfg.IdempotencyWaitTimeout)
+			for {
+				cached, err := repo.Get(ctx, idempotencyKey, requestPath)
+				if err != nil {
+					logger.Error("failed to check idempotency cache", "error", err)
+					next.ServeHTTP(w, r)
+					return
+				}
 
-			if cached != nil {
-				logger.Debug("returning cached idempotent response",
-					"key", idempotencyKey,
-					"path", requestPath,
-					"status", cached.ResponseStatus,
-				)
-				w.Header().Set("Content-Type", "application/json")
-				w.Header().Set("X-Idempotent-Replayed", "true")
-				w.WriteHeader(cached.ResponseStatus)
-				//nolint:errcheck // Best effort response writing
-				w.Write([]byte(cached.ResponseBody))
-				return
+				if cached != nil && cached.RequestHash != "" && cached.RequestHash != requestHash {
+					logger.Warn("idempotency key reused with a different request body",
+						"key", idempotencyKey,
+						"path", requestPath,
+					)
+					writeErrorResponse(w, http.StatusUnprocessableEntity, "idempotency_key_reused",
+						"Idempotency-Key was already used with a different request body")
+					return
+				}
+
+				if cached != nil && !cached.InProgress() {
+					logger.Debug("returning cached idempotent response",
+						"key", idempotencyKey,
+						"path", requestPath,
+						"status", cached.ResponseStatus,
+					)
+					writeReplayedResponse(w, cached)
+					return
+				}
+
+				reserved, err := repo.Reserve(ctx, reservation, cfg.IdempotencyLockTimeout)
+				if err != nil {
+					logger.Error("failed to reserve idempotency key", "error", err)
+					next.ServeHTTP(w, r)
+					return
+				}
+				if reserved {
+					break
+				}
+
+				// Another request holds the key: wait for its response rather than run the operation twice
+				if !time.Now().Before(deadline) {
+					logger.Warn("idempotency key still in progress",
+						"key", idempotencyKey,
+						"path", requestPath,
+					)
+					w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+					writeErrorResponse(w, http.StatusConflict, "request_in_progress",
+						"a request with this Idempotency-Key is still being processed")
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(inProgressPollInterval):
+				}
 			}
 
+			// Bookkeeping must outlive the client, or a disconnect would leave the key locked
+			storeCtx := context.WithoutCancel(ctx)
+			// settled means the reservation was completed or lost, so there is nothing to release
+			settled := false
+			defer func() {
+				if settled {
+					return
+				}
+				err := repo.Release(storeCtx, idempotencyKey, requestPath, reservation.LockToken)
+				if errors.Is(err, models.ErrReservationLost) {
+					logger.Warn("idempotency key reservation was taken over before release",
+						"key", idempotencyKey,
+						"path", requestPath,
+					)
+				} else if err != nil {
+					logger.Error("failed to release idempotency key",
+						"error", err,
+						"key", idempotencyKey,
+					)
+				}
+			}()
+
+			// Ledger writes made by the handler are held in one transaction that is
+			// committed together with the idempotency key, so a crash cannot leave
+			// one without the other
+			txCtx, pending := db.WithPendingTx(ctx)
 			capture := newResponseCapture(w)
-			next.ServeHTTP(capture, r)
-
-			if shouldCacheResponse(capture.statusCode) {
-				idemKey := &models.IdempotencyKey{
-					Key:            idempotencyKey,
-					RequestPath:    requestPath,
-					ResponseStatus: capture.statusCode,
-					ResponseBody:   capture.body.String(),
-					CreatedAt:      time.Now(),
+			next.ServeHTTP(capture, r.WithContext(txCtx))
+
+			if capture.injected || !shouldCacheResponse(capture.statusCode, cfg.IdempotencyCachedErrorStatuses) {
+				if err := pending.Rollback(); err != nil {
+					logger.Error("failed to roll back request transaction", "error", err)
 				}
+				capture.flush()
+				return
+			}
 
-				if err := repo.Store(ctx, idemKey); err != nil {
+			idemKey := &models.IdempotencyKey{
+				Key:            idempotencyKey,
+				RequestPath:    requestPath,
+				RequestHash:    requestHash,
+				LockToken:      reservation.LockToken,
+				ResponseStatus: capture.statusCode,
+				ResponseBody:   capture.body.String(),
+				CreatedAt:      time.Now(),
+			}
+
+			// A decline changed nothing, so its transaction is discarded and only the key is stored.
+			// Failing to store it just means a retry is evaluated again.
+			if !isSuccess(capture.statusCode) {
+				if err := pending.Rollback(); err != nil {
+					logger.Error("failed to roll back request transaction", "error", err)
+				}
+				err := repo.Store(storeCtx, idemKey)
+				if errors.Is(err, models.ErrReservationLost) {
+					settled = true
+					respondAfterTakeover(ctx, w, repo, reservation, cfg.IdempotencyWaitTimeout, logger)
+					return
+				}
+				if err != nil {
 					logger.Error("failed to store idempotency key",
 						"error", err,
 						"key", idempotencyKey,
 					)
+				} else {
+					settled = true
+				}
+				capture.flush()
+				return
+			}
+
+			err = repo.Store(txCtx, idemKey)
+			if errors.Is(err, models.ErrReservationLost) {
+				settled = true
+				if err := pending.Rollback(); err != nil {
+					logger.Error("failed to roll back request transaction", "error", err)
+				}
+				respondAfterTakeover(ctx, w, repo, reservation, cfg.IdempotencyWaitTimeout, logger)
+				return
+			}
+			if err != nil {
+				logger.Error("failed to store idempotency key",
+					"error", err,
+					"key", idempotencyKey,
+				)
+				if err := pending.Rollback(); err != nil {
+					logger.Error("failed to roll back request transaction", "error", err)
 				}
+				writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "internal error")
+				return
 			}
+
+			if err := pending.Commit(); err != nil {
+				logger.Error("failed to commit request transaction",
+					"error", err,
+					"key", idempotencyKey,
+				)
+				writeErrorResponse(w, http.StatusInternalServerError, "internal_error", "internal error")
+				return
+			}
+			settled = true
+
+			capture.flush()
 		})
 	}
 }
 
+// respondAfterTakeover answers a request whose reservation went stale and was taken over by
+// another request with the same key before it could store its response. Its own changes
+// are discarded, so it replays the response the new holder stores, waiting for it as a
+// duplicate would. If none arrives in time the client is told to retry.
+func respondAfterTakeover(
+	ctx context.Context,
+	w http.ResponseWriter,
+	repo IdempotencyRepository,
+	reservation *models.IdempotencyKey,
+	waitTimeout time.Duration,
+	logger *slog.Logger,
+) {
+	logger.Warn("idempotency key reservation was taken over; discarding this request's outcome",
+		"key", reservation.Key,
+		"path", reservation.RequestPath,
+	)
+
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		cached, err := repo.Get(context.WithoutCancel(ctx), reservation.Key, reservation.RequestPath)
+		if err != nil {
+			logger.Error("failed to check idempotency cache", "error", err)
+			break
+		}
+		if cached != nil && cached.RequestHash != "" && cached.RequestHash != reservation.RequestHash {
+			writeErrorResponse(w, http.StatusUnprocessableEntity, "idempotency_key_reused",
+				"Idempotency-Key was already used with a different request body")
+			return
+		}
+		if cached != nil && !cached.InProgress() {
+			writeReplayedResponse(w, cached)
+			return
+		}
+		if cached == nil || !time.Now().Before(deadline) {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(inProgressPollInterval):
+		}
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+	writeErrorResponse(w, http.StatusConflict, "request_in_progress",
+		"a request with this Idempotency-Key is still being processed")
+}
+
+// writeReplayedResponse sends a stored response again
+func writeReplayedResponse(w http.ResponseWriter, cached *models.IdempotencyKey) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Idempotent-Replayed", "true")
+	w.WriteHeader(cached.ResponseStatus)
+	//nolint:errcheck // Best effort response writing
+	w.Write([]byte(cached.ResponseBody))
+}
+
 func requiresIdempotency(r *http.Request) bool {
 	if r.Method != http.MethodPost {
 		return false
 	}
 
-	for _, path := range idempotentPaths {
-		if r.URL.Path == path {
+	for _, pattern := range idempotentPaths {
+		if matched, _ := path.Match(pattern, r.URL.Path); matched {
 			return true
 		}
 	}
@@ -133,6 +386,37 @@ func normalizeRequestPath(urlPath string) string {
 	return strings.TrimSuffix(urlPath, "/")
 }
 
-func shouldCacheResponse(statusCode int) bool {
+// fingerprintRequest hashes the request body so a reused key can be checked against the original payload.
+// JSON bodies are canonicalized first, so whitespace and key order do not change the fingerprint.
+func fingerprintRequest(body []byte) string {
+	canonical := body
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var parsed any
+	if err := decoder.Decode(&parsed); err == nil {
+		if reencoded, err := json.Marshal(parsed); err == nil {
+			canonical = reencoded
+		}
+	}
+
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])
+}
+
+// shouldCacheResponse reports whether a response is final for its key. Successes always are;
+// errors only when their status is listed as a deterministic outcome such as a decline.
+// Server errors are never cached, so a retry can still succeed.
+func shouldCacheResponse(statusCode int, cachedErrorStatuses []int) bool {
+	if isSuccess(statusCode) {
+		return true
+	}
+	if statusCode >= 500 {
+		return false
+	}
+	return slices.Contains(cachedErrorStatuses, statusCode)
+}
+
+func isSuccess(statusCode int) bool {
 	return statusCode >= 200 && statusCode < 300
 }
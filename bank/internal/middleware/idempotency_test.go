@@ -1,23 +1,36 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/service/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func testLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
 }
 
+func testConfig() *config.AppConfig {
+	return &config.AppConfig{
+		IdempotencyLockTimeout:         30 * time.Second,
+		IdempotencyCachedErrorStatuses: []int{400, 402, 409, 422},
+	}
+}
+
 func testHandler(status int, body string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(status)
@@ -27,7 +40,7 @@ func testHandler(status int, body string) http.Handler {
 
 func TestIdempotency_GETRequestsBypassed(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handlerCalled := false
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -48,7 +61,7 @@ func TestIdempotency_GETRequestsBypassed(t *testing.T) {
 
 func TestIdempotency_NonIdempotentPathBypassed(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handlerCalled := false
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -69,7 +82,7 @@ func TestIdempotency_NonIdempotentPathBypassed(t *testing.T) {
 
 func TestIdempotency_MissingKeyPassesThrough(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handlerCalled := false
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -91,9 +104,10 @@ func TestIdempotency_MissingKeyPassesThrough(t *testing.T) {
 func TestIdempotency_FirstRequestCached(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
 	repo.On("Get", mock.Anything, "unique-key-123", "/api/v1/authorizations").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
 	repo.On("Store", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey")).Return(nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 	handler := testHandler(http.StatusOK, `{"status":"success"}`)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
@@ -121,7 +135,7 @@ func TestIdempotency_SecondRequestReturnsCached(t *testing.T) {
 	}
 	repo.On("Get", mock.Anything, "duplicate-key", "/api/v1/authorizations").Return(cached, nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	callCount := 0
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -145,9 +159,10 @@ func TestIdempotency_SecondRequestReturnsCached(t *testing.T) {
 func TestIdempotency_SameKeyDifferentPathsAreSeparate(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
 	repo.On("Get", mock.Anything, "shared-key", mock.Anything).Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
 	repo.On("Store", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey")).Return(nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -177,9 +192,16 @@ func TestIdempotency_SameKeyDifferentPathsAreSeparate(t *testing.T) {
 func TestIdempotency_5xxResponsesNotCached(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
 	repo.On("Get", mock.Anything, "error-key", "/api/v1/authorizations").Return(nil, nil)
-	// Store should NOT be called for 5xx responses
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*models.IdempotencyKey).LockToken = "token-1"
+		}).
+		Return(true, nil)
+	// Store should NOT be called for 5xx responses; the reservation is released instead,
+	// and only if this request still holds it
+	repo.On("Release", mock.Anything, "error-key", "/api/v1/authorizations", "token-1").Return(nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -196,24 +218,129 @@ func TestIdempotency_5xxResponsesNotCached(t *testing.T) {
 	repo.AssertNotCalled(t, "Store")
 }
 
-func TestIdempotency_4xxResponsesNotCached(t *testing.T) {
+func TestIdempotency_UnlistedErrorStatusNotCached(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
-	repo.On("Get", mock.Anything, "bad-request-key", "/api/v1/authorizations").Return(nil, nil)
+	repo.On("Get", mock.Anything, "not-found-key", "/api/v1/captures").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	repo.On("Release", mock.Anything, "not-found-key", "/api/v1/captures", mock.Anything).Return(nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusBadRequest)
-		_, _ = w.Write([]byte(`{"error":"bad request"}`)) //nolint:errcheck // test helper
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"authorization_not_found"}`)) //nolint:errcheck // test helper
 	})
 
-	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
-	req.Header.Set("Idempotency-Key", "bad-request-key")
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	req.Header.Set("Idempotency-Key", "not-found-key")
 	rec := httptest.NewRecorder()
 
 	middleware(handler).ServeHTTP(rec, req)
 
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	repo.AssertNotCalled(t, "Store")
+}
+
+func TestIdempotency_CachePolicy(t *testing.T) {
+	tests := []struct {
+		name           string
+		cachedStatuses []int
+		status         int
+		wantCached     bool
+	}{
+		{name: "success", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusOK, wantCached: true},
+		{name: "validation error", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusBadRequest, wantCached: true},
+		{name: "decline", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusPaymentRequired, wantCached: true},
+		{name: "conflict", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusConflict, wantCached: true},
+		{name: "unprocessable", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusUnprocessableEntity, wantCached: true},
+		{name: "unlisted 4xx", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusNotFound, wantCached: false},
+		{name: "decline with caching disabled", cachedStatuses: nil, status: http.StatusPaymentRequired, wantCached: false},
+		{name: "success with caching disabled", cachedStatuses: nil, status: http.StatusCreated, wantCached: true},
+		{name: "server error", cachedStatuses: []int{400, 402, 409, 422}, status: http.StatusInternalServerError, wantCached: false},
+		{name: "server error even if listed", cachedStatuses: []int{503}, status: http.StatusServiceUnavailable, wantCached: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantCached, shouldCacheResponse(tt.status, tt.cachedStatuses))
+		})
+	}
+}
+
+func TestIdempotency_DeclineIsCachedAndReplayed(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+	repo.On("Get", mock.Anything, "decline-key", "/api/v1/authorizations").Return(nil, nil).Once()
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+
+	var stored *models.IdempotencyKey
+	repo.On("Store", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.IdempotencyKey)
+		}).Return(nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	callCount := 0
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		if callCount == 1 {
+			w.WriteHeader(http.StatusPaymentRequired)
+			_, _ = w.Write([]byte(`{"error":"insufficient_funds"}`)) //nolint:errcheck // test helper
+			return
+		}
+		// The account was topped up in between: a re-run would now succeed
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"approved"}`)) //nolint:errcheck // test helper
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
+	req.Header.Set("Idempotency-Key", "decline-key")
+	rec := httptest.NewRecorder()
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusPaymentRequired, rec.Code)
+	require.NotNil(t, stored, "decline should be stored")
+	assert.Equal(t, http.StatusPaymentRequired, stored.ResponseStatus)
+
+	repo.On("Get", mock.Anything, "decline-key", "/api/v1/authorizations").Return(stored, nil).Once()
+
+	retry := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
+	retry.Header.Set("Idempotency-Key", "decline-key")
+	retryRec := httptest.NewRecorder()
+	middleware(handler).ServeHTTP(retryRec, retry)
+
+	assert.Equal(t, 1, callCount, "retry should not run the handler again")
+	assert.Equal(t, http.StatusPaymentRequired, retryRec.Code)
+	assert.Equal(t, "true", retryRec.Header().Get("X-Idempotent-Replayed"))
+	assert.Equal(t, `{"error":"insufficient_funds"}`, retryRec.Body.String())
+}
+
+func TestIdempotency_InjectedFailureNotCached(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+	repo.On("Get", mock.Anything, "chaos-key", "/api/v1/refunds").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	repo.On("Release", mock.Anything, "chaos-key", "/api/v1/refunds", mock.Anything).Return(nil)
+
+	cfg := testConfig()
+	cfg.FailureRate = 1
+
+	chaos := testFailureInjection(cfg)
+	middleware := Idempotency(repo, cfg, testLogger())
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/refunds", nil)
+	req.Header.Set("Idempotency-Key", "chaos-key")
+	rec := httptest.NewRecorder()
+
+	middleware(chaos(handler)).ServeHTTP(rec, req)
+
+	assert.False(t, handlerCalled, "failure is injected before the handler runs")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
 	repo.AssertNotCalled(t, "Store")
 }
 
@@ -221,7 +348,7 @@ func TestIdempotency_RepoGetErrorFailsOpen(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
 	repo.On("Get", mock.Anything, "test-key", "/api/v1/authorizations").Return(nil, errors.New("database connection failed"))
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 
 	handlerCalled := false
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -239,12 +366,14 @@ func TestIdempotency_RepoGetErrorFailsOpen(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
-func TestIdempotency_RepoStoreErrorDoesNotAffectResponse(t *testing.T) {
+func TestIdempotency_RepoStoreErrorFailsRequest(t *testing.T) {
 	repo := mocks.NewMockIdempotencyRepository(t)
 	repo.On("Get", mock.Anything, "test-key", "/api/v1/authorizations").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
 	repo.On("Store", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey")).Return(errors.New("failed to store"))
+	repo.On("Release", mock.Anything, "test-key", "/api/v1/authorizations", mock.Anything).Return(nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 	handler := testHandler(http.StatusOK, `{"status":"success"}`)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
@@ -253,14 +382,17 @@ func TestIdempotency_RepoStoreErrorDoesNotAffectResponse(t *testing.T) {
 
 	middleware(handler).ServeHTTP(rec, req)
 
-	// Response should still be successful even if caching failed
-	assert.Equal(t, http.StatusOK, rec.Code)
-	assert.Equal(t, `{"status":"success"}`, rec.Body.String())
+	// The key is stored in the request's transaction, so without it the request must not succeed
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"error":"internal_error"`)
+	assert.NotContains(t, rec.Body.String(), "success")
 }
 
 func TestIdempotency_AllIdempotentPaths(t *testing.T) {
 	paths := []string{
 		"/api/v1/authorizations",
+		"/api/v1/authorizations/auth_550e8400-e29b-41d4-a716-446655440000/increments",
+		"/api/v1/authorizations/auth_550e8400-e29b-41d4-a716-446655440000/reversals",
 		"/api/v1/captures",
 		"/api/v1/voids",
 		"/api/v1/refunds",
@@ -270,9 +402,10 @@ func TestIdempotency_AllIdempotentPaths(t *testing.T) {
 		t.Run(path, func(t *testing.T) {
 			repo := mocks.NewMockIdempotencyRepository(t)
 			repo.On("Get", mock.Anything, "test-key", path).Return(nil, nil)
+			repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
 			repo.On("Store", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey")).Return(nil)
 
-			middleware := Idempotency(repo, testLogger())
+			middleware := Idempotency(repo, testConfig(), testLogger())
 			handler := testHandler(http.StatusOK, `{"path":"`+path+`"}`)
 
 			req := httptest.NewRequest(http.MethodPost, path, nil)
@@ -297,7 +430,7 @@ func TestIdempotency_CachedResponseHasCorrectContentType(t *testing.T) {
 	}
 	repo.On("Get", mock.Anything, "content-type-key", "/api/v1/authorizations").Return(cached, nil)
 
-	middleware := Idempotency(repo, testLogger())
+	middleware := Idempotency(repo, testConfig(), testLogger())
 	handler := testHandler(http.StatusOK, `{"status":"success"}`)
 
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
@@ -308,3 +441,383 @@ func TestIdempotency_CachedResponseHasCorrectContentType(t *testing.T) {
 
 	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
 }
+
+func TestIdempotency_StoresRequestFingerprint(t *testing.T) {
+	body := `{"amount":1000,"card_number":"4111111111111111"}`
+
+	repo := mocks.NewMockIdempotencyRepository(t)
+	repo.On("Get", mock.Anything, "hash-key", "/api/v1/authorizations").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	repo.On("Store", mock.Anything, mock.MatchedBy(func(k *models.IdempotencyKey) bool {
+		return k.RequestHash == fingerprintRequest([]byte(body))
+	})).Return(nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	var seenBody string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body) //nolint:errcheck // test helper
+		seenBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", "hash-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, body, seenBody, "handler should still see the full request body")
+	repo.AssertExpectations(t)
+}
+
+func TestIdempotency_KeyReusedWithDifferentBody(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	cached := &models.IdempotencyKey{
+		Key:            "reused-key",
+		RequestPath:    "/api/v1/captures",
+		RequestHash:    fingerprintRequest([]byte(`{"authorization_id":"auth_1","amount":1000}`)),
+		ResponseStatus: 200,
+		ResponseBody:   `{"capture_id":"cap_1"}`,
+	}
+	repo.On("Get", mock.Anything, "reused-key", "/api/v1/captures").Return(cached, nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", strings.NewReader(`{"authorization_id":"auth_1","amount":2000}`))
+	req.Header.Set("Idempotency-Key", "reused-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.False(t, handlerCalled, "handler should not run for a reused key")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"error":"idempotency_key_reused"`)
+	assert.Empty(t, rec.Header().Get("X-Idempotent-Replayed"))
+	repo.AssertNotCalled(t, "Store")
+}
+
+func TestIdempotency_EquivalentBodyIsReplayed(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	cached := &models.IdempotencyKey{
+		Key:            "replay-key",
+		RequestPath:    "/api/v1/captures",
+		RequestHash:    fingerprintRequest([]byte(`{"authorization_id":"auth_1","amount":1000}`)),
+		ResponseStatus: 200,
+		ResponseBody:   `{"capture_id":"cap_1"}`,
+	}
+	repo.On("Get", mock.Anything, "replay-key", "/api/v1/captures").Return(cached, nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+	handler := testHandler(http.StatusOK, `{"capture_id":"cap_2"}`)
+
+	// Same payload with different key order and whitespace
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", strings.NewReader("{\n  \"amount\": 1000,\n  \"authorization_id\": \"auth_1\"\n}"))
+	req.Header.Set("Idempotency-Key", "replay-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("X-Idempotent-Replayed"))
+	assert.Equal(t, `{"capture_id":"cap_1"}`, rec.Body.String())
+}
+
+func TestFingerprintRequest(t *testing.T) {
+	t.Run("ignores key order and whitespace", func(t *testing.T) {
+		a := fingerprintRequest([]byte(`{"a":1,"b":{"c":"x","d":[1,2]}}`))
+		b := fingerprintRequest([]byte(" { \"b\" : { \"d\" : [1, 2], \"c\" : \"x\" }, \"a\" : 1 } "))
+		assert.Equal(t, a, b)
+	})
+
+	t.Run("differs when values differ", func(t *testing.T) {
+		a := fingerprintRequest([]byte(`{"amount":1000}`))
+		b := fingerprintRequest([]byte(`{"amount":1001}`))
+		assert.NotEqual(t, a, b)
+	})
+
+	t.Run("hashes non-JSON bodies as-is", func(t *testing.T) {
+		assert.Equal(t, fingerprintRequest([]byte("not json")), fingerprintRequest([]byte("not json")))
+		assert.NotEqual(t, fingerprintRequest([]byte("not json")), fingerprintRequest([]byte("not json!")))
+	})
+}
+
+func TestIdempotency_InProgressReturnsConflict(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	lockedAt := time.Now()
+	inProgress := &models.IdempotencyKey{
+		Key:         "busy-key",
+		RequestPath: "/api/v1/captures",
+		RequestHash: fingerprintRequest(nil),
+		LockedAt:    &lockedAt,
+	}
+	repo.On("Get", mock.Anything, "busy-key", "/api/v1/captures").Return(inProgress, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(false, nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	req.Header.Set("Idempotency-Key", "busy-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.False(t, handlerCalled, "handler should not run while the key is in progress")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), `"error":"request_in_progress"`)
+	repo.AssertNotCalled(t, "Store")
+	repo.AssertNotCalled(t, "Release")
+}
+
+func TestIdempotency_WaitsForInProgressRequest(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	lockedAt := time.Now()
+	inProgress := &models.IdempotencyKey{
+		Key:         "wait-key",
+		RequestPath: "/api/v1/captures",
+		RequestHash: fingerprintRequest(nil),
+		LockedAt:    &lockedAt,
+	}
+	completed := &models.IdempotencyKey{
+		Key:            "wait-key",
+		RequestPath:    "/api/v1/captures",
+		RequestHash:    fingerprintRequest(nil),
+		ResponseStatus: http.StatusOK,
+		ResponseBody:   `{"capture_id":"cap_1"}`,
+	}
+	repo.On("Get", mock.Anything, "wait-key", "/api/v1/captures").Return(inProgress, nil).Once()
+	repo.On("Get", mock.Anything, "wait-key", "/api/v1/captures").Return(completed, nil).Once()
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(false, nil).Once()
+
+	cfg := testConfig()
+	cfg.IdempotencyWaitTimeout = time.Second
+	middleware := Idempotency(repo, cfg, testLogger())
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	req.Header.Set("Idempotency-Key", "wait-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.False(t, handlerCalled, "handler should not run for a duplicate request")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("X-Idempotent-Replayed"))
+	assert.Equal(t, `{"capture_id":"cap_1"}`, rec.Body.String())
+}
+
+func TestIdempotency_KeyTakenBetweenGetAndReserve(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	completed := &models.IdempotencyKey{
+		Key:            "race-key",
+		RequestPath:    "/api/v1/authorizations",
+		ResponseStatus: http.StatusOK,
+		ResponseBody:   `{"authorization_id":"auth_1"}`,
+	}
+	repo.On("Get", mock.Anything, "race-key", "/api/v1/authorizations").Return(nil, nil).Once()
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(false, nil).Once()
+	repo.On("Get", mock.Anything, "race-key", "/api/v1/authorizations").Return(completed, nil).Once()
+
+	cfg := testConfig()
+	cfg.IdempotencyWaitTimeout = time.Second
+	middleware := Idempotency(repo, cfg, testLogger())
+	handler := testHandler(http.StatusOK, `{"authorization_id":"auth_2"}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/authorizations", nil)
+	req.Header.Set("Idempotency-Key", "race-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, `{"authorization_id":"auth_1"}`, rec.Body.String())
+	assert.Equal(t, "true", rec.Header().Get("X-Idempotent-Replayed"))
+}
+
+func TestIdempotency_StoresKeyInRequestTransaction(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	var handlerCtx context.Context
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCtx = r.Context()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"success"}`)) //nolint:errcheck // test helper
+	})
+
+	repo.On("Get", mock.Anything, "tx-key", "/api/v1/voids").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	repo.On("Store", mock.MatchedBy(func(ctx context.Context) bool {
+		// The handler's writes and the key must share the pending transaction
+		return ctx == handlerCtx
+	}), mock.AnythingOfType("*models.IdempotencyKey")).Return(nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/voids", nil)
+	req.Header.Set("Idempotency-Key", "tx-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `{"status":"success"}`, rec.Body.String())
+	repo.AssertExpectations(t)
+}
+
+// recordingTx is a pending transaction that records how it ended
+type recordingTx struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *recordingTx) Commit() error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *recordingTx) Rollback() error {
+	tx.rolledBack = true
+	return nil
+}
+
+func TestIdempotency_LostReservationRollsBackAndReplays(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+
+	completed := &models.IdempotencyKey{
+		Key:            "lost-key",
+		RequestPath:    "/api/v1/captures",
+		RequestHash:    fingerprintRequest(nil),
+		ResponseStatus: http.StatusCreated,
+		ResponseBody:   `{"capture_id":"cap_winner"}`,
+	}
+	repo.On("Get", mock.Anything, "lost-key", "/api/v1/captures").Return(nil, nil).Once()
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	// The reservation went stale and another request took the key over and finished
+	repo.On("Store", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey")).Return(models.ErrReservationLost)
+	repo.On("Get", mock.Anything, "lost-key", "/api/v1/captures").Return(completed, nil).Once()
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	tx := &recordingTx{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pending, ok := db.PendingFromContext(r.Context())
+		require.True(t, ok)
+		_, err := pending.Join(func() (db.Committer, error) { return tx, nil })
+		require.NoError(t, err)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"capture_id":"cap_loser"}`)) //nolint:errcheck // test helper
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	req.Header.Set("Idempotency-Key", "lost-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.False(t, tx.committed, "a second capture must not be committed")
+	assert.True(t, tx.rolledBack)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "true", rec.Header().Get("X-Idempotent-Replayed"))
+	assert.Equal(t, `{"capture_id":"cap_winner"}`, rec.Body.String())
+	repo.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotency_InjectedResponseNeverCached(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+	repo.On("Get", mock.Anything, "fake-ok-key", "/api/v1/captures").Return(nil, nil)
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	repo.On("Release", mock.Anything, "fake-ok-key", "/api/v1/captures", mock.Anything).Return(nil)
+
+	middleware := Idempotency(repo, testConfig(), testLogger())
+
+	// A fault that looks like a success must not become the key's answer
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		marker, ok := w.(faultMarker)
+		require.True(t, ok, "idempotency writer should accept fault marks")
+		marker.markInjected()
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"capture_id":`)) //nolint:errcheck // test helper
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	req.Header.Set("Idempotency-Key", "fake-ok-key")
+	rec := httptest.NewRecorder()
+
+	middleware(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	repo.AssertNotCalled(t, "Store")
+}
+
+func TestIdempotency_PostCommitFailureStoresResponse(t *testing.T) {
+	repo := mocks.NewMockIdempotencyRepository(t)
+	repo.On("Get", mock.Anything, "ambiguous-key", "/api/v1/captures").Return(nil, nil).Once()
+	repo.On("Reserve", mock.Anything, mock.AnythingOfType("*models.IdempotencyKey"), mock.Anything).Return(true, nil)
+	repo.On("Store", mock.Anything, mock.MatchedBy(func(k *models.IdempotencyKey) bool {
+		return k.ResponseStatus == http.StatusOK && k.ResponseBody == `{"capture_id":"cap_1"}`
+	})).Return(nil)
+
+	cfg := testConfig()
+	cfg.PostCommitFailureRate = 1
+
+	chaos := testFailureInjection(cfg)
+	middleware := Idempotency(repo, cfg, testLogger())
+
+	handlerCalled := false
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerCalled = true
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"capture_id":"cap_1"}`)) //nolint:errcheck // test helper
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	req.Header.Set("Idempotency-Key", "ambiguous-key")
+	rec := httptest.NewRecorder()
+
+	middleware(chaos(handler)).ServeHTTP(rec, req)
+
+	assert.True(t, handlerCalled, "the operation runs before the failure")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "internal_error")
+	repo.AssertExpectations(t)
+
+	// The retry is answered from the stored response, as the real outcome
+	repo.On("Get", mock.Anything, "ambiguous-key", "/api/v1/captures").Return(&models.IdempotencyKey{
+		Key:            "ambiguous-key",
+		RequestPath:    "/api/v1/captures",
+		ResponseStatus: http.StatusOK,
+		ResponseBody:   `{"capture_id":"cap_1"}`,
+	}, nil).Once()
+
+	retry := httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil)
+	retry.Header.Set("Idempotency-Key", "ambiguous-key")
+	retryRec := httptest.NewRecorder()
+
+	middleware(chaos(handler)).ServeHTTP(retryRec, retry)
+
+	assert.Equal(t, http.StatusOK, retryRec.Code)
+	assert.Equal(t, "true", retryRec.Header().Get("X-Idempotent-Replayed"))
+	assert.Equal(t, `{"capture_id":"cap_1"}`, retryRec.Body.String())
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+)
+
+// Headers a request can force its own chaos with, when cfg.ChaosHeadersEnabled is set
+const (
+	mockFaultHeader   = "X-Mock-Fault"
+	mockLatencyHeader = "X-Mock-Latency-Ms"
+)
+
+// mockFaultAliases are the X-Mock-Fault values accepted besides the fault kinds themselves
+var mockFaultAliases = map[string]string{
+	"none":             "",
+	"timeout":          config.FaultHang,
+	"500":              config.FaultError,
+	"500-after-commit": config.FaultErrorAfterCommit,
+}
+
+// forcedDecision reads the chaos a request asks for with the mock headers. forced is
+// false when the request sends neither header, so it gets the configured chaos.
+// The latency asked for, plus the hang if one is forced, must end before the request's
+// idempotency key can be taken over by its own retry.
+func forcedDecision(r *http.Request, cfg *config.AppConfig) (decision chaos.Decision, forced bool, err error) {
+	faultValue := strings.ToLower(strings.TrimSpace(r.Header.Get(mockFaultHeader)))
+	latencyValue := strings.TrimSpace(r.Header.Get(mockLatencyHeader))
+	if faultValue == "" && latencyValue == "" {
+		return chaos.Decision{}, false, nil
+	}
+
+	if faultValue != "" {
+		fault, ok := mockFaultAliases[faultValue]
+		if !ok {
+			if !slices.Contains(chaos.Kinds, faultValue) {
+				return chaos.Decision{}, false, fmt.Errorf("%s must be none, timeout, 500, 500-after-commit or a fault kind, got %q",
+					mockFaultHeader, faultValue)
+			}
+			fault = faultValue
+		}
+		decision.Fault = fault
+	}
+
+	if latencyValue != "" {
+		latencyMS, err := strconv.Atoi(latencyValue)
+		if err != nil || latencyMS < 0 {
+			return chaos.Decision{}, false, fmt.Errorf("%s must be a whole number of milliseconds, got %q",
+				mockLatencyHeader, latencyValue)
+		}
+		decision.Latency = time.Duration(latencyMS) * time.Millisecond
+
+		limit := cfg.IdempotencyLockTimeout
+		if decision.Fault == config.FaultHang {
+			limit -= cfg.FaultHangDuration
+		}
+		if decision.Latency >= limit {
+			return chaos.Decision{}, false, fmt.Errorf("%s must be below %d so the request ends before its idempotency key expires, got %q",
+				mockLatencyHeader, max(limit.Milliseconds(), 0), latencyValue)
+		}
+	}
+
+	return decision, true, nil
+}
+
+func writeInvalidChaosHeaderResponse(w http.ResponseWriter, err error) {
+	// Not the API's answer to the request, so the idempotency cache must not keep it
+	markInjected(w)
+	writeErrorResponse(w, http.StatusBadRequest, "invalid_chaos_header", err.Error())
+}
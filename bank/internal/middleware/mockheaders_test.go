@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockHeaderServer serves a handler that counts its calls behind the idempotency and
+// chaos middleware, storing idempotency keys in memory so retries are really replayed
+func mockHeaderServer(t *testing.T, configure func(cfg *config.AppConfig)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	cfg := testConfig()
+	cfg.ChaosHeadersEnabled = true
+	cfg.FaultHangDuration = 10 * time.Second
+	if configure != nil {
+		configure(cfg)
+	}
+
+	calls := &atomic.Int32{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"capture_id":"cap_1"}`)) //nolint:errcheck // test helper
+	})
+
+	store := memory.NewStore()
+	srv := httptest.NewServer(Idempotency(store.IdempotencyKeys(), cfg, testLogger())(testFailureInjection(cfg)(handler)))
+	t.Cleanup(srv.Close)
+	return srv, calls
+}
+
+func postCaptureWithHeaders(t *testing.T, client *http.Client, url string, headers map[string]string) (*http.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodPost, url+"/api/v1/captures", strings.NewReader(`{}`))
+	require.NoError(t, err)
+	req.Header.Set("Idempotency-Key", "mock-key")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return client.Do(req)
+}
+
+func TestFailureInjection_MockHeadersIgnoredWhenDisabled(t *testing.T) {
+	srv, calls := mockHeaderServer(t, func(cfg *config.AppConfig) {
+		cfg.ChaosHeadersEnabled = false
+	})
+
+	resp, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, map[string]string{mockFaultHeader: "500"})
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck // test cleanup
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFailureInjection_MockFaultBeforeCommitIsRetried(t *testing.T) {
+	srv, calls := mockHeaderServer(t, nil)
+
+	resp, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, map[string]string{mockFaultHeader: "500"})
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck // test cleanup
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(0), calls.Load())
+
+	retry, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, nil)
+	require.NoError(t, err)
+	retry.Body.Close() //nolint:errcheck // test cleanup
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Empty(t, retry.Header.Get("X-Idempotent-Replayed"), "the failed attempt was not stored")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFailureInjection_MockFaultAfterCommitIsReplayed(t *testing.T) {
+	srv, calls := mockHeaderServer(t, nil)
+	headers := map[string]string{mockFaultHeader: "500-after-commit"}
+
+	resp, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, headers)
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck // test cleanup
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, int32(1), calls.Load(), "the operation ran")
+
+	// A retry sending the same headers is a replay: the fault already happened
+	retry, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, headers)
+	require.NoError(t, err)
+	retry.Body.Close() //nolint:errcheck // test cleanup
+	assert.Equal(t, http.StatusOK, retry.StatusCode)
+	assert.Equal(t, "true", retry.Header.Get("X-Idempotent-Replayed"))
+	assert.Equal(t, int32(1), calls.Load(), "the retry did not run the operation again")
+}
+
+func TestFailureInjection_MockTimeout(t *testing.T) {
+	srv, calls := mockHeaderServer(t, nil)
+	client := srv.Client()
+	client.Timeout = 50 * time.Millisecond
+
+	_, err := postCaptureWithHeaders(t, client, srv.URL, map[string]string{mockFaultHeader: "timeout"}) //nolint:bodyclose // no response
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+
+	client.Timeout = time.Second
+	require.Eventually(t, func() bool {
+		retry, err := postCaptureWithHeaders(t, client, srv.URL, nil)
+		if err != nil {
+			return false
+		}
+		retry.Body.Close() //nolint:errcheck // test cleanup
+		return retry.StatusCode == http.StatusOK
+	}, time.Second, 20*time.Millisecond, "the retry runs once the timed out request gives up the key")
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFailureInjection_MockLatencyEndsWithTheClient(t *testing.T) {
+	srv, calls := mockHeaderServer(t, nil)
+	client := srv.Client()
+	client.Timeout = 50 * time.Millisecond
+
+	_, err := postCaptureWithHeaders(t, client, srv.URL, map[string]string{mockLatencyHeader: "20000"}) //nolint:bodyclose // no response
+	var netErr net.Error
+	require.ErrorAs(t, err, &netErr)
+	assert.True(t, netErr.Timeout())
+
+	client.Timeout = time.Second
+	require.Eventually(t, func() bool {
+		retry, err := postCaptureWithHeaders(t, client, srv.URL, nil)
+		if err != nil {
+			return false
+		}
+		retry.Body.Close() //nolint:errcheck // test cleanup
+		return retry.StatusCode == http.StatusOK
+	}, time.Second, 20*time.Millisecond, "the delayed request stops once its client is gone")
+	assert.Equal(t, int32(1), calls.Load(), "the abandoned request never ran")
+}
+
+func TestFailureInjection_MockHeadersOverrideConfiguredChaos(t *testing.T) {
+	srv, calls := mockHeaderServer(t, func(cfg *config.AppConfig) {
+		cfg.FailureRate = 1
+	})
+
+	start := time.Now()
+	resp, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, map[string]string{
+		mockFaultHeader:   "none",
+		mockLatencyHeader: "50",
+	})
+	require.NoError(t, err)
+	resp.Body.Close() //nolint:errcheck // test cleanup
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestFailureInjection_InvalidMockHeader(t *testing.T) {
+	tests := []struct {
+		headers map[string]string
+		name    string
+	}{
+		{name: "unknown fault", headers: map[string]string{mockFaultHeader: "explode"}},
+		{name: "negative latency", headers: map[string]string{mockLatencyHeader: "-5"}},
+		{name: "latency with a unit", headers: map[string]string{mockLatencyHeader: "3s"}},
+		{name: "latency past the lock timeout", headers: map[string]string{mockLatencyHeader: "30000"}},
+		{name: "hang past the lock timeout", headers: map[string]string{mockFaultHeader: "timeout", mockLatencyHeader: "20000"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := mockHeaderServer(t, nil)
+
+			resp, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, tt.headers)
+			require.NoError(t, err)
+			defer resp.Body.Close() //nolint:errcheck // test cleanup
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			var body errorResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "invalid_chaos_header", body.Error)
+
+			retry, err := postCaptureWithHeaders(t, srv.Client(), srv.URL, nil)
+			require.NoError(t, err)
+			retry.Body.Close() //nolint:errcheck // test cleanup
+			assert.Equal(t, http.StatusOK, retry.StatusCode, "the 400 was not stored for the key")
+			assert.Equal(t, int32(1), calls.Load())
+		})
+	}
+}
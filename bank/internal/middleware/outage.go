@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
+)
+
+// Maintenance creates middleware that answers every API call with 503 while the
+// schedule is in a maintenance window, with a Retry-After of the time left in it.
+// Health, docs and admin requests are still served.
+func Maintenance(schedule *outage.Schedule, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isExcludedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			status := schedule.Status()
+			if status.Mode != config.OutageMaintenance {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger.Info("refusing request during maintenance",
+				"until", status.Until,
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(status.Remaining))))
+			writeErrorResponse(w, http.StatusServiceUnavailable, "maintenance",
+				"bank is down for scheduled maintenance until "+status.Until.UTC().Format(time.RFC3339))
+		})
+	}
+}
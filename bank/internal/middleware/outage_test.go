@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/chaos"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/outage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenance(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		windows     []config.OutageWindow
+		wantStatus  int
+		maintenance bool
+	}{
+		{
+			name:        "api request during maintenance",
+			path:        "/api/v1/transactions",
+			windows:     []config.OutageWindow{{Mode: config.OutageMaintenance, Duration: 90 * time.Second}},
+			wantStatus:  http.StatusServiceUnavailable,
+			maintenance: true,
+		},
+		{
+			name:       "health during maintenance",
+			path:       "/health",
+			windows:    []config.OutageWindow{{Mode: config.OutageMaintenance, Duration: 90 * time.Second}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "admin during maintenance",
+			path:       "/admin/chaos",
+			windows:    []config.OutageWindow{{Mode: config.OutageMaintenance, Duration: 90 * time.Second}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "before the window",
+			path:       "/api/v1/transactions",
+			windows:    []config.OutageWindow{{Mode: config.OutageMaintenance, After: time.Hour, Duration: 90 * time.Second}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "degraded window",
+			path:       "/api/v1/transactions",
+			windows:    []config.OutageWindow{{Mode: config.OutageDegraded, Duration: 90 * time.Second}},
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Maintenance(outage.NewSchedule(tt.windows, clock.System), testLogger())(testHandler(http.StatusOK, `{"status":"ok"}`))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if !tt.maintenance {
+				assert.Empty(t, rec.Header().Get("Retry-After"))
+				return
+			}
+			assert.Equal(t, "90", rec.Header().Get("Retry-After"))
+			var body errorResponse
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+			assert.Equal(t, "maintenance", body.Error)
+			assert.Contains(t, body.Message, "scheduled maintenance until")
+		})
+	}
+}
+
+func TestFailureInjection_Degraded(t *testing.T) {
+	cfg := testConfig()
+	cfg.DegradedFailureRate = 1
+	cfg.ChaosScript = []config.FaultStep{{Request: 1, Fault: config.FaultBadGateway}}
+	controller := chaos.NewController(chaos.FromConfig(cfg), chaos.LimitsFromConfig(cfg))
+
+	serve := func(windows ...config.OutageWindow) int {
+		handler := FailureInjection(controller, outage.NewSchedule(windows, clock.System), cfg, testLogger())(testHandler(http.StatusOK, `{"status":"ok"}`))
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/transactions", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, serve(config.OutageWindow{Mode: config.OutageDegraded, Duration: time.Hour}),
+		"the degraded failure rate applies instead of the script")
+	assert.Equal(t, http.StatusBadGateway, serve(config.OutageWindow{Mode: config.OutageDegraded, After: time.Hour, Duration: time.Hour}),
+		"outside the window the script applies")
+	assert.Equal(t, http.StatusOK, serve())
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/ratelimit"
+)
+
+// apiKeyHeader identifies a client for rate limiting; clients without one are told apart by IP
+const apiKeyHeader = "X-API-Key"
+
+// RateLimiting creates middleware that answers 429 with Retry-After once a client
+// exceeds its limit. Limited requests also get RateLimit-Limit, RateLimit-Remaining
+// and RateLimit-Reset headers so a client can slow down before it is refused.
+func RateLimiting(limiter *ratelimit.Limiter, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isExcludedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client := clientID(r)
+			decision := limiter.Allow(client, r.Method, r.URL.Path)
+			if decision.Limit > 0 {
+				w.Header().Set("RateLimit-Limit", strconv.Itoa(decision.Limit))
+				w.Header().Set("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+				w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+			}
+			if decision.Allowed {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger.Info("rate limited request",
+				"client", client,
+				"path", r.URL.Path,
+				"method", r.Method,
+			)
+			w.Header().Set("Retry-After", strconv.Itoa(max(1, ceilSeconds(decision.RetryAfter))))
+			writeErrorResponse(w, http.StatusTooManyRequests, "rate_limited", "rate limit exceeded, retry after the time in Retry-After")
+		})
+	}
+}
+
+// clientID names the client a request counts against: its API key, or else its IP
+func clientID(r *http.Request) string {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return "key:" + key
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
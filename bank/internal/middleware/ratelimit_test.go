@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiting(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Settings{
+		Default: ratelimit.Limit{RequestsPerSecond: 0.5, Burst: 2},
+	}, clock.System)
+	handler := RateLimiting(limiter, testLogger())(testHandler(http.StatusOK, `{"status":"ok"}`))
+
+	serve := func(path, apiKey, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		req.RemoteAddr = remoteAddr
+		if apiKey != "" {
+			req.Header.Set("X-API-Key", apiKey)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := serve("/api/v1/captures", "", "10.0.0.1:5000")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "2", first.Header().Get("RateLimit-Reset"))
+
+	assert.Equal(t, http.StatusOK, serve("/api/v1/captures", "", "10.0.0.1:5001").Code, "same IP from another port")
+
+	limited := serve("/api/v1/captures", "", "10.0.0.1:5000")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "2", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get("RateLimit-Remaining"))
+	var body errorResponse
+	require.NoError(t, json.NewDecoder(limited.Body).Decode(&body))
+	assert.Equal(t, "rate_limited", body.Error)
+
+	assert.Equal(t, http.StatusOK, serve("/api/v1/captures", "merchant-1", "10.0.0.1:5000").Code, "an API key is a client of its own")
+	assert.Equal(t, http.StatusOK, serve("/api/v1/captures", "", "10.0.0.2:5000").Code, "another IP is a client of its own")
+
+	for _, path := range []string{"/health", "/admin/rate-limits"} {
+		rec := serve(path, "", "10.0.0.1:5000")
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"), path)
+	}
+}
+
+func TestRateLimiting_NoLimit(t *testing.T) {
+	limiter := ratelimit.NewLimiter(ratelimit.Settings{}, clock.System)
+	handler := RateLimiting(limiter, testLogger())(testHandler(http.StatusOK, `{"status":"ok"}`))
+
+	for range 20 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/captures", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("RateLimit-Limit"))
+	}
+}
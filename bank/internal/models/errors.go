@@ -9,4 +9,8 @@ var (
 
 	// ErrNotFound indicates the requested entity was not found
 	ErrNotFound = errors.New("not found")
+
+	// ErrReservationLost indicates an idempotency key reservation went stale and was taken
+	// over by another request, so the caller no longer holds the key
+	ErrReservationLost = errors.New("idempotency key reservation lost")
 )
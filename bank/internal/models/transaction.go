@@ -11,10 +11,12 @@ type TransactionType string
 
 // Transaction type constants
 const (
-	TransactionTypeAuthHold TransactionType = "AUTH_HOLD" // Authorization hold (funds reserved)
-	TransactionTypeCapture  TransactionType = "CAPTURE"   // Capture authorized funds
-	TransactionTypeVoid     TransactionType = "VOID"      // Void/cancel authorization
-	TransactionTypeRefund   TransactionType = "REFUND"    // Refund captured funds
+	TransactionTypeAuthHold      TransactionType = "AUTH_HOLD"      // Authorization hold (funds reserved)
+	TransactionTypeAuthIncrement TransactionType = "AUTH_INCREMENT" // Increase of an existing authorization hold
+	TransactionTypeAuthReversal  TransactionType = "AUTH_REVERSAL"  // Partial release of an authorization hold
+	TransactionTypeCapture       TransactionType = "CAPTURE"        // Capture authorized funds
+	TransactionTypeVoid          TransactionType = "VOID"           // Void/cancel authorization
+	TransactionTypeRefund        TransactionType = "REFUND"         // Refund captured funds
 )
 
 // TransactionStatus represents the status of a transaction
@@ -29,23 +31,49 @@ const (
 
 // Transaction represents a ledger entry for account activity
 type Transaction struct {
-	CreatedAt   time.Time         `db:"created_at"`
-	Metadata    map[string]any    `db:"metadata"`
-	ReferenceID *uuid.UUID        `db:"reference_id"`
-	ExpiresAt   *time.Time        `db:"expires_at"`
-	Currency    string            `db:"currency"`
-	Type        TransactionType   `db:"type"`
-	Status      TransactionStatus `db:"status"`
-	AmountCents int64             `db:"amount_cents"`
-	ID          uuid.UUID         `db:"id"`
-	AccountID   uuid.UUID         `db:"account_id"`
+	CreatedAt           time.Time         `db:"created_at"`
+	Metadata            map[string]string `db:"metadata"` // Merchant-supplied key/value pairs
+	ReferenceID         *uuid.UUID        `db:"reference_id"`
+	ExpiresAt           *time.Time        `db:"expires_at"`
+	Currency            string            `db:"currency"`
+	Type                TransactionType   `db:"type"`
+	Status              TransactionStatus `db:"status"`
+	AmountCents         int64             `db:"amount_cents"`
+	CapturedAmountCents int64             `db:"captured_amount_cents"` // Running capture total (auth holds)
+	RefundedAmountCents int64             `db:"refunded_amount_cents"` // Running refund total (captures)
+	ReversedAmountCents int64             `db:"reversed_amount_cents"` // Running partial reversal total (auth holds)
+	ID                  uuid.UUID         `db:"id"`
+	AccountID           uuid.UUID         `db:"account_id"`
+}
+
+// RemainingAmountCents returns the part of an authorization hold that is still
+// reserved and capturable, i.e. neither captured nor reversed.
+// Holds that are no longer active have nothing remaining.
+func (t *Transaction) RemainingAmountCents() int64 {
+	if t.Status != TransactionStatusActive {
+		return 0
+	}
+	return t.AmountCents - t.CapturedAmountCents - t.ReversedAmountCents
+}
+
+// RefundableAmountCents returns the part of a capture that has not been refunded yet
+func (t *Transaction) RefundableAmountCents() int64 {
+	return t.AmountCents - t.RefundedAmountCents
 }
 
 // IdempotencyKey tracks processed requests to prevent duplicate transactions
 type IdempotencyKey struct {
-	CreatedAt      time.Time `db:"created_at"`
-	Key            string    `db:"key"`
-	RequestPath    string    `db:"request_path"`
-	ResponseBody   string    `db:"response_body"`
-	ResponseStatus int       `db:"response_status"`
+	CreatedAt      time.Time  `db:"created_at"`
+	LockedAt       *time.Time `db:"locked_at"` // Set while the original request is still being processed
+	Key            string     `db:"key"`
+	RequestPath    string     `db:"request_path"`
+	RequestHash    string     `db:"request_hash"` // SHA-256 of the canonical request body; empty for keys stored before hashing
+	ResponseBody   string     `db:"response_body"`
+	LockToken      string     `db:"lock_token"` // Identifies the reservation while in progress; set by Reserve
+	ResponseStatus int        `db:"response_status"`
+}
+
+// InProgress reports whether the key is reserved by a request that has not stored its response yet
+func (k *IdempotencyKey) InProgress() bool {
+	return k.LockedAt != nil
 }
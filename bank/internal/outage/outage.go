@@ -0,0 +1,112 @@
+// Package outage tells whether the bank is in a scheduled maintenance or degraded window.
+package outage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+)
+
+// Status is the outage the bank is in right now
+type Status struct {
+	// Until is when the window ends; zero outside any window
+	Until time.Time
+	// Mode is config.OutageMaintenance, config.OutageDegraded, or "" outside any window
+	Mode string
+	// Remaining is how long until the window ends
+	Remaining time.Duration
+}
+
+// Schedule holds the outage windows, timed from when it was created. It is safe for
+// concurrent use.
+type Schedule struct {
+	clock   clock.Clock
+	started time.Time
+	windows []config.OutageWindow
+	// scans remembers, for each cron window, how far its firings have been checked
+	scans []cronScan
+	mu    sync.Mutex
+}
+
+// cronScan is how far a cron window's firings have been checked, so each minute is
+// matched once rather than on every Status call
+type cronScan struct {
+	// through is the last minute checked; zero before the first check
+	through time.Time
+	// lastStart is the latest firing at or before through, if still recent enough to matter
+	lastStart time.Time
+}
+
+// NewSchedule returns a Schedule of windows, whose relative starts count from now
+func NewSchedule(windows []config.OutageWindow, clk clock.Clock) *Schedule {
+	return &Schedule{
+		clock:   clk,
+		started: clk.Now(),
+		windows: windows,
+		scans:   make([]cronScan, len(windows)),
+	}
+}
+
+// Status returns the outage in effect. When windows overlap, maintenance wins over
+// degraded, and of two windows in the same mode the one that ends later.
+func (s *Schedule) Status() Status {
+	now := s.clock.Now()
+	var status Status
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, window := range s.windows {
+		end, active := s.activeUntil(i, window, now)
+		if !active {
+			continue
+		}
+		if severity(window.Mode) > severity(status.Mode) ||
+			(window.Mode == status.Mode && end.After(status.Until)) {
+			status = Status{Mode: window.Mode, Until: end, Remaining: end.Sub(now)}
+		}
+	}
+	return status
+}
+
+// activeUntil reports whether the i-th window, window, covers now, and if so when it ends.
+// The caller holds s.mu.
+func (s *Schedule) activeUntil(i int, window config.OutageWindow, now time.Time) (time.Time, bool) {
+	if window.Cron == nil {
+		start := s.started.Add(window.After)
+		end := start.Add(window.Duration)
+		return end, !now.Before(start) && now.Before(end)
+	}
+
+	// Only a firing less than window.Duration ago can cover now. Check the minutes since
+	// the last call, or the whole span if that was longer ago or the clock went back.
+	scan := &s.scans[i]
+	minute := now.UTC().Truncate(time.Minute)
+	from := scan.through.Add(time.Minute)
+	if scan.through.IsZero() || minute.Before(scan.through) || minute.Sub(scan.through) > window.Duration {
+		from = minute.Add(-window.Duration)
+		scan.lastStart = time.Time{}
+	}
+	for t := from; !t.After(minute); t = t.Add(time.Minute) {
+		if window.Cron.Matches(t) {
+			scan.lastStart = t
+		}
+	}
+	scan.through = minute
+
+	if scan.lastStart.IsZero() || now.Sub(scan.lastStart) >= window.Duration {
+		return time.Time{}, false
+	}
+	return scan.lastStart.Add(window.Duration), true
+}
+
+func severity(mode string) int {
+	switch mode {
+	case config.OutageMaintenance:
+		return 2
+	case config.OutageDegraded:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,138 @@
+package outage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/cron"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func mustCron(t *testing.T, expr string) *cron.Schedule {
+	t.Helper()
+	schedule, err := cron.Parse(expr)
+	require.NoError(t, err)
+	return schedule
+}
+
+func TestSchedule_RelativeWindow(t *testing.T) {
+	clk := newFakeClock()
+	started := clk.now
+	schedule := NewSchedule([]config.OutageWindow{
+		{Mode: config.OutageMaintenance, After: 5 * time.Minute, Duration: 10 * time.Minute},
+	}, clk)
+
+	assert.Equal(t, Status{}, schedule.Status(), "before the window")
+
+	clk.now = started.Add(5 * time.Minute)
+	assert.Equal(t, Status{
+		Mode:      config.OutageMaintenance,
+		Until:     started.Add(15 * time.Minute),
+		Remaining: 10 * time.Minute,
+	}, schedule.Status())
+
+	clk.now = started.Add(14*time.Minute + 59*time.Second)
+	assert.Equal(t, time.Second, schedule.Status().Remaining)
+
+	clk.now = started.Add(15 * time.Minute)
+	assert.Equal(t, Status{}, schedule.Status(), "the window is over")
+}
+
+func TestSchedule_CronWindow(t *testing.T) {
+	clk := newFakeClock()
+	schedule := NewSchedule([]config.OutageWindow{
+		{Mode: config.OutageDegraded, Cron: mustCron(t, "*/15 * * * *"), Duration: 2 * time.Minute},
+	}, clk)
+
+	status := schedule.Status()
+	assert.Equal(t, config.OutageDegraded, status.Mode, "12:00 starts a window")
+	assert.Equal(t, time.Date(2026, 1, 1, 12, 2, 0, 0, time.UTC), status.Until)
+
+	clk.now = clk.now.Add(90 * time.Second)
+	assert.Equal(t, 30*time.Second, schedule.Status().Remaining)
+
+	clk.now = clk.now.Add(30 * time.Second)
+	assert.Empty(t, schedule.Status().Mode, "12:02 is after the window")
+
+	clk.now = time.Date(2026, 1, 1, 12, 16, 59, 0, time.UTC)
+	assert.Equal(t, config.OutageDegraded, schedule.Status().Mode, "the window recurs")
+}
+
+func TestSchedule_LongCronWindowAcrossCalls(t *testing.T) {
+	at := func(day, hour, minute, second int) time.Time {
+		return time.Date(2026, 1, day, hour, minute, second, 0, time.UTC)
+	}
+	clk := &fakeClock{now: at(1, 8, 59, 0)}
+	schedule := NewSchedule([]config.OutageWindow{
+		{Mode: config.OutageDegraded, Cron: mustCron(t, "0 9 * * *"), Duration: 8 * time.Hour},
+	}, clk)
+
+	steps := []struct {
+		now   time.Time
+		until time.Time
+	}{
+		{now: at(1, 8, 59, 0)},
+		{now: at(1, 9, 0, 0), until: at(1, 17, 0, 0)},
+		{now: at(1, 9, 0, 30), until: at(1, 17, 0, 0)},
+		{now: at(1, 12, 0, 0), until: at(1, 17, 0, 0)},
+		{now: at(1, 16, 59, 59), until: at(1, 17, 0, 0)},
+		{now: at(1, 17, 0, 0)},
+		// The clock going back finds the firing again
+		{now: at(1, 10, 0, 0), until: at(1, 17, 0, 0)},
+		// A gap longer than the window does not carry the old firing over
+		{now: at(2, 8, 0, 0)},
+		{now: at(2, 9, 30, 0), until: at(2, 17, 0, 0)},
+	}
+	for _, step := range steps {
+		clk.now = step.now
+		status := schedule.Status()
+		assert.Equal(t, step.until, status.Until, "at %s", step.now)
+		assert.Equal(t, !step.until.IsZero(), status.Mode == config.OutageDegraded, "at %s", step.now)
+	}
+}
+
+func TestSchedule_CronIsUTC(t *testing.T) {
+	clk := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC).In(time.FixedZone("UTC+5", 5*60*60))}
+	schedule := NewSchedule([]config.OutageWindow{
+		{Mode: config.OutageMaintenance, Cron: mustCron(t, "0 12 * * *"), Duration: time.Minute},
+	}, clk)
+
+	assert.Equal(t, config.OutageMaintenance, schedule.Status().Mode)
+}
+
+func TestSchedule_OverlappingWindows(t *testing.T) {
+	clk := newFakeClock()
+	started := clk.now
+	schedule := NewSchedule([]config.OutageWindow{
+		{Mode: config.OutageDegraded, Duration: time.Hour},
+		{Mode: config.OutageMaintenance, After: 10 * time.Minute, Duration: 5 * time.Minute},
+		{Mode: config.OutageMaintenance, After: 12 * time.Minute, Duration: 5 * time.Minute},
+	}, clk)
+
+	assert.Equal(t, config.OutageDegraded, schedule.Status().Mode)
+
+	clk.now = started.Add(13 * time.Minute)
+	status := schedule.Status()
+	assert.Equal(t, config.OutageMaintenance, status.Mode, "maintenance wins over degraded")
+	assert.Equal(t, started.Add(17*time.Minute), status.Until, "the later end wins")
+
+	clk.now = started.Add(20 * time.Minute)
+	assert.Equal(t, config.OutageDegraded, schedule.Status().Mode)
+}
+
+func TestSchedule_NoWindows(t *testing.T) {
+	assert.Equal(t, Status{}, NewSchedule(nil, newFakeClock()).Status())
+}
@@ -0,0 +1,257 @@
+// Package ratelimit holds the per-client request limits, which can be changed while the bank runs.
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/config"
+	"github.com/benx421/payment-gateway/bank/internal/reload"
+)
+
+// maxBuckets is how many client buckets are kept. Past it full ones, which limit nothing,
+// are dropped, and clients that still find no room share one bucket per route.
+const maxBuckets = 10000
+
+// Limit is a token bucket: it refills at RequestsPerSecond and holds up to Burst
+// requests. A zero RequestsPerSecond means no limit.
+type Limit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// Rule applies its Limit instead of the default to the requests it matches
+type Rule struct {
+	// Method is the HTTP method to match; empty matches any
+	Method string
+	// Path is a path.Match pattern, e.g. /api/v1/captures or /api/v1/authorizations/*/increments
+	Path string
+	Limit
+}
+
+// Settings is the complete rate limit configuration
+type Settings struct {
+	// Routes are tried in order; the first match wins over Default. Each route has
+	// its own bucket per client, and Default one more shared by all other requests.
+	Routes  []Rule
+	Default Limit
+}
+
+// FromConfig returns the limits set by RATE_LIMIT_RPS, RATE_LIMIT_BURST and RATE_LIMIT_ENDPOINTS
+func FromConfig(cfg *config.RateLimitConfig) Settings {
+	routes := make([]Rule, len(cfg.Endpoints))
+	for i, endpoint := range cfg.Endpoints {
+		routes[i] = Rule{
+			Method: endpoint.Method,
+			Path:   endpoint.Path,
+			Limit:  Limit{RequestsPerSecond: endpoint.RequestsPerSecond, Burst: endpoint.Burst},
+		}
+	}
+
+	return Settings{
+		Routes:  routes,
+		Default: Limit{RequestsPerSecond: cfg.RequestsPerSecond, Burst: cfg.Burst},
+	}
+}
+
+// Validate checks every limit is usable and every route can match a request
+func (s Settings) Validate() error {
+	if err := s.Default.validate(); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+
+	for i, rule := range s.Routes {
+		if !strings.HasPrefix(rule.Path, "/") {
+			return fmt.Errorf("route %d: path must start with /, got %q", i, rule.Path)
+		}
+		if _, err := path.Match(rule.Path, ""); err != nil {
+			return fmt.Errorf("route %d: invalid path pattern %q", i, rule.Path)
+		}
+		if rule.Method != "" && !slices.Contains(methods, rule.Method) {
+			return fmt.Errorf("route %d: invalid method %q", i, rule.Method)
+		}
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("route %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+var methods = []string{
+	http.MethodGet,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+func (l Limit) validate() error {
+	if l.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests per second cannot be negative, got %f", l.RequestsPerSecond)
+	}
+	if l.RequestsPerSecond > 0 && l.Burst < 1 {
+		return fmt.Errorf("burst must be at least 1, got %d", l.Burst)
+	}
+	return nil
+}
+
+// Decision is the outcome of counting a request against its limit
+type Decision struct {
+	// Reset is how long until the bucket is full again
+	Reset time.Duration
+	// RetryAfter is how long until the next request would be allowed; zero when Allowed
+	RetryAfter time.Duration
+	// Limit is the bucket's size; zero when no limit applies
+	Limit     int
+	Remaining int
+	Allowed   bool
+}
+
+// Limiter counts each client's requests in token buckets. It is safe for concurrent
+// use; an admin changing the limits gives every client a full bucket again.
+type Limiter struct {
+	clock  clock.Clock
+	limits *reload.Holder[Settings, state]
+}
+
+// state is the buckets counted under one set of limits. The settings are kept with
+// them, as a bucket's route index only means something under the limits it was made for.
+type state struct {
+	buckets  map[bucketKey]*bucket
+	settings Settings
+	mu       sync.Mutex
+}
+
+type bucketKey struct {
+	client string
+	// route is the index of the matching rule, or -1 for the default
+	route int
+	// shared marks the overflow bucket of a route, used by every client without one of its own
+	shared bool
+}
+
+type bucket struct {
+	updated time.Time
+	tokens  float64
+}
+
+func newState(settings Settings) *state {
+	return &state{
+		settings: settings.clone(),
+		buckets:  map[bucketKey]*bucket{},
+	}
+}
+
+// NewLimiter returns a Limiter starting with initial, which must be valid
+func NewLimiter(initial Settings, clk clock.Clock) *Limiter {
+	return &Limiter{clock: clk, limits: reload.NewHolder(initial.clone(), newState)}
+}
+
+// Settings returns a copy of the limits in force
+func (l *Limiter) Settings() Settings {
+	return l.limits.Current().settings.clone()
+}
+
+// Update validates settings and enforces them from the next request, with every bucket full
+func (l *Limiter) Update(settings Settings) error {
+	return l.limits.Update(settings)
+}
+
+// Reset goes back to the limits the bank started with, with every bucket full, and returns them
+func (l *Limiter) Reset() Settings {
+	return l.limits.Reset().settings.clone()
+}
+
+// Allow counts a request from client against the limit for its method and path
+func (l *Limiter) Allow(client, method, requestPath string) Decision {
+	s := l.limits.Current()
+	key := bucketKey{client: client, route: -1}
+	limit := s.settings.Default
+	for i, rule := range s.settings.Routes {
+		if rule.Method != "" && rule.Method != method {
+			continue
+		}
+		if matched, _ := path.Match(rule.Path, requestPath); matched {
+			key.route = i
+			limit = rule.Limit
+			break
+		}
+	}
+	if limit.RequestsPerSecond <= 0 {
+		return Decision{Allowed: true}
+	}
+
+	now := l.clock.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= maxBuckets {
+			s.prune(now)
+		}
+		if len(s.buckets) >= maxBuckets {
+			// Every tracked client is mid-burst; a new one must not get a fresh bucket,
+			// or the map would grow without bound
+			key = bucketKey{route: key.route, shared: true}
+			b, ok = s.buckets[key]
+		}
+		if !ok {
+			b = &bucket{tokens: float64(limit.Burst), updated: now}
+			s.buckets[key] = b
+		}
+	}
+	b.refill(limit, now)
+
+	decision := Decision{Limit: limit.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - b.tokens) / limit.RequestsPerSecond)
+	}
+	decision.Remaining = int(math.Floor(b.tokens))
+	decision.Reset = seconds((float64(limit.Burst) - b.tokens) / limit.RequestsPerSecond)
+	return decision
+}
+
+func (b *bucket) refill(limit Limit, now time.Time) {
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed.Seconds()*limit.RequestsPerSecond)
+	}
+	b.updated = now
+}
+
+// prune drops the buckets that have refilled completely, which are the same as new ones
+func (s *state) prune(now time.Time) {
+	for key, b := range s.buckets {
+		limit := s.settings.Default
+		if key.route >= 0 {
+			limit = s.settings.Routes[key.route].Limit
+		}
+		b.refill(limit, now)
+		if b.tokens >= float64(limit.Burst) {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// clone copies the routes, so a caller editing its slice cannot change the limits in force
+func (s Settings) clone() Settings {
+	return Settings{
+		Routes:  slices.Clone(s.Routes),
+		Default: s.Default,
+	}
+}
@@ -0,0 +1,193 @@
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+}
+
+func TestSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		settings Settings
+	}{
+		{
+			name: "valid",
+			settings: Settings{
+				Routes:  []Rule{{Method: http.MethodPost, Path: "/api/v1/captures", Limit: Limit{RequestsPerSecond: 1, Burst: 1}}},
+				Default: Limit{RequestsPerSecond: 10, Burst: 20},
+			},
+		},
+		{name: "no limit", settings: Settings{}},
+		{name: "negative rate", settings: Settings{Default: Limit{RequestsPerSecond: -1}}, wantErr: "default: requests per second cannot be negative"},
+		{name: "rate without burst", settings: Settings{Default: Limit{RequestsPerSecond: 5}}, wantErr: "default: burst must be at least 1"},
+		{name: "relative path", settings: Settings{Routes: []Rule{{Path: "api/v1/captures"}}}, wantErr: "route 0: path must start with /"},
+		{name: "bad pattern", settings: Settings{Routes: []Rule{{Path: "/api/v1/[captures"}}}, wantErr: "route 0: invalid path pattern"},
+		{name: "bad method", settings: Settings{Routes: []Rule{{Method: "FETCH", Path: "/api/v1/captures"}}}, wantErr: `route 0: invalid method "FETCH"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLimiter_TokenBucket(t *testing.T) {
+	clk := newFakeClock()
+	limiter := NewLimiter(Settings{Default: Limit{RequestsPerSecond: 2, Burst: 3}}, clk)
+
+	for i := range 3 {
+		decision := limiter.Allow("ip:10.0.0.1", http.MethodPost, "/api/v1/captures")
+		require.True(t, decision.Allowed, "request %d is within the burst", i+1)
+		assert.Equal(t, 3, decision.Limit)
+		assert.Equal(t, 2-i, decision.Remaining)
+	}
+
+	refused := limiter.Allow("ip:10.0.0.1", http.MethodPost, "/api/v1/captures")
+	assert.False(t, refused.Allowed)
+	assert.Equal(t, 0, refused.Remaining)
+	assert.Equal(t, 500*time.Millisecond, refused.RetryAfter)
+	assert.Equal(t, 1500*time.Millisecond, refused.Reset)
+
+	clk.now = clk.now.Add(500 * time.Millisecond)
+	assert.True(t, limiter.Allow("ip:10.0.0.1", http.MethodPost, "/api/v1/captures").Allowed, "one token refilled")
+	assert.False(t, limiter.Allow("ip:10.0.0.1", http.MethodPost, "/api/v1/captures").Allowed)
+
+	clk.now = clk.now.Add(time.Hour)
+	assert.Equal(t, 2, limiter.Allow("ip:10.0.0.1", http.MethodPost, "/api/v1/captures").Remaining, "the bucket never holds more than the burst")
+}
+
+func TestLimiter_BucketsPerClientAndRoute(t *testing.T) {
+	limiter := NewLimiter(Settings{
+		Routes: []Rule{
+			{Method: http.MethodPost, Path: "/api/v1/captures", Limit: Limit{RequestsPerSecond: 1, Burst: 1}},
+			{Path: "/api/v1/transactions"},
+		},
+		Default: Limit{RequestsPerSecond: 1, Burst: 1},
+	}, newFakeClock())
+
+	assert.True(t, limiter.Allow("key:a", http.MethodPost, "/api/v1/captures").Allowed)
+	assert.False(t, limiter.Allow("key:a", http.MethodPost, "/api/v1/captures").Allowed)
+	assert.True(t, limiter.Allow("key:b", http.MethodPost, "/api/v1/captures").Allowed, "each client has its own bucket")
+
+	assert.True(t, limiter.Allow("key:a", http.MethodPost, "/api/v1/voids").Allowed, "the route has its own bucket")
+	assert.False(t, limiter.Allow("key:a", http.MethodGet, "/api/v1/captures/cap_1").Allowed, "other requests share the default bucket")
+
+	for range 5 {
+		decision := limiter.Allow("key:a", http.MethodGet, "/api/v1/transactions")
+		assert.True(t, decision.Allowed, "a zero rate is no limit")
+		assert.Zero(t, decision.Limit)
+	}
+}
+
+func TestLimiter_UpdateAndReset(t *testing.T) {
+	initial := Settings{Default: Limit{RequestsPerSecond: 1, Burst: 1}}
+	limiter := NewLimiter(initial, newFakeClock())
+
+	require.True(t, limiter.Allow("ip:10.0.0.1", http.MethodGet, "/api/v1/transactions").Allowed)
+	require.False(t, limiter.Allow("ip:10.0.0.1", http.MethodGet, "/api/v1/transactions").Allowed)
+
+	updated := Settings{
+		Routes:  []Rule{{Path: "/api/v1/transactions", Limit: Limit{RequestsPerSecond: 1, Burst: 2}}},
+		Default: Limit{RequestsPerSecond: 1, Burst: 1},
+	}
+	require.NoError(t, limiter.Update(updated))
+	updated.Routes[0].Burst = 100
+	assert.Equal(t, 2, limiter.Settings().Routes[0].Burst, "changing the caller's settings does not change the stored ones")
+	assert.True(t, limiter.Allow("ip:10.0.0.1", http.MethodGet, "/api/v1/transactions").Allowed, "buckets start full")
+
+	err := limiter.Update(Settings{Default: Limit{RequestsPerSecond: -1}})
+	require.Error(t, err)
+	assert.Len(t, limiter.Settings().Routes, 1, "invalid settings are not applied")
+
+	assert.Equal(t, initial, limiter.Reset())
+	assert.Equal(t, initial, limiter.Settings())
+}
+
+func TestLimiter_PrunesFullBuckets(t *testing.T) {
+	clk := newFakeClock()
+	limiter := NewLimiter(Settings{Default: Limit{RequestsPerSecond: 1, Burst: 1}}, clk)
+
+	for i := range maxBuckets {
+		limiter.Allow(fmt.Sprintf("ip:10.0.%d.%d", i/256, i%256), http.MethodGet, "/api/v1/transactions")
+	}
+	clk.now = clk.now.Add(time.Second)
+	limiter.Allow("ip:192.168.0.1", http.MethodGet, "/api/v1/transactions")
+
+	assert.Len(t, limiter.limits.Current().buckets, 1)
+}
+
+func TestLimiter_BucketsStayBoundedWhenNoneAreFull(t *testing.T) {
+	clk := newFakeClock()
+	limiter := NewLimiter(Settings{Default: Limit{RequestsPerSecond: 0.001, Burst: 2}}, clk)
+
+	for i := range maxBuckets + 500 {
+		limiter.Allow(fmt.Sprintf("ip:10.%d.%d.%d", i/65536, i/256%256, i%256), http.MethodGet, "/api/v1/transactions")
+	}
+	assert.Len(t, limiter.limits.Current().buckets, maxBuckets+1, "clients past the cap share one bucket")
+
+	// The 500 overflowing clients drained the shared bucket, so the next new client is limited
+	decision := limiter.Allow("ip:192.168.0.1", http.MethodGet, "/api/v1/transactions")
+	assert.False(t, decision.Allowed)
+	assert.Len(t, limiter.limits.Current().buckets, maxBuckets+1)
+
+	// Once the tracked buckets refill they are dropped and clients get their own again
+	clk.now = clk.now.Add(time.Hour)
+	assert.True(t, limiter.Allow("ip:192.168.0.2", http.MethodGet, "/api/v1/transactions").Allowed)
+	assert.Len(t, limiter.limits.Current().buckets, 1)
+}
+
+func TestLimiter_ConcurrentRequests(t *testing.T) {
+	limiter := NewLimiter(Settings{Default: Limit{RequestsPerSecond: 1, Burst: 50}}, newFakeClock())
+
+	var mu sync.Mutex
+	allowed := 0
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				if limiter.Allow("ip:10.0.0.1", http.MethodPost, "/api/v1/captures").Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 10 {
+			//nolint:errcheck // Every update here is valid
+			limiter.Update(limiter.Settings())
+		}
+	}()
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, allowed, 50)
+}
@@ -0,0 +1,49 @@
+// Package reload holds settings that the admin API can replace while the bank runs.
+package reload
+
+import "sync/atomic"
+
+// Validator is implemented by settings that can tell whether they can be applied
+type Validator interface {
+	Validate() error
+}
+
+// Holder keeps the settings in effect as an applied value A built from settings S,
+// such as the chaos run or rate limit buckets that go with them. Readers load the
+// current A without locking; Update and Reset swap in a fresh one, so state kept in
+// A starts over whenever settings are applied. It is safe for concurrent use.
+type Holder[S Validator, A any] struct {
+	current atomic.Pointer[A]
+	apply   func(S) *A
+	initial S
+}
+
+// NewHolder returns a Holder applying initial, which must be valid. apply builds the
+// applied value for a set of settings; it must not keep memory shared with initial
+// if the caller may change it afterwards.
+func NewHolder[S Validator, A any](initial S, apply func(S) *A) *Holder[S, A] {
+	h := &Holder[S, A]{apply: apply, initial: initial}
+	h.current.Store(apply(initial))
+	return h
+}
+
+// Current returns the applied value in effect
+func (h *Holder[S, A]) Current() *A {
+	return h.current.Load()
+}
+
+// Update validates settings and applies them in place of the current ones
+func (h *Holder[S, A]) Update(settings S) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	h.current.Store(h.apply(settings))
+	return nil
+}
+
+// Reset applies the initial settings again and returns the result
+func (h *Holder[S, A]) Reset() *A {
+	applied := h.apply(h.initial)
+	h.current.Store(applied)
+	return applied
+}
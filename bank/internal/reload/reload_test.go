@@ -0,0 +1,46 @@
+package reload
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSettings struct {
+	value int
+}
+
+func (s testSettings) Validate() error {
+	if s.value < 0 {
+		return errors.New("value cannot be negative")
+	}
+	return nil
+}
+
+type applied struct {
+	settings testSettings
+	uses     int
+}
+
+func apply(s testSettings) *applied {
+	return &applied{settings: s}
+}
+
+func TestHolder(t *testing.T) {
+	h := NewHolder(testSettings{value: 1}, apply)
+	assert.Equal(t, 1, h.Current().settings.value)
+
+	h.Current().uses++
+	require.NoError(t, h.Update(testSettings{value: 2}))
+	assert.Equal(t, 2, h.Current().settings.value)
+	assert.Zero(t, h.Current().uses, "applying settings starts their state afresh")
+
+	require.EqualError(t, h.Update(testSettings{value: -1}), "value cannot be negative")
+	assert.Equal(t, 2, h.Current().settings.value, "invalid settings are not applied")
+
+	reset := h.Reset()
+	assert.Same(t, reset, h.Current())
+	assert.Equal(t, 1, reset.settings.value)
+}
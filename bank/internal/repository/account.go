@@ -14,6 +14,7 @@ import (
 // AccountRepository defines the interface for account data access
 type AccountRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*models.Account, error)
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error)
 	FindByAccountNumber(ctx context.Context, accountNumber string) (*models.Account, error)
 	FindByAccountNumberForUpdate(ctx context.Context, accountNumber string) (*models.Account, error)
 	AdjustBalances(ctx context.Context, accountID uuid.UUID, balanceDelta, availableBalanceDelta int64) error
@@ -63,6 +64,39 @@ func (r *accountRepository) FindByID(ctx context.Context, id uuid.UUID) (*models
 	return &account, nil
 }
 
+// FindByIDForUpdate retrieves an account by its UUID with row-level lock
+func (r *accountRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	query := `
+		SELECT id, account_number, cvv, expiry_month, expiry_year,
+		       balance_cents, available_balance_cents, created_at, updated_at
+		FROM accounts
+		WHERE id = $1
+		FOR UPDATE
+	`
+
+	var account models.Account
+	err := r.exec.QueryRowContext(ctx, query, id).Scan(
+		&account.ID,
+		&account.AccountNumber,
+		&account.CVV,
+		&account.ExpiryMonth,
+		&account.ExpiryYear,
+		&account.BalanceCents,
+		&account.AvailableBalanceCents,
+		&account.CreatedAt,
+		&account.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("account not found: %w", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find and lock account by id: %w", err)
+	}
+
+	return &account, nil
+}
+
 // FindByAccountNumber retrieves an account by its account number (card number)
 func (r *accountRepository) FindByAccountNumber(ctx context.Context, accountNumber string) (*models.Account, error) {
 	query := `
@@ -95,6 +95,30 @@ func TestAccountRepository_FindByID(t *testing.T) {
 	}
 }
 
+func TestAccountRepository_FindByIDForUpdate(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+
+	existing, err := NewAccountRepository(database).FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get existing account")
+
+	tx, err := database.BeginTx(context.Background(), nil)
+	require.NoError(t, err, "failed to begin transaction")
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in test cleanup
+	}()
+
+	repo := NewAccountRepository(tx)
+
+	account, err := repo.FindByIDForUpdate(context.Background(), existing.ID)
+	require.NoError(t, err, "unexpected error")
+	assert.Equal(t, existing.ID, account.ID, "account ID mismatch")
+	assert.Equal(t, existing.AvailableBalanceCents, account.AvailableBalanceCents, "available balance mismatch")
+
+	_, err = repo.FindByIDForUpdate(context.Background(), uuid.New())
+	assert.Error(t, err, "expected error for non-existent account")
+}
+
 func TestAccountRepository_AdjustBalances(t *testing.T) {
 	database := setupTestDB(t)
 	defer cleanupTestDB(t, database)
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"sort"
 	"testing"
 
 	"github.com/benx421/payment-gateway/bank/internal/config"
@@ -34,16 +35,23 @@ func setupTestDB(t *testing.T) *db.DB {
 func runMigrations(t *testing.T, database *db.DB) {
 	t.Helper()
 
-	migrationPath := filepath.Join("..", "..", "internal", "db", "migrations", "000001_init.up.sql")
-	sqlBytes, err := os.ReadFile(migrationPath) // #nosec G304
-	if err != nil {
-		t.Fatalf("failed to read migration file: %v", err)
+	migrationPaths, err := filepath.Glob(filepath.Join("..", "..", "internal", "db", "migrations", "*.up.sql"))
+	if err != nil || len(migrationPaths) == 0 {
+		t.Fatalf("failed to find migration files: %v", err)
 	}
+	sort.Strings(migrationPaths)
 
-	_, err = database.ExecContext(context.Background(), string(sqlBytes))
-	if err != nil {
-		if err.Error() != "pq: relation \"accounts\" already exists" {
-			t.Logf("migration execution completed (tables may already exist)")
+	for _, migrationPath := range migrationPaths {
+		sqlBytes, err := os.ReadFile(migrationPath) // #nosec G304
+		if err != nil {
+			t.Fatalf("failed to read migration file: %v", err)
+		}
+
+		_, err = database.ExecContext(context.Background(), string(sqlBytes))
+		if err != nil {
+			if err.Error() != "pq: relation \"accounts\" already exists" {
+				t.Logf("migration execution completed (tables may already exist)")
+			}
 		}
 	}
 }
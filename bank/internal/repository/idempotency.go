@@ -8,12 +8,17 @@ import (
 
 	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/google/uuid"
 )
 
 // IdempotencyRepository defines the interface for idempotency key data access
 type IdempotencyRepository interface {
 	Get(ctx context.Context, key, requestPath string) (*models.IdempotencyKey, error)
 	Store(ctx context.Context, idemKey *models.IdempotencyKey) error
+	Reserve(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration) (bool, error)
+	Release(ctx context.Context, key, requestPath, lockToken string) error
+	ListByKey(ctx context.Context, key string) ([]*models.IdempotencyKey, error)
+	Delete(ctx context.Context, key, requestPath string) (int64, error)
 	DeleteOlderThan(ctx context.Context, before time.Time) (int64, error)
 }
 
@@ -31,7 +36,7 @@ func NewIdempotencyRepository(exec db.Executor) IdempotencyRepository {
 // Get retrieves a cached idempotency key and its response
 func (r *idempotencyRepository) Get(ctx context.Context, key, requestPath string) (*models.IdempotencyKey, error) {
 	query := `
-		SELECT key, request_path, response_status, response_body, created_at
+		SELECT key, request_path, request_hash, response_status, response_body, created_at, locked_at
 		FROM idempotency_keys
 		WHERE key = $1 AND request_path = $2
 	`
@@ -40,9 +45,11 @@ func (r *idempotencyRepository) Get(ctx context.Context, key, requestPath string
 	err := r.exec.QueryRowContext(ctx, query, key, requestPath).Scan(
 		&idemKey.Key,
 		&idemKey.RequestPath,
+		&idemKey.RequestHash,
 		&idemKey.ResponseStatus,
 		&idemKey.ResponseBody,
 		&idemKey.CreatedAt,
+		&idemKey.LockedAt,
 	)
 
 	if err == sql.ErrNoRows {
@@ -56,30 +63,177 @@ func (r *idempotencyRepository) Get(ctx context.Context, key, requestPath string
 }
 
 // Store saves an idempotency key with its response
+// A reservation made by Reserve is completed and unlocked if idemKey.LockToken still holds it.
+// A key that already has a response, or that another request has taken over, is left untouched
+// and models.ErrReservationLost is returned.
+// If ctx carries a pending transaction (see db.WithPendingTx), the key is written in it, so it is
+// committed together with the request's ledger changes.
 func (r *idempotencyRepository) Store(ctx context.Context, idemKey *models.IdempotencyKey) error {
 	query := `
-		INSERT INTO idempotency_keys (key, request_path, response_status, response_body, created_at)
-		VALUES ($1, $2, $3, $4, COALESCE($5, NOW()))
-		ON CONFLICT (key, request_path) DO NOTHING
+		INSERT INTO idempotency_keys (key, request_path, request_hash, response_status, response_body, created_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, NOW()))
+		ON CONFLICT (key, request_path) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			response_status = EXCLUDED.response_status,
+			response_body = EXCLUDED.response_body,
+			created_at = EXCLUDED.created_at,
+			locked_at = NULL,
+			lock_token = NULL
+		WHERE idempotency_keys.lock_token = $7
 	`
 
-	_, err := r.exec.ExecContext(
+	result, err := db.ExecutorFromContext(ctx, r.exec).ExecContext(
 		ctx, query,
 		idemKey.Key,
 		idemKey.RequestPath,
+		idemKey.RequestHash,
 		idemKey.ResponseStatus,
 		idemKey.ResponseBody,
 		idemKey.CreatedAt,
+		idemKey.LockToken,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to store idempotency key: %w", err)
 	}
 
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrReservationLost
+	}
+
 	return nil
 }
 
+// Reserve claims a key for a request that is about to be processed, so concurrent
+// requests with the same key can wait for its response instead of running again.
+// It returns false if the key is already stored or reserved. A reservation older
+// than lockTimeout is assumed abandoned (e.g. the process crashed) and is taken over.
+// On success idemKey.LockToken is set to a new token that identifies this reservation.
+func (r *idempotencyRepository) Reserve(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (key, request_path, request_hash, response_status, response_body, created_at, locked_at, lock_token)
+		VALUES ($1, $2, $3, 0, '', NOW(), NOW(), $4)
+		ON CONFLICT (key, request_path) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			created_at = EXCLUDED.created_at,
+			locked_at = EXCLUDED.locked_at,
+			lock_token = EXCLUDED.lock_token
+		WHERE idempotency_keys.locked_at < NOW() - make_interval(secs => $5)
+		RETURNING key
+	`
+
+	token := uuid.NewString()
+	var key string
+	err := r.exec.QueryRowContext(ctx, query,
+		idemKey.Key,
+		idemKey.RequestPath,
+		idemKey.RequestHash,
+		token,
+		lockTimeout.Seconds(),
+	).Scan(&key)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	idemKey.LockToken = token
+	return true, nil
+}
+
+// Release drops a reservation whose request finished without a response worth storing,
+// so the key can be retried. Only the reservation lockToken identifies is dropped: if it
+// was taken over or completed, nothing changes and models.ErrReservationLost is returned.
+func (r *idempotencyRepository) Release(ctx context.Context, key, requestPath, lockToken string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1 AND request_path = $2 AND lock_token = $3
+	`
+
+	result, err := r.exec.ExecContext(ctx, query, key, requestPath, lockToken)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrReservationLost
+	}
+
+	return nil
+}
+
+// ListByKey returns every stored entry for a key, one per request path
+func (r *idempotencyRepository) ListByKey(ctx context.Context, key string) ([]*models.IdempotencyKey, error) {
+	query := `
+		SELECT key, request_path, request_hash, response_status, response_body, created_at, locked_at
+		FROM idempotency_keys
+		WHERE key = $1
+		ORDER BY created_at, request_path
+	`
+
+	rows, err := r.exec.QueryContext(ctx, query, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list idempotency keys: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck // close error is not actionable after iteration
+	}()
+
+	var keys []*models.IdempotencyKey
+	for rows.Next() {
+		var idemKey models.IdempotencyKey
+		if err := rows.Scan(
+			&idemKey.Key,
+			&idemKey.RequestPath,
+			&idemKey.RequestHash,
+			&idemKey.ResponseStatus,
+			&idemKey.ResponseBody,
+			&idemKey.CreatedAt,
+			&idemKey.LockedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan idempotency key: %w", err)
+		}
+		keys = append(keys, &idemKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list idempotency keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Delete removes a key so its next request runs again. An empty requestPath removes
+// the key for every path. It returns the number of entries removed.
+func (r *idempotencyRepository) Delete(ctx context.Context, key, requestPath string) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE key = $1 AND ($2::text = '' OR request_path = $2::text)
+	`
+
+	result, err := r.exec.ExecContext(ctx, query, key, requestPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
 // DeleteOlderThan removes idempotency keys created before the specified time
-// This is used for cleanup of keys older than 24 hours
+// This is used by the periodic sweep to enforce the configured retention
 func (r *idempotencyRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
 	query := `
 		DELETE FROM idempotency_keys
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,6 +23,7 @@ func TestIdempotencyRepository_Store_And_Get(t *testing.T) {
 		key         string
 		requestPath string
 		body        string
+		hash        string
 		status      int
 	}{
 		{
@@ -37,6 +39,7 @@ func TestIdempotencyRepository_Store_And_Get(t *testing.T) {
 			requestPath: "/api/v1/captures",
 			status:      201,
 			body:        `{"capture_id":"cap_123"}`,
+			hash:        "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
 		},
 	}
 
@@ -47,6 +50,7 @@ func TestIdempotencyRepository_Store_And_Get(t *testing.T) {
 				RequestPath:    tt.requestPath,
 				ResponseStatus: tt.status,
 				ResponseBody:   tt.body,
+				RequestHash:    tt.hash,
 			}
 
 			err := repo.Store(context.Background(), idemKey)
@@ -60,6 +64,7 @@ func TestIdempotencyRepository_Store_And_Get(t *testing.T) {
 			assert.Equal(t, tt.requestPath, retrieved.RequestPath, "request path mismatch")
 			assert.Equal(t, tt.status, retrieved.ResponseStatus, "status mismatch")
 			assert.Equal(t, tt.body, retrieved.ResponseBody, "body mismatch")
+			assert.Equal(t, tt.hash, retrieved.RequestHash, "request hash mismatch")
 		})
 	}
 }
@@ -103,7 +108,7 @@ func TestIdempotencyRepository_Store_OnConflict(t *testing.T) {
 		ResponseBody:   `{"second":"response"}`,
 	}
 	err = repo.Store(context.Background(), second)
-	require.NoError(t, err, "failed to store second key")
+	assert.ErrorIs(t, err, models.ErrReservationLost, "a stored key is not overwritten")
 
 	retrieved, err := repo.Get(context.Background(), key, path)
 	require.NoError(t, err, "failed to get key")
@@ -218,3 +223,245 @@ func TestIdempotencyRepository_DeleteOlderThan_NoneDeleted(t *testing.T) {
 	require.NoError(t, err, "unexpected error")
 	assert.Equal(t, int64(0), deletedCount, "deleted count should be 0")
 }
+
+func TestIdempotencyRepository_Reserve(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewIdempotencyRepository(database)
+	ctx := context.Background()
+	key := &models.IdempotencyKey{Key: "reserve-key", RequestPath: "/api/v1/captures", RequestHash: "abc"}
+
+	reserved, err := repo.Reserve(ctx, key, time.Minute)
+	require.NoError(t, err, "failed to reserve key")
+	assert.True(t, reserved, "first reservation should succeed")
+	token := key.LockToken
+	assert.NotEmpty(t, token, "reservation should set a lock token")
+
+	retrieved, err := repo.Get(ctx, key.Key, key.RequestPath)
+	require.NoError(t, err, "failed to get reserved key")
+	require.NotNil(t, retrieved, "expected reserved key")
+	assert.True(t, retrieved.InProgress(), "reserved key should be in progress")
+	assert.Equal(t, "abc", retrieved.RequestHash, "request hash mismatch")
+
+	reserved, err = repo.Reserve(ctx, key, time.Minute)
+	require.NoError(t, err, "failed to reserve key again")
+	assert.False(t, reserved, "a held key should not be reserved twice")
+
+	err = repo.Store(ctx, &models.IdempotencyKey{
+		Key:            key.Key,
+		RequestPath:    key.RequestPath,
+		RequestHash:    "abc",
+		LockToken:      token,
+		ResponseStatus: 201,
+		ResponseBody:   `{"capture_id":"cap_1"}`,
+		CreatedAt:      time.Now(),
+	})
+	require.NoError(t, err, "failed to complete reservation")
+
+	retrieved, err = repo.Get(ctx, key.Key, key.RequestPath)
+	require.NoError(t, err, "failed to get completed key")
+	assert.False(t, retrieved.InProgress(), "completed key should not be in progress")
+	assert.Equal(t, 201, retrieved.ResponseStatus, "status mismatch")
+	assert.Equal(t, `{"capture_id":"cap_1"}`, retrieved.ResponseBody, "body mismatch")
+
+	reserved, err = repo.Reserve(ctx, key, 0)
+	require.NoError(t, err, "failed to reserve completed key")
+	assert.False(t, reserved, "a completed key should never be reserved")
+}
+
+func TestIdempotencyRepository_Reserve_TakesOverStaleReservation(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewIdempotencyRepository(database)
+	ctx := context.Background()
+	key := &models.IdempotencyKey{Key: "stale-key", RequestPath: "/api/v1/voids"}
+
+	reserved, err := repo.Reserve(ctx, key, time.Minute)
+	require.NoError(t, err, "failed to reserve key")
+	require.True(t, reserved, "first reservation should succeed")
+
+	_, err = database.ExecContext(ctx,
+		"UPDATE idempotency_keys SET locked_at = NOW() - INTERVAL '2 minutes' WHERE key = $1", key.Key)
+	require.NoError(t, err, "failed to age reservation")
+
+	staleToken := key.LockToken
+	takeover := &models.IdempotencyKey{Key: key.Key, RequestPath: key.RequestPath}
+	reserved, err = repo.Reserve(ctx, takeover, time.Minute)
+	require.NoError(t, err, "failed to take over reservation")
+	assert.True(t, reserved, "stale reservation should be taken over")
+	assert.NotEqual(t, staleToken, takeover.LockToken, "the new holder gets its own token")
+
+	err = repo.Release(ctx, key.Key, key.RequestPath, staleToken)
+	assert.ErrorIs(t, err, models.ErrReservationLost, "the previous holder cannot release the key")
+
+	retrieved, err := repo.Get(ctx, key.Key, key.RequestPath)
+	require.NoError(t, err, "failed to get key")
+	require.NotNil(t, retrieved, "the new holder's reservation should remain")
+	assert.True(t, retrieved.InProgress(), "the new holder's reservation should remain")
+}
+
+func TestIdempotencyRepository_Release(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewIdempotencyRepository(database)
+	ctx := context.Background()
+
+	reservation := &models.IdempotencyKey{Key: "release-key", RequestPath: "/api/v1/refunds"}
+	reserved, err := repo.Reserve(ctx, reservation, time.Minute)
+	require.NoError(t, err, "failed to reserve key")
+	require.True(t, reserved, "first reservation should succeed")
+
+	require.NoError(t, repo.Release(ctx, "release-key", "/api/v1/refunds", reservation.LockToken), "failed to release key")
+
+	retrieved, err := repo.Get(ctx, "release-key", "/api/v1/refunds")
+	require.NoError(t, err, "unexpected error checking released key")
+	assert.Nil(t, retrieved, "released key should be gone")
+
+	completed := &models.IdempotencyKey{
+		Key:            "completed-key",
+		RequestPath:    "/api/v1/refunds",
+		ResponseStatus: 200,
+		ResponseBody:   "done",
+		CreatedAt:      time.Now(),
+	}
+	require.NoError(t, repo.Store(ctx, completed), "failed to store key")
+	err = repo.Release(ctx, completed.Key, completed.RequestPath, "")
+	assert.ErrorIs(t, err, models.ErrReservationLost, "a stored response is not a reservation")
+
+	retrieved, err = repo.Get(ctx, completed.Key, completed.RequestPath)
+	require.NoError(t, err, "unexpected error checking completed key")
+	assert.NotNil(t, retrieved, "release should not delete a stored response")
+}
+
+func TestIdempotencyRepository_Store_InPendingTransaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit bool
+	}{
+		{name: "commit keeps ledger row and key", commit: true},
+		{name: "rollback drops ledger row and key", commit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := setupTestDB(t)
+			defer cleanupTestDB(t, database)
+			truncateTables(t, database)
+
+			repo := NewIdempotencyRepository(database)
+			account, err := NewAccountRepository(database).FindByAccountNumber(context.Background(), "4111111111111111")
+			require.NoError(t, err, "failed to get account")
+
+			ctx, pending := db.WithPendingTx(context.Background())
+
+			// This is what a service does: begin, write, commit
+			tx, err := database.BeginTx(ctx, nil)
+			require.NoError(t, err, "failed to begin transaction")
+			txn := &models.Transaction{
+				AccountID:   account.ID,
+				Type:        models.TransactionTypeAuthHold,
+				AmountCents: 10000,
+				Currency:    "USD",
+				Status:      models.TransactionStatusActive,
+			}
+			require.NoError(t, NewTransactionRepository(tx).Create(ctx, txn), "failed to create transaction")
+			require.NoError(t, tx.Commit(), "service commit should be deferred")
+
+			require.NoError(t, repo.Store(ctx, &models.IdempotencyKey{
+				Key:            "pending-key",
+				RequestPath:    "/api/v1/authorizations",
+				ResponseStatus: 200,
+				ResponseBody:   `{"status":"success"}`,
+				CreatedAt:      time.Now(),
+			}), "failed to store key")
+
+			// Nothing is visible outside the transaction until the pending commit
+			_, err = NewTransactionRepository(database).FindByID(context.Background(), txn.ID)
+			require.Error(t, err, "ledger row should not be visible before commit")
+
+			if tt.commit {
+				require.NoError(t, pending.Commit(), "failed to commit")
+			} else {
+				require.NoError(t, pending.Rollback(), "failed to roll back")
+			}
+
+			stored, err := repo.Get(context.Background(), "pending-key", "/api/v1/authorizations")
+			require.NoError(t, err, "failed to get key")
+			_, findErr := NewTransactionRepository(database).FindByID(context.Background(), txn.ID)
+
+			if tt.commit {
+				assert.NotNil(t, stored, "key should be committed")
+				assert.NoError(t, findErr, "ledger row should be committed")
+			} else {
+				assert.Nil(t, stored, "key should be rolled back")
+				assert.Error(t, findErr, "ledger row should be rolled back")
+			}
+		})
+	}
+}
+
+func TestPendingTx_ServiceRollbackWins(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	ctx, pending := db.WithPendingTx(context.Background())
+
+	tx, err := database.BeginTx(ctx, nil)
+	require.NoError(t, err, "failed to begin transaction")
+	require.NoError(t, tx.Rollback(), "service rollback should be deferred")
+
+	assert.Error(t, pending.Commit(), "a transaction rolled back by the service must not commit")
+}
+
+func TestIdempotencyRepository_ListByKey_And_Delete(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewIdempotencyRepository(database)
+	ctx := context.Background()
+
+	for _, path := range []string{"/api/v1/authorizations", "/api/v1/captures", "/api/v1/voids"} {
+		require.NoError(t, repo.Store(ctx, &models.IdempotencyKey{
+			Key:            "support-key",
+			RequestPath:    path,
+			ResponseStatus: 200,
+			ResponseBody:   path,
+			CreatedAt:      time.Now(),
+		}), "failed to store key")
+	}
+	require.NoError(t, repo.Store(ctx, &models.IdempotencyKey{
+		Key:            "other-key",
+		RequestPath:    "/api/v1/captures",
+		ResponseStatus: 200,
+		ResponseBody:   "other",
+		CreatedAt:      time.Now(),
+	}), "failed to store key")
+
+	keys, err := repo.ListByKey(ctx, "support-key")
+	require.NoError(t, err, "failed to list keys")
+	assert.Len(t, keys, 3, "expected one entry per path")
+
+	deleted, err := repo.Delete(ctx, "support-key", "/api/v1/captures")
+	require.NoError(t, err, "failed to delete one path")
+	assert.Equal(t, int64(1), deleted, "deleted count mismatch")
+
+	deleted, err = repo.Delete(ctx, "support-key", "")
+	require.NoError(t, err, "failed to delete all paths")
+	assert.Equal(t, int64(2), deleted, "deleted count mismatch")
+
+	keys, err = repo.ListByKey(ctx, "support-key")
+	require.NoError(t, err, "failed to list keys")
+	assert.Empty(t, keys, "all entries should be deleted")
+
+	other, err := repo.Get(ctx, "other-key", "/api/v1/captures")
+	require.NoError(t, err, "failed to get other key")
+	assert.NotNil(t, other, "other keys should be untouched")
+}
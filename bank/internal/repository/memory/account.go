@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/google/uuid"
+)
+
+// accountRepository implements repository.AccountRepository
+type accountRepository struct {
+	exec executor
+}
+
+func accountRow(id uuid.UUID) rowID {
+	return rowID{table: "accounts", key: id.String()}
+}
+
+// FindByID retrieves an account by its UUID
+func (r *accountRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	return r.find(ctx, func(*tx) uuid.UUID { return id }, false)
+}
+
+// FindByIDForUpdate retrieves an account by its UUID and locks it until the transaction ends
+func (r *accountRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	return r.find(ctx, func(*tx) uuid.UUID { return id }, true)
+}
+
+// FindByAccountNumber retrieves an account by its account number (card number)
+func (r *accountRepository) FindByAccountNumber(ctx context.Context, accountNumber string) (*models.Account, error) {
+	return r.find(ctx, func(t *tx) uuid.UUID { return t.accountID(accountNumber) }, false)
+}
+
+// FindByAccountNumberForUpdate retrieves an account by its account number and locks it until the transaction ends
+func (r *accountRepository) FindByAccountNumberForUpdate(ctx context.Context, accountNumber string) (*models.Account, error) {
+	return r.find(ctx, func(t *tx) uuid.UUID { return t.accountID(accountNumber) }, true)
+}
+
+// AdjustBalances adjusts the balance and available balance by the given deltas
+func (r *accountRepository) AdjustBalances(ctx context.Context, accountID uuid.UUID, balanceDelta, availableBalanceDelta int64) error {
+	return r.exec.run(func(t *tx) error {
+		if err := t.lock(ctx, accountRow(accountID)); err != nil {
+			return fmt.Errorf("failed to adjust account balances: %w", err)
+		}
+
+		account := t.account(accountID)
+		if account == nil {
+			return fmt.Errorf("account not found")
+		}
+
+		account.BalanceCents += balanceDelta
+		account.AvailableBalanceCents += availableBalanceDelta
+		account.UpdatedAt = time.Now()
+		t.putAccount(account)
+		return nil
+	})
+}
+
+func (r *accountRepository) find(ctx context.Context, lookup func(t *tx) uuid.UUID, forUpdate bool) (*models.Account, error) {
+	var account *models.Account
+	err := r.exec.run(func(t *tx) error {
+		id := lookup(t)
+		account = t.account(id)
+		if account == nil || !forUpdate {
+			return nil
+		}
+
+		if err := t.lock(ctx, accountRow(id)); err != nil {
+			return fmt.Errorf("failed to find and lock account: %w", err)
+		}
+		account = t.account(id)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, fmt.Errorf("account not found: %w", sql.ErrNoRows)
+	}
+
+	return account, nil
+}
+
+// accountID resolves an account number; account numbers never change
+func (t *tx) accountID(accountNumber string) uuid.UUID {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	return t.store.accountNumbers[accountNumber]
+}
+
+// account returns a copy of an account as seen by t, or nil if it does not exist
+func (t *tx) account(id uuid.UUID) *models.Account {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	if account, ok := t.accounts[id]; ok {
+		return cloneAccount(account)
+	}
+	return cloneAccount(t.store.accounts[id])
+}
+
+func (t *tx) putAccount(account *models.Account) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	t.accounts[account.ID] = account
+}
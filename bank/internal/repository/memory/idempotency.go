@@ -0,0 +1,258 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/google/uuid"
+)
+
+// idempotencyRepository implements repository.IdempotencyRepository
+type idempotencyRepository struct {
+	exec executor
+}
+
+func idempotencyRow(id idempotencyID) rowID {
+	return rowID{table: "idempotency_keys", key: id.key + "\x00" + id.requestPath}
+}
+
+// Get retrieves a cached idempotency key and its response
+// It returns nil without an error when the key is unknown.
+func (r *idempotencyRepository) Get(_ context.Context, key, requestPath string) (*models.IdempotencyKey, error) {
+	var idemKey *models.IdempotencyKey
+	err := r.exec.run(func(t *tx) error {
+		idemKey = t.idempotencyKey(idempotencyID{key: key, requestPath: requestPath})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return idemKey, nil
+}
+
+// Store saves an idempotency key with its response
+// A reservation made by Reserve is completed and unlocked if idemKey.LockToken still holds it.
+// A key that already has a response, or that another request has taken over, is left untouched
+// and models.ErrReservationLost is returned.
+// If ctx carries a pending transaction (see db.WithPendingTx), the key is written in it, so it is
+// committed together with the request's ledger changes.
+func (r *idempotencyRepository) Store(ctx context.Context, idemKey *models.IdempotencyKey) error {
+	exec := r.exec
+	if pending, ok := db.TxFromContext(ctx).(*tx); ok {
+		exec = pending
+	}
+
+	id := idempotencyID{key: idemKey.Key, requestPath: idemKey.RequestPath}
+	err := exec.run(func(t *tx) error {
+		if err := t.lock(ctx, idempotencyRow(id)); err != nil {
+			return err
+		}
+
+		existing := t.idempotencyKey(id)
+		if existing != nil && (!existing.InProgress() || existing.LockToken != idemKey.LockToken) {
+			return models.ErrReservationLost
+		}
+
+		stored := cloneIdempotencyKey(idemKey)
+		stored.LockedAt = nil
+		stored.LockToken = ""
+		if stored.CreatedAt.IsZero() {
+			stored.CreatedAt = time.Now()
+		}
+		t.putIdempotencyKey(id, stored)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Reserve claims a key for a request that is about to be processed, so concurrent
+// requests with the same key can wait for its response instead of running again.
+// It returns false if the key is already stored or reserved. A reservation older
+// than lockTimeout is assumed abandoned and is taken over.
+// On success idemKey.LockToken is set to a new token that identifies this reservation.
+func (r *idempotencyRepository) Reserve(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration) (bool, error) {
+	id := idempotencyID{key: idemKey.Key, requestPath: idemKey.RequestPath}
+	token := ""
+	err := r.exec.run(func(t *tx) error {
+		if err := t.lock(ctx, idempotencyRow(id)); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		existing := t.idempotencyKey(id)
+		if existing != nil && (!existing.InProgress() || !existing.LockedAt.Before(now.Add(-lockTimeout))) {
+			return nil
+		}
+
+		token = uuid.NewString()
+		t.putIdempotencyKey(id, &models.IdempotencyKey{
+			Key:         idemKey.Key,
+			RequestPath: idemKey.RequestPath,
+			RequestHash: idemKey.RequestHash,
+			LockToken:   token,
+			CreatedAt:   now,
+			LockedAt:    &now,
+		})
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	reserved := token != ""
+	if reserved {
+		idemKey.LockToken = token
+	}
+
+	return reserved, nil
+}
+
+// Release drops a reservation whose request finished without a response worth storing,
+// so the key can be retried. Only the reservation lockToken identifies is dropped: if it
+// was taken over or completed, nothing changes and models.ErrReservationLost is returned.
+func (r *idempotencyRepository) Release(ctx context.Context, key, requestPath, lockToken string) error {
+	id := idempotencyID{key: key, requestPath: requestPath}
+	released := false
+	err := r.exec.run(func(t *tx) error {
+		if err := t.lock(ctx, idempotencyRow(id)); err != nil {
+			return err
+		}
+
+		if existing := t.idempotencyKey(id); existing != nil && existing.InProgress() && existing.LockToken == lockToken {
+			t.putIdempotencyKey(id, nil)
+			released = true
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	if !released {
+		return models.ErrReservationLost
+	}
+
+	return nil
+}
+
+// ListByKey returns every stored entry for a key, one per request path
+func (r *idempotencyRepository) ListByKey(_ context.Context, key string) ([]*models.IdempotencyKey, error) {
+	var keys []*models.IdempotencyKey
+	err := r.exec.run(func(t *tx) error {
+		keys = t.listIdempotencyKeys(func(idemKey *models.IdempotencyKey) bool {
+			return idemKey.Key == key
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list idempotency keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// Delete removes a key so its next request runs again. An empty requestPath removes
+// the key for every path. It returns the number of entries removed.
+func (r *idempotencyRepository) Delete(ctx context.Context, key, requestPath string) (int64, error) {
+	deleted, err := r.deleteWhere(ctx, func(idemKey *models.IdempotencyKey) bool {
+		return idemKey.Key == key && (requestPath == "" || idemKey.RequestPath == requestPath)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteOlderThan removes idempotency keys created before the specified time
+// This is used by the periodic sweep to enforce the configured retention
+func (r *idempotencyRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.deleteWhere(ctx, func(idemKey *models.IdempotencyKey) bool {
+		return idemKey.CreatedAt.Before(before)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// deleteWhere removes the keys matching match. Each key is locked and checked
+// again before it is removed, in case it changed while waiting for the lock.
+func (r *idempotencyRepository) deleteWhere(ctx context.Context, match func(idemKey *models.IdempotencyKey) bool) (int64, error) {
+	var deleted int64
+	err := r.exec.run(func(t *tx) error {
+		for _, candidate := range t.listIdempotencyKeys(match) {
+			id := idempotencyID{key: candidate.Key, requestPath: candidate.RequestPath}
+			if err := t.lock(ctx, idempotencyRow(id)); err != nil {
+				return err
+			}
+
+			if current := t.idempotencyKey(id); current != nil && match(current) {
+				t.putIdempotencyKey(id, nil)
+				deleted++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// idempotencyKey returns a copy of a key as seen by t, or nil if it does not exist
+func (t *tx) idempotencyKey(id idempotencyID) *models.IdempotencyKey {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	if idemKey, ok := t.idempotencyKeys[id]; ok {
+		return cloneIdempotencyKey(idemKey)
+	}
+	return cloneIdempotencyKey(t.store.idempotencyKeys[id])
+}
+
+// putIdempotencyKey writes a key in t; a nil key deletes it
+func (t *tx) putIdempotencyKey(id idempotencyID, idemKey *models.IdempotencyKey) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	t.idempotencyKeys[id] = idemKey
+}
+
+// listIdempotencyKeys returns copies of the keys visible to t that match keep,
+// ordered by creation time and request path
+func (t *tx) listIdempotencyKeys(keep func(idemKey *models.IdempotencyKey) bool) []*models.IdempotencyKey {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	var keys []*models.IdempotencyKey
+	for id, idemKey := range t.store.idempotencyKeys {
+		if _, ok := t.idempotencyKeys[id]; !ok && keep(idemKey) {
+			keys = append(keys, cloneIdempotencyKey(idemKey))
+		}
+	}
+	for _, idemKey := range t.idempotencyKeys {
+		if idemKey != nil && keep(idemKey) {
+			keys = append(keys, cloneIdempotencyKey(idemKey))
+		}
+	}
+
+	slices.SortFunc(keys, func(a, b *models.IdempotencyKey) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RequestPath, b.RequestPath)
+	})
+	return keys
+}
@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository_ReserveAndStore(t *testing.T) {
+	ctx := context.Background()
+	repo := NewStore().IdempotencyKeys()
+	idemKey := &models.IdempotencyKey{Key: "key-1", RequestPath: "/api/v1/captures", RequestHash: "hash"}
+
+	reserved, err := repo.Reserve(ctx, idemKey, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.NotEmpty(t, idemKey.LockToken)
+
+	reserved, err = repo.Reserve(ctx, idemKey, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved, "a live reservation cannot be taken")
+
+	found, err := repo.Get(ctx, "key-1", "/api/v1/captures")
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.True(t, found.InProgress())
+
+	require.NoError(t, repo.Store(ctx, &models.IdempotencyKey{
+		Key:            "key-1",
+		RequestPath:    "/api/v1/captures",
+		RequestHash:    "hash",
+		LockToken:      idemKey.LockToken,
+		ResponseStatus: 201,
+		ResponseBody:   `{"id":"cap_1"}`,
+	}))
+
+	found, err = repo.Get(ctx, "key-1", "/api/v1/captures")
+	require.NoError(t, err)
+	assert.False(t, found.InProgress())
+	assert.Equal(t, 201, found.ResponseStatus)
+
+	err = repo.Store(ctx, &models.IdempotencyKey{
+		Key:            "key-1",
+		RequestPath:    "/api/v1/captures",
+		ResponseStatus: 500,
+	})
+	assert.ErrorIs(t, err, models.ErrReservationLost)
+	found, err = repo.Get(ctx, "key-1", "/api/v1/captures")
+	require.NoError(t, err)
+	assert.Equal(t, 201, found.ResponseStatus, "a stored response is never overwritten")
+
+	reserved, err = repo.Reserve(ctx, idemKey, 0)
+	require.NoError(t, err)
+	assert.False(t, reserved, "a stored response cannot be reserved")
+
+	assert.ErrorIs(t, repo.Release(ctx, "key-1", "/api/v1/captures", idemKey.LockToken), models.ErrReservationLost)
+	found, err = repo.Get(ctx, "key-1", "/api/v1/captures")
+	require.NoError(t, err)
+	assert.NotNil(t, found, "release leaves stored responses alone")
+}
+
+func TestIdempotencyRepository_ReserveTakesOverStaleReservation(t *testing.T) {
+	ctx := context.Background()
+	repo := NewStore().IdempotencyKeys()
+	idemKey := &models.IdempotencyKey{Key: "stale", RequestPath: "/api/v1/voids", RequestHash: "first"}
+
+	reserved, err := repo.Reserve(ctx, idemKey, time.Minute)
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	time.Sleep(5 * time.Millisecond)
+	takeover := &models.IdempotencyKey{Key: "stale", RequestPath: "/api/v1/voids", RequestHash: "second"}
+	reserved, err = repo.Reserve(ctx, takeover, time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.NotEqual(t, idemKey.LockToken, takeover.LockToken)
+
+	found, err := repo.Get(ctx, "stale", "/api/v1/voids")
+	require.NoError(t, err)
+	assert.Equal(t, "second", found.RequestHash)
+
+	assert.ErrorIs(t, repo.Release(ctx, "stale", "/api/v1/voids", idemKey.LockToken), models.ErrReservationLost,
+		"the previous holder cannot release the new holder's reservation")
+	found, err = repo.Get(ctx, "stale", "/api/v1/voids")
+	require.NoError(t, err)
+	assert.True(t, found.InProgress())
+
+	require.NoError(t, repo.Release(ctx, "stale", "/api/v1/voids", takeover.LockToken))
+	found, err = repo.Get(ctx, "stale", "/api/v1/voids")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestIdempotencyRepository_ListAndDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewStore().IdempotencyKeys()
+
+	old := time.Now().Add(-48 * time.Hour)
+	for _, idemKey := range []*models.IdempotencyKey{
+		{Key: "shared", RequestPath: "/api/v1/captures", ResponseStatus: 201, CreatedAt: old},
+		{Key: "shared", RequestPath: "/api/v1/authorizations", ResponseStatus: 201},
+		{Key: "shared", RequestPath: "/api/v1/voids", ResponseStatus: 201},
+		{Key: "other", RequestPath: "/api/v1/captures", ResponseStatus: 201},
+	} {
+		require.NoError(t, repo.Store(ctx, idemKey))
+	}
+
+	keys, err := repo.ListByKey(ctx, "shared")
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	assert.Equal(t, "/api/v1/captures", keys[0].RequestPath, "oldest first")
+
+	deleted, err := repo.Delete(ctx, "shared", "/api/v1/voids")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = repo.DeleteOlderThan(ctx, time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	deleted, err = repo.Delete(ctx, "shared", "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	keys, err = repo.ListByKey(ctx, "other")
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
@@ -0,0 +1,337 @@
+// Package memory provides an in-memory storage backend, so the bank can run without PostgreSQL.
+package memory
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/google/uuid"
+)
+
+var errTxDone = errors.New("transaction has already been committed or rolled back")
+
+// Store keeps accounts, transactions and idempotency keys in memory.
+//
+// Transactions behave like the Postgres backend at READ COMMITTED: writes stay
+// private to the transaction until Commit, and every row a transaction writes or
+// reads with a ...ForUpdate method is locked until it ends. A transaction waiting
+// for a lock gives up when its context is done; deadlocks are not detected.
+type Store struct {
+	accounts        map[uuid.UUID]*models.Account
+	accountNumbers  map[string]uuid.UUID
+	transactions    map[uuid.UUID]*models.Transaction
+	idempotencyKeys map[idempotencyID]*models.IdempotencyKey
+	locks           map[rowID]*rowLock
+	mu              sync.Mutex
+}
+
+// idempotencyID is the primary key of an idempotency key
+type idempotencyID struct {
+	key         string
+	requestPath string
+}
+
+// rowID names a lockable row, or an entry of a unique index
+type rowID struct {
+	table string
+	key   string
+}
+
+type rowLock struct {
+	owner    *tx
+	released chan struct{}
+}
+
+// tx is a transaction on a Store. Its writes are applied to the store on Commit.
+type tx struct {
+	store           *Store
+	accounts        map[uuid.UUID]*models.Account
+	transactions    map[uuid.UUID]*models.Transaction
+	idempotencyKeys map[idempotencyID]*models.IdempotencyKey // a nil entry is a deleted key
+	locks           []rowID
+	done            bool
+}
+
+// joinedTx is the view of a request's pending transaction (see db.WithPendingTx)
+// handed to one of its users; the PendingTx commits the underlying tx.
+type joinedTx struct {
+	*tx
+	pending *db.PendingTx
+	done    bool
+}
+
+// executor runs repository calls, either inside an open transaction or,
+// for a Store, each in a transaction of its own
+type executor interface {
+	run(fn func(t *tx) error) error
+}
+
+// Ensure the memory types implement the repository interfaces
+var (
+	_ repository.Store                 = (*Store)(nil)
+	_ repository.StoreTx               = (*tx)(nil)
+	_ repository.StoreTx               = (*joinedTx)(nil)
+	_ repository.AccountRepository     = (*accountRepository)(nil)
+	_ repository.TransactionRepository = (*transactionRepository)(nil)
+	_ repository.IdempotencyRepository = (*idempotencyRepository)(nil)
+)
+
+// NewStore creates an empty Store holding the given accounts
+func NewStore(accounts ...*models.Account) *Store {
+	s := &Store{
+		accounts:        make(map[uuid.UUID]*models.Account),
+		accountNumbers:  make(map[string]uuid.UUID),
+		transactions:    make(map[uuid.UUID]*models.Transaction),
+		idempotencyKeys: make(map[idempotencyID]*models.IdempotencyKey),
+		locks:           make(map[rowID]*rowLock),
+	}
+
+	now := time.Now()
+	for _, account := range accounts {
+		account = cloneAccount(account)
+		if account.ID == uuid.Nil {
+			account.ID = uuid.New()
+		}
+		if account.CreatedAt.IsZero() {
+			account.CreatedAt = now
+		}
+		if account.UpdatedAt.IsZero() {
+			account.UpdatedAt = now
+		}
+		s.accounts[account.ID] = account
+		s.accountNumbers[account.AccountNumber] = account.ID
+	}
+
+	return s
+}
+
+// TestAccounts returns the test cards seeded by the database migrations
+func TestAccounts() []*models.Account {
+	return []*models.Account{
+		{AccountNumber: "4111111111111111", CVV: "123", ExpiryMonth: 12, ExpiryYear: 2030, BalanceCents: 1000000, AvailableBalanceCents: 1000000}, // $10,000 primary
+		{AccountNumber: "4242424242424242", CVV: "456", ExpiryMonth: 6, ExpiryYear: 2030, BalanceCents: 50000, AvailableBalanceCents: 50000},      // $500 secondary
+		{AccountNumber: "5555555555554444", CVV: "789", ExpiryMonth: 9, ExpiryYear: 2030, BalanceCents: 0, AvailableBalanceCents: 0},              // $0 zero balance
+		{AccountNumber: "5105105105105100", CVV: "321", ExpiryMonth: 3, ExpiryYear: 2020, BalanceCents: 500000, AvailableBalanceCents: 500000},    // $5,000 expired
+	}
+}
+
+// Accounts returns an AccountRepository whose calls each run on their own
+func (s *Store) Accounts() repository.AccountRepository {
+	return &accountRepository{exec: s}
+}
+
+// Transactions returns a TransactionRepository whose calls each run on their own
+func (s *Store) Transactions() repository.TransactionRepository {
+	return &transactionRepository{exec: s}
+}
+
+// IdempotencyKeys returns an IdempotencyRepository whose calls each run on their own
+func (s *Store) IdempotencyKeys() repository.IdempotencyRepository {
+	return &idempotencyRepository{exec: s}
+}
+
+// BeginTx starts a transaction. Isolation is always READ COMMITTED, whatever opts asks for.
+// If ctx comes from db.WithPendingTx, the request's shared transaction is joined.
+func (s *Store) BeginTx(ctx context.Context, _ *sql.TxOptions) (repository.StoreTx, error) {
+	pending, ok := db.PendingFromContext(ctx)
+	if !ok {
+		return s.begin(), nil
+	}
+
+	shared, err := pending.Join(func() (db.Committer, error) {
+		return s.begin(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := shared.(*tx)
+	if !ok {
+		return nil, errors.New("pending transaction belongs to another storage backend")
+	}
+	return &joinedTx{tx: t, pending: pending}, nil
+}
+
+// PingContext reports the store as healthy unless ctx is done
+func (s *Store) PingContext(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func (s *Store) begin() *tx {
+	return &tx{
+		store:           s,
+		accounts:        make(map[uuid.UUID]*models.Account),
+		transactions:    make(map[uuid.UUID]*models.Transaction),
+		idempotencyKeys: make(map[idempotencyID]*models.IdempotencyKey),
+	}
+}
+
+func (s *Store) run(fn func(t *tx) error) error {
+	t := s.begin()
+	if err := fn(t); err != nil {
+		_ = t.Rollback() //nolint:errcheck // rolling back a memory transaction cannot fail
+		return err
+	}
+	return t.Commit()
+}
+
+func (t *tx) Accounts() repository.AccountRepository {
+	return &accountRepository{exec: t}
+}
+
+func (t *tx) Transactions() repository.TransactionRepository {
+	return &transactionRepository{exec: t}
+}
+
+func (t *tx) IdempotencyKeys() repository.IdempotencyRepository {
+	return &idempotencyRepository{exec: t}
+}
+
+// Commit applies the transaction's writes to the store and releases its locks
+func (t *tx) Commit() error {
+	if t.done {
+		return errTxDone
+	}
+	t.end(true)
+	return nil
+}
+
+// Rollback discards the transaction's writes and releases its locks.
+// It does nothing once the transaction has ended.
+func (t *tx) Rollback() error {
+	if !t.done {
+		t.end(false)
+	}
+	return nil
+}
+
+func (t *tx) run(fn func(t *tx) error) error {
+	if t.done {
+		return errTxDone
+	}
+	return fn(t)
+}
+
+func (t *tx) end(apply bool) {
+	s := t.store
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if apply {
+		maps.Copy(s.accounts, t.accounts)
+		maps.Copy(s.transactions, t.transactions)
+		for id, idemKey := range t.idempotencyKeys {
+			if idemKey == nil {
+				delete(s.idempotencyKeys, id)
+			} else {
+				s.idempotencyKeys[id] = idemKey
+			}
+		}
+	}
+
+	for _, id := range t.locks {
+		close(s.locks[id].released)
+		delete(s.locks, id)
+	}
+	t.locks = nil
+	t.done = true
+}
+
+// lock takes the lock on a row, waiting for the transaction holding it to end
+func (t *tx) lock(ctx context.Context, id rowID) error {
+	s := t.store
+	for {
+		s.mu.Lock()
+		held, ok := s.locks[id]
+		if !ok || held.owner == t {
+			t.tryLockLocked(id)
+			s.mu.Unlock()
+			return nil
+		}
+		released := held.released
+		s.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return fmt.Errorf("failed to lock %s row: %w", id.table, ctx.Err())
+		}
+	}
+}
+
+// tryLockLocked takes the lock on a row unless another transaction holds it.
+// The caller must hold the store mutex.
+func (t *tx) tryLockLocked(id rowID) bool {
+	s := t.store
+	if held, ok := s.locks[id]; ok {
+		return held.owner == t
+	}
+	s.locks[id] = &rowLock{owner: t, released: make(chan struct{})}
+	t.locks = append(t.locks, id)
+	return true
+}
+
+// Commit leaves the shared transaction open for the PendingTx to commit
+func (j *joinedTx) Commit() error {
+	j.done = true
+	return nil
+}
+
+// Rollback before Commit marks the whole pending transaction to be rolled back
+func (j *joinedTx) Rollback() error {
+	if !j.done {
+		j.done = true
+		j.pending.MarkRollbackOnly()
+	}
+	return nil
+}
+
+func compareUUID(a, b uuid.UUID) int {
+	return bytes.Compare(a[:], b[:])
+}
+
+func cloneAccount(account *models.Account) *models.Account {
+	if account == nil {
+		return nil
+	}
+	clone := *account
+	return &clone
+}
+
+func cloneTransaction(txn *models.Transaction) *models.Transaction {
+	if txn == nil {
+		return nil
+	}
+	clone := *txn
+	if txn.ReferenceID != nil {
+		referenceID := *txn.ReferenceID
+		clone.ReferenceID = &referenceID
+	}
+	if txn.ExpiresAt != nil {
+		expiresAt := *txn.ExpiresAt
+		clone.ExpiresAt = &expiresAt
+	}
+	clone.Metadata = maps.Clone(txn.Metadata)
+	return &clone
+}
+
+func cloneIdempotencyKey(idemKey *models.IdempotencyKey) *models.IdempotencyKey {
+	if idemKey == nil {
+		return nil
+	}
+	clone := *idemKey
+	if idemKey.LockedAt != nil {
+		lockedAt := *idemKey.LockedAt
+		clone.LockedAt = &lockedAt
+	}
+	return &clone
+}
@@ -0,0 +1,246 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/service"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*Store, *models.Account) {
+	t.Helper()
+
+	store := NewStore(TestAccounts()...)
+	account, err := store.Accounts().FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err)
+
+	return store, account
+}
+
+func newHold(accountID uuid.UUID, amount int64) *models.Transaction {
+	expiresAt := time.Now().Add(7 * 24 * time.Hour)
+	return &models.Transaction{
+		AccountID:   accountID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: amount,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+		ExpiresAt:   &expiresAt,
+	}
+}
+
+func TestStore_CommitAndRollback(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+
+	tx, err := store.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Accounts().AdjustBalances(ctx, account.ID, -100, -100))
+
+	inTx, err := tx.Accounts().FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.BalanceCents-100, inTx.BalanceCents, "a transaction sees its own writes")
+
+	outside, err := store.Accounts().FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.BalanceCents, outside.BalanceCents, "uncommitted writes are not visible")
+
+	require.NoError(t, tx.Rollback())
+	outside, err = store.Accounts().FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.BalanceCents, outside.BalanceCents)
+
+	tx, err = store.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Accounts().AdjustBalances(ctx, account.ID, -100, -100))
+	require.NoError(t, tx.Commit())
+	require.NoError(t, tx.Rollback(), "rollback after commit is a no-op")
+
+	outside, err = store.Accounts().FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.BalanceCents-100, outside.BalanceCents)
+
+	_, err = tx.Accounts().FindByID(ctx, account.ID)
+	assert.Error(t, err, "a finished transaction cannot be used")
+}
+
+func TestStore_RowLockWaitsForCommit(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+
+	first, err := store.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = first.Accounts().FindByIDForUpdate(ctx, account.ID)
+	require.NoError(t, err)
+
+	locked := make(chan *models.Account)
+	go func() {
+		second, err := store.BeginTx(ctx, nil)
+		if err != nil {
+			close(locked)
+			return
+		}
+		defer func() {
+			_ = second.Rollback() //nolint:errcheck // test cleanup
+		}()
+		found, err := second.Accounts().FindByIDForUpdate(ctx, account.ID)
+		if err != nil {
+			close(locked)
+			return
+		}
+		locked <- found
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("second transaction got the row lock while the first held it")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, first.Accounts().AdjustBalances(ctx, account.ID, -500, -500))
+	require.NoError(t, first.Commit())
+
+	select {
+	case found := <-locked:
+		require.NotNil(t, found)
+		assert.Equal(t, account.BalanceCents-500, found.BalanceCents, "the waiter reads the committed row")
+	case <-time.After(time.Second):
+		t.Fatal("second transaction never got the row lock")
+	}
+}
+
+func TestStore_RowLockWaitHonoursContext(t *testing.T) {
+	store, account := newTestStore(t)
+
+	first, err := store.BeginTx(context.Background(), nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = first.Rollback() //nolint:errcheck // test cleanup
+	}()
+	_, err = first.Accounts().FindByIDForUpdate(context.Background(), account.ID)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = store.Accounts().AdjustBalances(ctx, account.ID, 1, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestStore_PendingTransaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit bool
+	}{
+		{name: "commit makes the writes visible", commit: true},
+		{name: "rollback discards the writes", commit: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, account := newTestStore(t)
+			ctx, pending := db.WithPendingTx(context.Background())
+
+			tx, err := store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+			require.NoError(t, err)
+			require.NoError(t, tx.Accounts().AdjustBalances(ctx, account.ID, -100, -100))
+			require.NoError(t, tx.Commit())
+			require.NoError(t, tx.Rollback())
+			assert.True(t, pending.Started())
+
+			require.NoError(t, store.IdempotencyKeys().Store(ctx, &models.IdempotencyKey{
+				Key:            "pending-key",
+				RequestPath:    "/api/v1/captures",
+				ResponseStatus: 201,
+				ResponseBody:   `{}`,
+			}))
+
+			found, err := store.IdempotencyKeys().Get(context.Background(), "pending-key", "/api/v1/captures")
+			require.NoError(t, err)
+			assert.Nil(t, found, "the key is written in the pending transaction")
+
+			if tt.commit {
+				require.NoError(t, pending.Commit())
+			} else {
+				require.NoError(t, pending.Rollback())
+			}
+
+			found, err = store.IdempotencyKeys().Get(context.Background(), "pending-key", "/api/v1/captures")
+			require.NoError(t, err)
+			reloaded, err := store.Accounts().FindByID(context.Background(), account.ID)
+			require.NoError(t, err)
+			if tt.commit {
+				assert.NotNil(t, found)
+				assert.Equal(t, account.BalanceCents-100, reloaded.BalanceCents)
+			} else {
+				assert.Nil(t, found)
+				assert.Equal(t, account.BalanceCents, reloaded.BalanceCents)
+			}
+		})
+	}
+}
+
+func TestStore_PendingTransactionRolledBackByService(t *testing.T) {
+	store, account := newTestStore(t)
+	ctx, pending := db.WithPendingTx(context.Background())
+
+	tx, err := store.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Accounts().AdjustBalances(ctx, account.ID, -100, -100))
+	require.NoError(t, tx.Rollback())
+
+	assert.Error(t, pending.Commit())
+
+	reloaded, err := store.Accounts().FindByID(context.Background(), account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.BalanceCents, reloaded.BalanceCents)
+}
+
+func TestStore_ConcurrentCapturesNeverExceedAuthorization(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+	captureService := service.NewCaptureService(store, clock.System)
+
+	hold := newHold(account.ID, 500)
+	require.NoError(t, store.Transactions().Create(ctx, hold))
+	require.NoError(t, store.Accounts().AdjustBalances(ctx, account.ID, 0, -500))
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := captureService.Capture(ctx, hold.ID, 100, false, nil); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 5, succeeded)
+
+	reloadedHold, err := store.Transactions().FindByID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), reloadedHold.CapturedAmountCents)
+	assert.Equal(t, models.TransactionStatusCompleted, reloadedHold.Status)
+
+	reloadedAccount, err := store.Accounts().FindByID(ctx, account.ID)
+	require.NoError(t, err)
+	assert.Equal(t, account.BalanceCents-500, reloadedAccount.BalanceCents)
+	assert.Equal(t, account.AvailableBalanceCents-500, reloadedAccount.AvailableBalanceCents)
+}
@@ -0,0 +1,363 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/google/uuid"
+)
+
+// transactionRepository implements repository.TransactionRepository
+type transactionRepository struct {
+	exec executor
+}
+
+func transactionRow(id uuid.UUID) rowID {
+	return rowID{table: "transactions", key: id.String()}
+}
+
+// voidRow stands for the unique index allowing one void per authorization
+func voidRow(referenceID uuid.UUID) rowID {
+	return rowID{table: "transactions_void_reference", key: referenceID.String()}
+}
+
+// Create inserts a new transaction
+func (r *transactionRepository) Create(ctx context.Context, txn *models.Transaction) error {
+	if txn.ID == uuid.Nil {
+		txn.ID = uuid.New()
+	}
+
+	row := cloneTransaction(txn)
+	if row.CreatedAt.IsZero() {
+		row.CreatedAt = time.Now()
+	}
+
+	err := r.exec.run(func(t *tx) error {
+		if err := t.lock(ctx, transactionRow(row.ID)); err != nil {
+			return err
+		}
+		if row.Type == models.TransactionTypeVoid && row.ReferenceID != nil {
+			if err := t.lock(ctx, voidRow(*row.ReferenceID)); err != nil {
+				return err
+			}
+		}
+		return t.insertTransaction(row)
+	})
+	if errors.Is(err, models.ErrDuplicateTransaction) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a transaction by its ID
+func (r *transactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+	return r.find(ctx, id, false)
+}
+
+// FindByIDForUpdate retrieves a transaction by ID and locks it until the transaction ends
+func (r *transactionRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
+	return r.find(ctx, id, true)
+}
+
+// FindByReferenceID finds a transaction by its reference_id and type
+// It returns nil without an error when there is none.
+func (r *transactionRepository) FindByReferenceID(_ context.Context, refID uuid.UUID, txnType models.TransactionType) (*models.Transaction, error) {
+	var found *models.Transaction
+	err := r.exec.run(func(t *tx) error {
+		found = t.firstTransaction(func(txn *models.Transaction) bool {
+			return txn.Type == txnType && txn.ReferenceID != nil && *txn.ReferenceID == refID
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction by reference: %w", err)
+	}
+
+	return found, nil
+}
+
+// FindExpiredHoldsForUpdate locks up to limit ACTIVE authorization holds whose
+// expires_at is before the given time.
+// Holds locked by another transaction are skipped, like SKIP LOCKED in Postgres.
+// Results are ordered by account so that callers update accounts in a stable order.
+func (r *transactionRepository) FindExpiredHoldsForUpdate(_ context.Context, before time.Time, limit int) ([]*models.Transaction, error) {
+	var holds []*models.Transaction
+	err := r.exec.run(func(t *tx) error {
+		t.store.mu.Lock()
+		defer t.store.mu.Unlock()
+
+		candidates := t.transactionsLocked(func(txn *models.Transaction) bool {
+			return txn.Type == models.TransactionTypeAuthHold &&
+				txn.Status == models.TransactionStatusActive &&
+				txn.ExpiresAt != nil && txn.ExpiresAt.Before(before)
+		})
+		slices.SortFunc(candidates, func(a, b *models.Transaction) int {
+			if c := compareUUID(a.AccountID, b.AccountID); c != 0 {
+				return c
+			}
+			return a.ExpiresAt.Compare(*b.ExpiresAt)
+		})
+
+		for _, hold := range candidates {
+			if len(holds) == limit {
+				break
+			}
+			if t.tryLockLocked(transactionRow(hold.ID)) {
+				holds = append(holds, cloneTransaction(hold))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired holds: %w", err)
+	}
+
+	return holds, nil
+}
+
+// ListByReferenceID returns every transaction that references refID, oldest first
+func (r *transactionRepository) ListByReferenceID(_ context.Context, refID uuid.UUID) ([]*models.Transaction, error) {
+	var txns []*models.Transaction
+	err := r.exec.run(func(t *tx) error {
+		txns = t.listTransactions(func(txn *models.Transaction) bool {
+			return txn.ReferenceID != nil && *txn.ReferenceID == refID
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions by reference: %w", err)
+	}
+
+	return txns, nil
+}
+
+// List retrieves transactions matching the filter, ordered by creation time and ID
+func (r *transactionRepository) List(_ context.Context, filter repository.TransactionFilter) ([]*models.Transaction, error) {
+	var txns []*models.Transaction
+	err := r.exec.run(func(t *tx) error {
+		txns = t.listTransactions(func(txn *models.Transaction) bool {
+			return matchesFilter(txn, filter)
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+
+	if filter.Limit > 0 && len(txns) > filter.Limit {
+		txns = txns[:filter.Limit]
+	}
+	return txns, nil
+}
+
+// UpdateStatus updates the status of a transaction
+func (r *transactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransactionStatus) error {
+	return r.update(ctx, id, "transaction status", func(txn *models.Transaction) {
+		txn.Status = status
+	})
+}
+
+// AddAuthorizedAmount raises the total held by an authorization hold.
+// Only amount_cents changes; the hold keeps its original expires_at.
+func (r *transactionRepository) AddAuthorizedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	return r.update(ctx, id, "authorized amount", func(txn *models.Transaction) {
+		txn.AmountCents += amount
+	})
+}
+
+// AddCapturedAmount adds amount to the running captured total of an authorization hold
+func (r *transactionRepository) AddCapturedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	return r.update(ctx, id, "captured amount", func(txn *models.Transaction) {
+		txn.CapturedAmountCents += amount
+	})
+}
+
+// AddRefundedAmount adds amount to the running refunded total of a capture
+func (r *transactionRepository) AddRefundedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	return r.update(ctx, id, "refunded amount", func(txn *models.Transaction) {
+		txn.RefundedAmountCents += amount
+	})
+}
+
+// AddReversedAmount adds amount to the running reversed total of an authorization hold
+func (r *transactionRepository) AddReversedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	return r.update(ctx, id, "reversed amount", func(txn *models.Transaction) {
+		txn.ReversedAmountCents += amount
+	})
+}
+
+func (r *transactionRepository) find(ctx context.Context, id uuid.UUID, forUpdate bool) (*models.Transaction, error) {
+	var txn *models.Transaction
+	err := r.exec.run(func(t *tx) error {
+		txn = t.transaction(id)
+		if txn == nil || !forUpdate {
+			return nil
+		}
+
+		if err := t.lock(ctx, transactionRow(id)); err != nil {
+			return err
+		}
+		txn = t.transaction(id)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find transaction: %w", err)
+	}
+	if txn == nil {
+		return nil, fmt.Errorf("transaction not found: %w", sql.ErrNoRows)
+	}
+
+	return txn, nil
+}
+
+// update locks a transaction and changes it with apply
+func (r *transactionRepository) update(ctx context.Context, id uuid.UUID, field string, apply func(txn *models.Transaction)) error {
+	return r.exec.run(func(t *tx) error {
+		if err := t.lock(ctx, transactionRow(id)); err != nil {
+			return fmt.Errorf("failed to update %s: %w", field, err)
+		}
+
+		txn := t.transaction(id)
+		if txn == nil {
+			return fmt.Errorf("transaction not found")
+		}
+
+		apply(txn)
+		t.putTransaction(txn)
+		return nil
+	})
+}
+
+// matchesFilter applies the conditions of a TransactionFilter
+func matchesFilter(txn *models.Transaction, filter repository.TransactionFilter) bool {
+	if filter.AccountID != uuid.Nil && txn.AccountID != filter.AccountID {
+		return false
+	}
+	if filter.ReferenceID != uuid.Nil && (txn.ReferenceID == nil || *txn.ReferenceID != filter.ReferenceID) {
+		return false
+	}
+	if filter.MetadataKey != "" {
+		value, ok := txn.Metadata[filter.MetadataKey]
+		if !ok || (filter.MetadataValue != "" && value != filter.MetadataValue) {
+			return false
+		}
+	}
+	if filter.Type != "" && txn.Type != filter.Type {
+		return false
+	}
+	if filter.Status != "" && txn.Status != filter.Status {
+		return false
+	}
+	if filter.CreatedFrom != nil && txn.CreatedAt.Before(*filter.CreatedFrom) {
+		return false
+	}
+	if filter.CreatedTo != nil && !txn.CreatedAt.Before(*filter.CreatedTo) {
+		return false
+	}
+	if filter.After != nil && compareCreated(txn, filter.After.CreatedAt, filter.After.ID) <= 0 {
+		return false
+	}
+	return true
+}
+
+// compareCreated orders a transaction against a (created_at, id) position
+func compareCreated(txn *models.Transaction, createdAt time.Time, id uuid.UUID) int {
+	if c := txn.CreatedAt.Compare(createdAt); c != 0 {
+		return c
+	}
+	return compareUUID(txn.ID, id)
+}
+
+// transaction returns a copy of a transaction as seen by t, or nil if it does not exist
+func (t *tx) transaction(id uuid.UUID) *models.Transaction {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	if txn, ok := t.transactions[id]; ok {
+		return cloneTransaction(txn)
+	}
+	return cloneTransaction(t.store.transactions[id])
+}
+
+func (t *tx) putTransaction(txn *models.Transaction) {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	t.transactions[txn.ID] = txn
+}
+
+// insertTransaction adds a new transaction, enforcing the primary key and the
+// one-void-per-authorization unique index
+func (t *tx) insertTransaction(txn *models.Transaction) error {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	if _, ok := t.transactions[txn.ID]; ok {
+		return models.ErrDuplicateTransaction
+	}
+	if _, ok := t.store.transactions[txn.ID]; ok {
+		return models.ErrDuplicateTransaction
+	}
+	if txn.Type == models.TransactionTypeVoid && txn.ReferenceID != nil {
+		voids := t.transactionsLocked(func(other *models.Transaction) bool {
+			return other.Type == models.TransactionTypeVoid && other.ReferenceID != nil && *other.ReferenceID == *txn.ReferenceID
+		})
+		if len(voids) > 0 {
+			return models.ErrDuplicateTransaction
+		}
+	}
+
+	t.transactions[txn.ID] = txn
+	return nil
+}
+
+// firstTransaction returns a copy of the oldest transaction matching keep, or nil
+func (t *tx) firstTransaction(keep func(txn *models.Transaction) bool) *models.Transaction {
+	txns := t.listTransactions(keep)
+	if len(txns) == 0 {
+		return nil
+	}
+	return txns[0]
+}
+
+// listTransactions returns copies of the transactions matching keep, ordered by creation time and ID
+func (t *tx) listTransactions(keep func(txn *models.Transaction) bool) []*models.Transaction {
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	txns := t.transactionsLocked(keep)
+	slices.SortFunc(txns, func(a, b *models.Transaction) int {
+		return compareCreated(a, b.CreatedAt, b.ID)
+	})
+	for i, txn := range txns {
+		txns[i] = cloneTransaction(txn)
+	}
+	return txns
+}
+
+// transactionsLocked returns the transactions visible to t that match keep, in no
+// particular order. The caller must hold the store mutex and must not modify them.
+func (t *tx) transactionsLocked(keep func(txn *models.Transaction) bool) []*models.Transaction {
+	var txns []*models.Transaction
+	for id, txn := range t.store.transactions {
+		if _, ok := t.transactions[id]; !ok && keep(txn) {
+			txns = append(txns, txn)
+		}
+	}
+	for _, txn := range t.transactions {
+		if keep(txn) {
+			txns = append(txns, txn)
+		}
+	}
+	return txns
+}
@@ -0,0 +1,205 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionRepository_CreateAndFind(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+	repo := store.Transactions()
+
+	hold := newHold(account.ID, 10000)
+	hold.Metadata = map[string]string{"order_id": "order-1"}
+	require.NoError(t, repo.Create(ctx, hold))
+	assert.NotEqual(t, uuid.Nil, hold.ID)
+
+	found, err := repo.FindByID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, hold.AmountCents, found.AmountCents)
+	assert.Equal(t, "order-1", found.Metadata["order_id"])
+	assert.False(t, found.CreatedAt.IsZero())
+
+	found.Metadata["order_id"] = "changed"
+	again, err := repo.FindByID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", again.Metadata["order_id"], "returned rows are copies")
+
+	_, err = repo.FindByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = repo.Create(ctx, &models.Transaction{ID: hold.ID, AccountID: account.ID, Type: models.TransactionTypeAuthHold})
+	assert.ErrorIs(t, err, models.ErrDuplicateTransaction)
+}
+
+func TestTransactionRepository_OneVoidPerAuthorization(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+	repo := store.Transactions()
+
+	hold := newHold(account.ID, 1000)
+	require.NoError(t, repo.Create(ctx, hold))
+
+	newVoid := func() *models.Transaction {
+		return &models.Transaction{
+			AccountID:   account.ID,
+			Type:        models.TransactionTypeVoid,
+			AmountCents: 1000,
+			Currency:    "USD",
+			ReferenceID: &hold.ID,
+			Status:      models.TransactionStatusCompleted,
+		}
+	}
+	require.NoError(t, repo.Create(ctx, newVoid()))
+	assert.ErrorIs(t, repo.Create(ctx, newVoid()), models.ErrDuplicateTransaction)
+
+	capture := &models.Transaction{AccountID: account.ID, Type: models.TransactionTypeCapture, ReferenceID: &hold.ID}
+	require.NoError(t, repo.Create(ctx, capture))
+	require.NoError(t, repo.Create(ctx, &models.Transaction{AccountID: account.ID, Type: models.TransactionTypeCapture, ReferenceID: &hold.ID}),
+		"only voids are unique per reference")
+
+	found, err := repo.FindByReferenceID(ctx, hold.ID, models.TransactionTypeVoid)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+
+	found, err = repo.FindByReferenceID(ctx, hold.ID, models.TransactionTypeRefund)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	history, err := repo.ListByReferenceID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 3)
+}
+
+func TestTransactionRepository_Updates(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+	repo := store.Transactions()
+
+	hold := newHold(account.ID, 1000)
+	require.NoError(t, repo.Create(ctx, hold))
+
+	require.NoError(t, repo.AddAuthorizedAmount(ctx, hold.ID, 500))
+	require.NoError(t, repo.AddCapturedAmount(ctx, hold.ID, 300))
+	require.NoError(t, repo.AddReversedAmount(ctx, hold.ID, 200))
+	require.NoError(t, repo.AddRefundedAmount(ctx, hold.ID, 100))
+	require.NoError(t, repo.UpdateStatus(ctx, hold.ID, models.TransactionStatusCompleted))
+
+	found, err := repo.FindByID(ctx, hold.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), found.AmountCents)
+	assert.Equal(t, int64(300), found.CapturedAmountCents)
+	assert.Equal(t, int64(200), found.ReversedAmountCents)
+	assert.Equal(t, int64(100), found.RefundedAmountCents)
+	assert.Equal(t, models.TransactionStatusCompleted, found.Status)
+
+	assert.Error(t, repo.UpdateStatus(ctx, uuid.New(), models.TransactionStatusCompleted))
+}
+
+func TestTransactionRepository_FindExpiredHoldsForUpdate(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+	repo := store.Transactions()
+
+	var expired []*models.Transaction
+	for range 3 {
+		hold := newHold(account.ID, 100)
+		past := time.Now().Add(-time.Hour)
+		hold.ExpiresAt = &past
+		require.NoError(t, repo.Create(ctx, hold))
+		expired = append(expired, hold)
+	}
+	require.NoError(t, repo.Create(ctx, newHold(account.ID, 100)))
+
+	first, err := store.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = first.Rollback() //nolint:errcheck // test cleanup
+	}()
+	_, err = first.Transactions().FindByIDForUpdate(ctx, expired[0].ID)
+	require.NoError(t, err)
+
+	second, err := store.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer func() {
+		_ = second.Rollback() //nolint:errcheck // test cleanup
+	}()
+
+	holds, err := second.Transactions().FindExpiredHoldsForUpdate(ctx, time.Now(), 10)
+	require.NoError(t, err)
+	require.Len(t, holds, 2, "the locked hold is skipped")
+	for _, hold := range holds {
+		assert.NotEqual(t, expired[0].ID, hold.ID)
+	}
+
+	holds, err = second.Transactions().FindExpiredHoldsForUpdate(ctx, time.Now(), 1)
+	require.NoError(t, err)
+	assert.Len(t, holds, 1)
+}
+
+func TestTransactionRepository_List(t *testing.T) {
+	ctx := context.Background()
+	store, account := newTestStore(t)
+	repo := store.Transactions()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var created []*models.Transaction
+	for i := range 5 {
+		txn := &models.Transaction{
+			AccountID:   account.ID,
+			Type:        models.TransactionTypeCapture,
+			AmountCents: int64(100 * (i + 1)),
+			Currency:    "USD",
+			Status:      models.TransactionStatusCompleted,
+			CreatedAt:   base.Add(time.Duration(i) * time.Minute),
+		}
+		if i%2 == 0 {
+			txn.Metadata = map[string]string{"order_id": "even"}
+		}
+		require.NoError(t, repo.Create(ctx, txn))
+		created = append(created, txn)
+	}
+
+	page, err := repo.List(ctx, repository.TransactionFilter{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, created[0].ID, page[0].ID)
+	assert.Equal(t, created[1].ID, page[1].ID)
+
+	page, err = repo.List(ctx, repository.TransactionFilter{
+		After: &repository.TransactionCursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID},
+		Limit: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, created[2].ID, page[0].ID)
+
+	page, err = repo.List(ctx, repository.TransactionFilter{MetadataKey: "order_id", MetadataValue: "even"})
+	require.NoError(t, err)
+	assert.Len(t, page, 3)
+
+	from := base.Add(time.Minute)
+	to := base.Add(3 * time.Minute)
+	page, err = repo.List(ctx, repository.TransactionFilter{CreatedFrom: &from, CreatedTo: &to})
+	require.NoError(t, err)
+	assert.Len(t, page, 2)
+
+	plusTwo := time.FixedZone("UTC+2", 2*60*60)
+	fromOffset, toOffset := from.In(plusTwo), to.In(plusTwo)
+	page, err = repo.List(ctx, repository.TransactionFilter{CreatedFrom: &fromOffset, CreatedTo: &toOffset})
+	require.NoError(t, err)
+	assert.Len(t, page, 2, "a non-UTC offset must not shift the range")
+
+	page, err = repo.List(ctx, repository.TransactionFilter{AccountID: uuid.New()})
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
@@ -250,6 +250,65 @@ func (_c *MockAccountRepository_FindByID_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// FindByIDForUpdate provides a mock function with given fields: ctx, id
+func (_m *MockAccountRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByIDForUpdate")
+	}
+
+	var r0 *models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Account, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepository_FindByIDForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByIDForUpdate'
+type MockAccountRepository_FindByIDForUpdate_Call struct {
+	*mock.Call
+}
+
+// FindByIDForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAccountRepository_Expecter) FindByIDForUpdate(ctx interface{}, id interface{}) *MockAccountRepository_FindByIDForUpdate_Call {
+	return &MockAccountRepository_FindByIDForUpdate_Call{Call: _e.mock.On("FindByIDForUpdate", ctx, id)}
+}
+
+func (_c *MockAccountRepository_FindByIDForUpdate_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAccountRepository_FindByIDForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_FindByIDForUpdate_Call) Return(_a0 *models.Account, _a1 error) *MockAccountRepository_FindByIDForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepository_FindByIDForUpdate_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Account, error)) *MockAccountRepository_FindByIDForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockAccountRepository creates a new instance of MockAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAccountRepository(t interface {
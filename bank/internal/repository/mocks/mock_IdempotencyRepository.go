@@ -0,0 +1,427 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/benx421/payment-gateway/bank/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockIdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+type MockIdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepository_Expecter {
+	return &MockIdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, key, requestPath
+func (_m *MockIdempotencyRepository) Delete(ctx context.Context, key string, requestPath string) (int64, error) {
+	ret := _m.Called(ctx, key, requestPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, key, requestPath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, key, requestPath)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, requestPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepository_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIdempotencyRepository_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - requestPath string
+func (_e *MockIdempotencyRepository_Expecter) Delete(ctx interface{}, key interface{}, requestPath interface{}) *MockIdempotencyRepository_Delete_Call {
+	return &MockIdempotencyRepository_Delete_Call{Call: _e.mock.On("Delete", ctx, key, requestPath)}
+}
+
+func (_c *MockIdempotencyRepository_Delete_Call) Run(run func(ctx context.Context, key string, requestPath string)) *MockIdempotencyRepository_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Delete_Call) Return(_a0 int64, _a1 error) *MockIdempotencyRepository_Delete_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Delete_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockIdempotencyRepository_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteOlderThan provides a mock function with given fields: ctx, before
+func (_m *MockIdempotencyRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteOlderThan")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepository_DeleteOlderThan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteOlderThan'
+type MockIdempotencyRepository_DeleteOlderThan_Call struct {
+	*mock.Call
+}
+
+// DeleteOlderThan is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *MockIdempotencyRepository_Expecter) DeleteOlderThan(ctx interface{}, before interface{}) *MockIdempotencyRepository_DeleteOlderThan_Call {
+	return &MockIdempotencyRepository_DeleteOlderThan_Call{Call: _e.mock.On("DeleteOlderThan", ctx, before)}
+}
+
+func (_c *MockIdempotencyRepository_DeleteOlderThan_Call) Run(run func(ctx context.Context, before time.Time)) *MockIdempotencyRepository_DeleteOlderThan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_DeleteOlderThan_Call) Return(_a0 int64, _a1 error) *MockIdempotencyRepository_DeleteOlderThan_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_DeleteOlderThan_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *MockIdempotencyRepository_DeleteOlderThan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key, requestPath
+func (_m *MockIdempotencyRepository) Get(ctx context.Context, key string, requestPath string) (*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key, requestPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.IdempotencyKey, error)); ok {
+		return rf(ctx, key, requestPath)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.IdempotencyKey); ok {
+		r0 = rf(ctx, key, requestPath)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, key, requestPath)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepository_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIdempotencyRepository_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - requestPath string
+func (_e *MockIdempotencyRepository_Expecter) Get(ctx interface{}, key interface{}, requestPath interface{}) *MockIdempotencyRepository_Get_Call {
+	return &MockIdempotencyRepository_Get_Call{Call: _e.mock.On("Get", ctx, key, requestPath)}
+}
+
+func (_c *MockIdempotencyRepository_Get_Call) Run(run func(ctx context.Context, key string, requestPath string)) *MockIdempotencyRepository_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Get_Call) Return(_a0 *models.IdempotencyKey, _a1 error) *MockIdempotencyRepository_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Get_Call) RunAndReturn(run func(context.Context, string, string) (*models.IdempotencyKey, error)) *MockIdempotencyRepository_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByKey provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyRepository) ListByKey(ctx context.Context, key string) ([]*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByKey")
+	}
+
+	var r0 []*models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.IdempotencyKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepository_ListByKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByKey'
+type MockIdempotencyRepository_ListByKey_Call struct {
+	*mock.Call
+}
+
+// ListByKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyRepository_Expecter) ListByKey(ctx interface{}, key interface{}) *MockIdempotencyRepository_ListByKey_Call {
+	return &MockIdempotencyRepository_ListByKey_Call{Call: _e.mock.On("ListByKey", ctx, key)}
+}
+
+func (_c *MockIdempotencyRepository_ListByKey_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyRepository_ListByKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_ListByKey_Call) Return(_a0 []*models.IdempotencyKey, _a1 error) *MockIdempotencyRepository_ListByKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_ListByKey_Call) RunAndReturn(run func(context.Context, string) ([]*models.IdempotencyKey, error)) *MockIdempotencyRepository_ListByKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Release provides a mock function with given fields: ctx, key, requestPath, lockToken
+func (_m *MockIdempotencyRepository) Release(ctx context.Context, key string, requestPath string, lockToken string) error {
+	ret := _m.Called(ctx, key, requestPath, lockToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, key, requestPath, lockToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockIdempotencyRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - requestPath string
+//   - lockToken string
+func (_e *MockIdempotencyRepository_Expecter) Release(ctx interface{}, key interface{}, requestPath interface{}, lockToken interface{}) *MockIdempotencyRepository_Release_Call {
+	return &MockIdempotencyRepository_Release_Call{Call: _e.mock.On("Release", ctx, key, requestPath, lockToken)}
+}
+
+func (_c *MockIdempotencyRepository_Release_Call) Run(run func(ctx context.Context, key string, requestPath string, lockToken string)) *MockIdempotencyRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Release_Call) Return(_a0 error) *MockIdempotencyRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Release_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockIdempotencyRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, idemKey, lockTimeout
+func (_m *MockIdempotencyRepository) Reserve(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration) (bool, error) {
+	ret := _m.Called(ctx, idemKey, lockTimeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey, time.Duration) (bool, error)); ok {
+		return rf(ctx, idemKey, lockTimeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey, time.Duration) bool); ok {
+		r0 = rf(ctx, idemKey, lockTimeout)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.IdempotencyKey, time.Duration) error); ok {
+		r1 = rf(ctx, idemKey, lockTimeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepository_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type MockIdempotencyRepository_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idemKey *models.IdempotencyKey
+//   - lockTimeout time.Duration
+func (_e *MockIdempotencyRepository_Expecter) Reserve(ctx interface{}, idemKey interface{}, lockTimeout interface{}) *MockIdempotencyRepository_Reserve_Call {
+	return &MockIdempotencyRepository_Reserve_Call{Call: _e.mock.On("Reserve", ctx, idemKey, lockTimeout)}
+}
+
+func (_c *MockIdempotencyRepository_Reserve_Call) Run(run func(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration)) *MockIdempotencyRepository_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.IdempotencyKey), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Reserve_Call) Return(_a0 bool, _a1 error) *MockIdempotencyRepository_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Reserve_Call) RunAndReturn(run func(context.Context, *models.IdempotencyKey, time.Duration) (bool, error)) *MockIdempotencyRepository_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Store provides a mock function with given fields: ctx, idemKey
+func (_m *MockIdempotencyRepository) Store(ctx context.Context, idemKey *models.IdempotencyKey) error {
+	ret := _m.Called(ctx, idemKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Store")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) error); ok {
+		r0 = rf(ctx, idemKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepository_Store_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Store'
+type MockIdempotencyRepository_Store_Call struct {
+	*mock.Call
+}
+
+// Store is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idemKey *models.IdempotencyKey
+func (_e *MockIdempotencyRepository_Expecter) Store(ctx interface{}, idemKey interface{}) *MockIdempotencyRepository_Store_Call {
+	return &MockIdempotencyRepository_Store_Call{Call: _e.mock.On("Store", ctx, idemKey)}
+}
+
+func (_c *MockIdempotencyRepository_Store_Call) Run(run func(ctx context.Context, idemKey *models.IdempotencyKey)) *MockIdempotencyRepository_Store_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Store_Call) Return(_a0 error) *MockIdempotencyRepository_Store_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Store_Call) RunAndReturn(run func(context.Context, *models.IdempotencyKey) error) *MockIdempotencyRepository_Store_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyRepository creates a new instance of MockIdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,6 +8,10 @@ import (
 	models "github.com/benx421/payment-gateway/bank/internal/models"
 	mock "github.com/stretchr/testify/mock"
 
+	repository "github.com/benx421/payment-gateway/bank/internal/repository"
+
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -24,6 +28,198 @@ func (_m *MockTransactionRepository) EXPECT() *MockTransactionRepository_Expecte
 	return &MockTransactionRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddAuthorizedAmount provides a mock function with given fields: ctx, id, amount
+func (_m *MockTransactionRepository) AddAuthorizedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, id, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddAuthorizedAmount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionRepository_AddAuthorizedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddAuthorizedAmount'
+type MockTransactionRepository_AddAuthorizedAmount_Call struct {
+	*mock.Call
+}
+
+// AddAuthorizedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - amount int64
+func (_e *MockTransactionRepository_Expecter) AddAuthorizedAmount(ctx interface{}, id interface{}, amount interface{}) *MockTransactionRepository_AddAuthorizedAmount_Call {
+	return &MockTransactionRepository_AddAuthorizedAmount_Call{Call: _e.mock.On("AddAuthorizedAmount", ctx, id, amount)}
+}
+
+func (_c *MockTransactionRepository_AddAuthorizedAmount_Call) Run(run func(ctx context.Context, id uuid.UUID, amount int64)) *MockTransactionRepository_AddAuthorizedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddAuthorizedAmount_Call) Return(_a0 error) *MockTransactionRepository_AddAuthorizedAmount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddAuthorizedAmount_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) error) *MockTransactionRepository_AddAuthorizedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddCapturedAmount provides a mock function with given fields: ctx, id, amount
+func (_m *MockTransactionRepository) AddCapturedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, id, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddCapturedAmount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionRepository_AddCapturedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddCapturedAmount'
+type MockTransactionRepository_AddCapturedAmount_Call struct {
+	*mock.Call
+}
+
+// AddCapturedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - amount int64
+func (_e *MockTransactionRepository_Expecter) AddCapturedAmount(ctx interface{}, id interface{}, amount interface{}) *MockTransactionRepository_AddCapturedAmount_Call {
+	return &MockTransactionRepository_AddCapturedAmount_Call{Call: _e.mock.On("AddCapturedAmount", ctx, id, amount)}
+}
+
+func (_c *MockTransactionRepository_AddCapturedAmount_Call) Run(run func(ctx context.Context, id uuid.UUID, amount int64)) *MockTransactionRepository_AddCapturedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddCapturedAmount_Call) Return(_a0 error) *MockTransactionRepository_AddCapturedAmount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddCapturedAmount_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) error) *MockTransactionRepository_AddCapturedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddRefundedAmount provides a mock function with given fields: ctx, id, amount
+func (_m *MockTransactionRepository) AddRefundedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, id, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddRefundedAmount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionRepository_AddRefundedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddRefundedAmount'
+type MockTransactionRepository_AddRefundedAmount_Call struct {
+	*mock.Call
+}
+
+// AddRefundedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - amount int64
+func (_e *MockTransactionRepository_Expecter) AddRefundedAmount(ctx interface{}, id interface{}, amount interface{}) *MockTransactionRepository_AddRefundedAmount_Call {
+	return &MockTransactionRepository_AddRefundedAmount_Call{Call: _e.mock.On("AddRefundedAmount", ctx, id, amount)}
+}
+
+func (_c *MockTransactionRepository_AddRefundedAmount_Call) Run(run func(ctx context.Context, id uuid.UUID, amount int64)) *MockTransactionRepository_AddRefundedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddRefundedAmount_Call) Return(_a0 error) *MockTransactionRepository_AddRefundedAmount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddRefundedAmount_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) error) *MockTransactionRepository_AddRefundedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddReversedAmount provides a mock function with given fields: ctx, id, amount
+func (_m *MockTransactionRepository) AddReversedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	ret := _m.Called(ctx, id, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddReversedAmount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = rf(ctx, id, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionRepository_AddReversedAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReversedAmount'
+type MockTransactionRepository_AddReversedAmount_Call struct {
+	*mock.Call
+}
+
+// AddReversedAmount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - amount int64
+func (_e *MockTransactionRepository_Expecter) AddReversedAmount(ctx interface{}, id interface{}, amount interface{}) *MockTransactionRepository_AddReversedAmount_Call {
+	return &MockTransactionRepository_AddReversedAmount_Call{Call: _e.mock.On("AddReversedAmount", ctx, id, amount)}
+}
+
+func (_c *MockTransactionRepository_AddReversedAmount_Call) Run(run func(ctx context.Context, id uuid.UUID, amount int64)) *MockTransactionRepository_AddReversedAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddReversedAmount_Call) Return(_a0 error) *MockTransactionRepository_AddReversedAmount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionRepository_AddReversedAmount_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) error) *MockTransactionRepository_AddReversedAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function with given fields: ctx, tx
 func (_m *MockTransactionRepository) Create(ctx context.Context, tx *models.Transaction) error {
 	ret := _m.Called(ctx, tx)
@@ -249,6 +445,184 @@ func (_c *MockTransactionRepository_FindByReferenceID_Call) RunAndReturn(run fun
 	return _c
 }
 
+// FindExpiredHoldsForUpdate provides a mock function with given fields: ctx, before, limit
+func (_m *MockTransactionRepository) FindExpiredHoldsForUpdate(ctx context.Context, before time.Time, limit int) ([]*models.Transaction, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindExpiredHoldsForUpdate")
+	}
+
+	var r0 []*models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]*models.Transaction, error)); ok {
+		return rf(ctx, before, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []*models.Transaction); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_FindExpiredHoldsForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindExpiredHoldsForUpdate'
+type MockTransactionRepository_FindExpiredHoldsForUpdate_Call struct {
+	*mock.Call
+}
+
+// FindExpiredHoldsForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+//   - limit int
+func (_e *MockTransactionRepository_Expecter) FindExpiredHoldsForUpdate(ctx interface{}, before interface{}, limit interface{}) *MockTransactionRepository_FindExpiredHoldsForUpdate_Call {
+	return &MockTransactionRepository_FindExpiredHoldsForUpdate_Call{Call: _e.mock.On("FindExpiredHoldsForUpdate", ctx, before, limit)}
+}
+
+func (_c *MockTransactionRepository_FindExpiredHoldsForUpdate_Call) Run(run func(ctx context.Context, before time.Time, limit int)) *MockTransactionRepository_FindExpiredHoldsForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindExpiredHoldsForUpdate_Call) Return(_a0 []*models.Transaction, _a1 error) *MockTransactionRepository_FindExpiredHoldsForUpdate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_FindExpiredHoldsForUpdate_Call) RunAndReturn(run func(context.Context, time.Time, int) ([]*models.Transaction, error)) *MockTransactionRepository_FindExpiredHoldsForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, filter
+func (_m *MockTransactionRepository) List(ctx context.Context, filter repository.TransactionFilter) ([]*models.Transaction, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, repository.TransactionFilter) ([]*models.Transaction, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, repository.TransactionFilter) []*models.Transaction); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, repository.TransactionFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockTransactionRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter repository.TransactionFilter
+func (_e *MockTransactionRepository_Expecter) List(ctx interface{}, filter interface{}) *MockTransactionRepository_List_Call {
+	return &MockTransactionRepository_List_Call{Call: _e.mock.On("List", ctx, filter)}
+}
+
+func (_c *MockTransactionRepository_List_Call) Run(run func(ctx context.Context, filter repository.TransactionFilter)) *MockTransactionRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(repository.TransactionFilter))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_List_Call) Return(_a0 []*models.Transaction, _a1 error) *MockTransactionRepository_List_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_List_Call) RunAndReturn(run func(context.Context, repository.TransactionFilter) ([]*models.Transaction, error)) *MockTransactionRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByReferenceID provides a mock function with given fields: ctx, refID
+func (_m *MockTransactionRepository) ListByReferenceID(ctx context.Context, refID uuid.UUID) ([]*models.Transaction, error) {
+	ret := _m.Called(ctx, refID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByReferenceID")
+	}
+
+	var r0 []*models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.Transaction, error)); ok {
+		return rf(ctx, refID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Transaction); ok {
+		r0 = rf(ctx, refID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, refID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepository_ListByReferenceID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByReferenceID'
+type MockTransactionRepository_ListByReferenceID_Call struct {
+	*mock.Call
+}
+
+// ListByReferenceID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - refID uuid.UUID
+func (_e *MockTransactionRepository_Expecter) ListByReferenceID(ctx interface{}, refID interface{}) *MockTransactionRepository_ListByReferenceID_Call {
+	return &MockTransactionRepository_ListByReferenceID_Call{Call: _e.mock.On("ListByReferenceID", ctx, refID)}
+}
+
+func (_c *MockTransactionRepository_ListByReferenceID_Call) Run(run func(ctx context.Context, refID uuid.UUID)) *MockTransactionRepository_ListByReferenceID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepository_ListByReferenceID_Call) Return(_a0 []*models.Transaction, _a1 error) *MockTransactionRepository_ListByReferenceID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepository_ListByReferenceID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.Transaction, error)) *MockTransactionRepository_ListByReferenceID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateStatus provides a mock function with given fields: ctx, id, status
 func (_m *MockTransactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransactionStatus) error {
 	ret := _m.Called(ctx, id, status)
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/benx421/payment-gateway/bank/internal/db"
+)
+
+// Store is a storage backend. Repositories taken from it run each call on its
+// own; repositories taken from a StoreTx run inside that transaction.
+type Store interface {
+	Accounts() AccountRepository
+	Transactions() TransactionRepository
+	IdempotencyKeys() IdempotencyRepository
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (StoreTx, error)
+	PingContext(ctx context.Context) error
+}
+
+// StoreTx is a transaction on a Store.
+// Rows read with a ...ForUpdate method or written in it stay locked until Commit or Rollback.
+// Rollback after Commit does nothing, so it can always be deferred.
+type StoreTx interface {
+	Accounts() AccountRepository
+	Transactions() TransactionRepository
+	IdempotencyKeys() IdempotencyRepository
+	Commit() error
+	Rollback() error
+}
+
+// postgresStore implements Store on a PostgreSQL connection pool
+type postgresStore struct {
+	db *db.DB
+}
+
+// postgresTx implements StoreTx on a PostgreSQL transaction
+type postgresTx struct {
+	*db.Tx
+}
+
+// NewPostgresStore creates a Store backed by PostgreSQL
+func NewPostgresStore(database *db.DB) Store {
+	return &postgresStore{db: database}
+}
+
+func (s *postgresStore) Accounts() AccountRepository {
+	return NewAccountRepository(s.db)
+}
+
+func (s *postgresStore) Transactions() TransactionRepository {
+	return NewTransactionRepository(s.db)
+}
+
+func (s *postgresStore) IdempotencyKeys() IdempotencyRepository {
+	return NewIdempotencyRepository(s.db)
+}
+
+// BeginTx starts a database transaction
+// If ctx comes from db.WithPendingTx, the request's shared transaction is joined.
+func (s *postgresStore) BeginTx(ctx context.Context, opts *sql.TxOptions) (StoreTx, error) {
+	tx, err := s.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &postgresTx{Tx: tx}, nil
+}
+
+func (s *postgresStore) PingContext(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+func (t *postgresTx) Accounts() AccountRepository {
+	return NewAccountRepository(t.Tx)
+}
+
+func (t *postgresTx) Transactions() TransactionRepository {
+	return NewTransactionRepository(t.Tx)
+}
+
+func (t *postgresTx) IdempotencyKeys() IdempotencyRepository {
+	return NewIdempotencyRepository(t.Tx)
+}
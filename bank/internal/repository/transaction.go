@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/models"
@@ -17,7 +19,44 @@ type TransactionRepository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error)
 	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Transaction, error)
 	FindByReferenceID(ctx context.Context, refID uuid.UUID, txnType models.TransactionType) (*models.Transaction, error)
+	FindExpiredHoldsForUpdate(ctx context.Context, before time.Time, limit int) ([]*models.Transaction, error)
+	ListByReferenceID(ctx context.Context, refID uuid.UUID) ([]*models.Transaction, error)
+	List(ctx context.Context, filter TransactionFilter) ([]*models.Transaction, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status models.TransactionStatus) error
+	AddAuthorizedAmount(ctx context.Context, id uuid.UUID, amount int64) error
+	AddCapturedAmount(ctx context.Context, id uuid.UUID, amount int64) error
+	AddRefundedAmount(ctx context.Context, id uuid.UUID, amount int64) error
+	AddReversedAmount(ctx context.Context, id uuid.UUID, amount int64) error
+}
+
+// TransactionFilter narrows the rows returned by List. Zero-valued fields are not applied.
+type TransactionFilter struct {
+	CreatedFrom   *time.Time // inclusive
+	CreatedTo     *time.Time // exclusive
+	After         *TransactionCursor
+	MetadataKey   string
+	MetadataValue string // matched only together with MetadataKey
+	Type          models.TransactionType
+	Status        models.TransactionStatus
+	Limit         int
+	AccountID     uuid.UUID
+	ReferenceID   uuid.UUID
+}
+
+// TransactionCursor identifies the last row of a page for keyset pagination
+type TransactionCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+// transactionColumns lists the columns read by every transaction query, in scan order
+const transactionColumns = `id, account_id, type, amount_cents, currency,
+		       reference_id, status, expires_at, metadata, created_at,
+		       captured_amount_cents, refunded_amount_cents, reversed_amount_cents`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
 }
 
 type transactionRepository struct {
@@ -49,8 +88,9 @@ func (r *transactionRepository) Create(ctx context.Context, tx *models.Transacti
 	query := `
 		INSERT INTO transactions (
 			id, account_id, type, amount_cents, currency,
-			reference_id, status, expires_at, metadata, created_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, NOW()))
+			reference_id, status, expires_at, metadata, created_at,
+			captured_amount_cents, refunded_amount_cents, reversed_amount_cents
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, COALESCE($10, NOW()), $11, $12, $13)
 	`
 
 	_, err := r.exec.ExecContext(
@@ -64,7 +104,10 @@ func (r *transactionRepository) Create(ctx context.Context, tx *models.Transacti
 		tx.Status,
 		tx.ExpiresAt,
 		metadataJSON,
-		tx.CreatedAt,
+		tx.CreatedAt.UTC(),
+		tx.CapturedAmountCents,
+		tx.RefundedAmountCents,
+		tx.ReversedAmountCents,
 	)
 	if err != nil {
 		if db.IsUniqueViolation(err) {
@@ -79,28 +122,12 @@ func (r *transactionRepository) Create(ctx context.Context, tx *models.Transacti
 // FindByID retrieves a transaction by its ID
 func (r *transactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
 	query := `
-		SELECT id, account_id, type, amount_cents, currency,
-		       reference_id, status, expires_at, metadata, created_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		WHERE id = $1
 	`
 
-	var tx models.Transaction
-	var metadataJSON []byte
-
-	err := r.exec.QueryRowContext(ctx, query, id).Scan(
-		&tx.ID,
-		&tx.AccountID,
-		&tx.Type,
-		&tx.AmountCents,
-		&tx.Currency,
-		&tx.ReferenceID,
-		&tx.Status,
-		&tx.ExpiresAt,
-		&metadataJSON,
-		&tx.CreatedAt,
-	)
-
+	tx, err := scanTransaction(r.exec.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("transaction not found: %w", err)
 	}
@@ -108,42 +135,20 @@ func (r *transactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*mo
 		return nil, fmt.Errorf("failed to find transaction: %w", err)
 	}
 
-	if metadataJSON != nil {
-		if err := json.Unmarshal(metadataJSON, &tx.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-		}
-	}
-
-	return &tx, nil
+	return tx, nil
 }
 
 // FindByIDForUpdate retrieves a transaction by ID with a row lock (SELECT FOR UPDATE)
 // This must be called within a transaction to prevent race conditions
 func (r *transactionRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Transaction, error) {
 	query := `
-		SELECT id, account_id, type, amount_cents, currency,
-		       reference_id, status, expires_at, metadata, created_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		WHERE id = $1
 		FOR UPDATE
 	`
 
-	var tx models.Transaction
-	var metadataJSON []byte
-
-	err := r.exec.QueryRowContext(ctx, query, id).Scan(
-		&tx.ID,
-		&tx.AccountID,
-		&tx.Type,
-		&tx.AmountCents,
-		&tx.Currency,
-		&tx.ReferenceID,
-		&tx.Status,
-		&tx.ExpiresAt,
-		&metadataJSON,
-		&tx.CreatedAt,
-	)
-
+	tx, err := scanTransaction(r.exec.QueryRowContext(ctx, query, id))
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("transaction not found: %w", err)
 	}
@@ -151,42 +156,20 @@ func (r *transactionRepository) FindByIDForUpdate(ctx context.Context, id uuid.U
 		return nil, fmt.Errorf("failed to find transaction: %w", err)
 	}
 
-	if metadataJSON != nil {
-		if err := json.Unmarshal(metadataJSON, &tx.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
-		}
-	}
-
-	return &tx, nil
+	return tx, nil
 }
 
 // FindByReferenceID finds a transaction by its reference_id and type
 // This is used to check if a capture/void/refund already exists for an authorization/capture
 func (r *transactionRepository) FindByReferenceID(ctx context.Context, refID uuid.UUID, txnType models.TransactionType) (*models.Transaction, error) {
 	query := `
-		SELECT id, account_id, type, amount_cents, currency,
-		       reference_id, status, expires_at, metadata, created_at
+		SELECT ` + transactionColumns + `
 		FROM transactions
 		WHERE reference_id = $1 AND type = $2
 		LIMIT 1
 	`
 
-	var tx models.Transaction
-	var metadataJSON []byte
-
-	err := r.exec.QueryRowContext(ctx, query, refID, txnType).Scan(
-		&tx.ID,
-		&tx.AccountID,
-		&tx.Type,
-		&tx.AmountCents,
-		&tx.Currency,
-		&tx.ReferenceID,
-		&tx.Status,
-		&tx.ExpiresAt,
-		&metadataJSON,
-		&tx.CreatedAt,
-	)
-
+	tx, err := scanTransaction(r.exec.QueryRowContext(ctx, query, refID, txnType))
 	if err == sql.ErrNoRows {
 		return nil, nil // Not found is not an error for this use case
 	}
@@ -194,13 +177,164 @@ func (r *transactionRepository) FindByReferenceID(ctx context.Context, refID uui
 		return nil, fmt.Errorf("failed to find transaction by reference: %w", err)
 	}
 
-	if metadataJSON != nil {
-		if err := json.Unmarshal(metadataJSON, &tx.Metadata); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+	return tx, nil
+}
+
+// FindExpiredHoldsForUpdate locks up to limit ACTIVE authorization holds whose
+// expires_at is before the given time.
+// Rows already locked by another transaction are skipped (SKIP LOCKED), so
+// concurrent sweepers never block on or double-process the same hold.
+// Results are ordered by account so that callers update accounts in a stable order.
+func (r *transactionRepository) FindExpiredHoldsForUpdate(ctx context.Context, before time.Time, limit int) ([]*models.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE type = $1 AND status = $2 AND expires_at < $3
+		ORDER BY account_id, expires_at
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED
+	`
+
+	rows, err := r.exec.QueryContext(ctx, query,
+		models.TransactionTypeAuthHold,
+		models.TransactionStatusActive,
+		before,
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find expired holds: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck // close error is not actionable after iteration
+	}()
+
+	var holds []*models.Transaction
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan expired hold: %w", err)
 		}
+		holds = append(holds, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate expired holds: %w", err)
 	}
 
-	return &tx, nil
+	return holds, nil
+}
+
+// ListByReferenceID returns every transaction that references refID, oldest first.
+// For an authorization hold this is its history: increments, captures, reversals and voids.
+func (r *transactionRepository) ListByReferenceID(ctx context.Context, refID uuid.UUID) ([]*models.Transaction, error) {
+	query := `
+		SELECT ` + transactionColumns + `
+		FROM transactions
+		WHERE reference_id = $1
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.exec.QueryContext(ctx, query, refID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions by reference: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck // close error is not actionable after iteration
+	}()
+
+	var txns []*models.Transaction
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		txns = append(txns, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transactions: %w", err)
+	}
+
+	return txns, nil
+}
+
+// List retrieves transactions matching the filter, ordered by creation time and ID.
+// Pages are keyed on (created_at, id) so no row is repeated across pages. created_at is set before
+// the row commits, so a row committed while paging can land behind the cursor and be missed.
+func (r *transactionRepository) List(ctx context.Context, filter TransactionFilter) ([]*models.Transaction, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	addCondition := func(expr string, values ...any) {
+		placeholders := make([]any, len(values))
+		for i, v := range values {
+			args = append(args, v)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		conditions = append(conditions, fmt.Sprintf(expr, placeholders...))
+	}
+
+	if filter.AccountID != uuid.Nil {
+		addCondition("account_id = %s", filter.AccountID)
+	}
+	if filter.ReferenceID != uuid.Nil {
+		addCondition("reference_id = %s", filter.ReferenceID)
+	}
+	if filter.MetadataKey != "" {
+		if filter.MetadataValue != "" {
+			addCondition("metadata @> jsonb_build_object(%s::text, %s::text)", filter.MetadataKey, filter.MetadataValue)
+		} else {
+			addCondition("metadata ? %s", filter.MetadataKey)
+		}
+	}
+	if filter.Type != "" {
+		addCondition("type = %s", filter.Type)
+	}
+	if filter.Status != "" {
+		addCondition("status = %s", filter.Status)
+	}
+	// created_at has no time zone and the driver sends only a time's wall clock, so
+	// every time compared with it is in UTC, as created_at is written
+	if filter.CreatedFrom != nil {
+		addCondition("created_at >= %s", filter.CreatedFrom.UTC())
+	}
+	if filter.CreatedTo != nil {
+		addCondition("created_at < %s", filter.CreatedTo.UTC())
+	}
+	if filter.After != nil {
+		addCondition("(created_at, id) > (%s, %s)", filter.After.CreatedAt.UTC(), filter.After.ID)
+	}
+
+	query := `SELECT ` + transactionColumns + ` FROM transactions`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY created_at, id`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list transactions: %w", err)
+	}
+	defer func() {
+		_ = rows.Close() //nolint:errcheck // close error is not actionable after iteration
+	}()
+
+	var txns []*models.Transaction
+	for rows.Next() {
+		tx, err := scanTransaction(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan transaction: %w", err)
+		}
+		txns = append(txns, tx)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate transactions: %w", err)
+	}
+
+	return txns, nil
 }
 
 // UpdateStatus updates the status of a transaction
@@ -226,3 +360,133 @@ func (r *transactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID,
 
 	return nil
 }
+
+// AddAuthorizedAmount raises the total held by an authorization hold.
+// Only amount_cents changes; the hold keeps its original expires_at.
+func (r *transactionRepository) AddAuthorizedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	query := `
+		UPDATE transactions
+		SET amount_cents = amount_cents + $2
+		WHERE id = $1
+	`
+
+	result, err := r.exec.ExecContext(ctx, query, id, amount)
+	if err != nil {
+		return fmt.Errorf("failed to update authorized amount: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+// AddCapturedAmount adds amount to the running captured total of an authorization hold
+func (r *transactionRepository) AddCapturedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	query := `
+		UPDATE transactions
+		SET captured_amount_cents = captured_amount_cents + $2
+		WHERE id = $1
+	`
+
+	result, err := r.exec.ExecContext(ctx, query, id, amount)
+	if err != nil {
+		return fmt.Errorf("failed to update captured amount: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+// AddRefundedAmount adds amount to the running refunded total of a capture
+func (r *transactionRepository) AddRefundedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	query := `
+		UPDATE transactions
+		SET refunded_amount_cents = refunded_amount_cents + $2
+		WHERE id = $1
+	`
+
+	result, err := r.exec.ExecContext(ctx, query, id, amount)
+	if err != nil {
+		return fmt.Errorf("failed to update refunded amount: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+// AddReversedAmount adds amount to the running reversed total of an authorization hold
+func (r *transactionRepository) AddReversedAmount(ctx context.Context, id uuid.UUID, amount int64) error {
+	query := `
+		UPDATE transactions
+		SET reversed_amount_cents = reversed_amount_cents + $2
+		WHERE id = $1
+	`
+
+	result, err := r.exec.ExecContext(ctx, query, id, amount)
+	if err != nil {
+		return fmt.Errorf("failed to update reversed amount: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("transaction not found")
+	}
+
+	return nil
+}
+
+// scanTransaction reads a row selected with transactionColumns into a Transaction
+func scanTransaction(row rowScanner) (*models.Transaction, error) {
+	var tx models.Transaction
+	var metadataJSON []byte
+
+	err := row.Scan(
+		&tx.ID,
+		&tx.AccountID,
+		&tx.Type,
+		&tx.AmountCents,
+		&tx.Currency,
+		&tx.ReferenceID,
+		&tx.Status,
+		&tx.ExpiresAt,
+		&metadataJSON,
+		&tx.CreatedAt,
+		&tx.CapturedAmountCents,
+		&tx.RefundedAmountCents,
+		&tx.ReversedAmountCents,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadataJSON != nil {
+		if err := json.Unmarshal(metadataJSON, &tx.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	return &tx, nil
+}
@@ -47,7 +47,7 @@ func TestTransactionRepository_Create(t *testing.T) {
 				AmountCents: 5000,
 				Currency:    "USD",
 				Status:      models.TransactionStatusCompleted,
-				Metadata: map[string]any{
+				Metadata: map[string]string{
 					"merchant_id": "test_merchant",
 					"order_id":    "12345",
 				},
@@ -288,6 +288,364 @@ func TestTransactionRepository_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestTransactionRepository_AddAuthorizedAmount(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+
+	authTx := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+		ExpiresAt:   timePtr(time.Now().Add(time.Hour).Truncate(time.Microsecond)),
+	}
+	require.NoError(t, repo.Create(context.Background(), authTx), "failed to create transaction")
+
+	require.NoError(t, repo.AddAuthorizedAmount(context.Background(), authTx.ID, 2500))
+
+	updated, err := repo.FindByID(context.Background(), authTx.ID)
+	require.NoError(t, err, "failed to retrieve updated transaction")
+	assert.Equal(t, int64(12500), updated.AmountCents, "authorized amount mismatch")
+	assert.True(t, authTx.ExpiresAt.Equal(*updated.ExpiresAt), "expiry should not change")
+
+	err = repo.AddAuthorizedAmount(context.Background(), uuid.New(), 100)
+	assert.Error(t, err, "expected error for non-existent transaction")
+}
+
+func TestTransactionRepository_AddCapturedAmount(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+
+	authTx := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+	}
+	require.NoError(t, repo.Create(context.Background(), authTx), "failed to create transaction")
+
+	require.NoError(t, repo.AddCapturedAmount(context.Background(), authTx.ID, 4000))
+	require.NoError(t, repo.AddCapturedAmount(context.Background(), authTx.ID, 2500))
+
+	updated, err := repo.FindByID(context.Background(), authTx.ID)
+	require.NoError(t, err, "failed to retrieve updated transaction")
+	assert.Equal(t, int64(6500), updated.CapturedAmountCents, "captured amount mismatch")
+	assert.Equal(t, int64(3500), updated.RemainingAmountCents(), "remaining amount mismatch")
+
+	err = repo.AddCapturedAmount(context.Background(), uuid.New(), 100)
+	assert.Error(t, err, "expected error for non-existent transaction")
+}
+
+func TestTransactionRepository_AddRefundedAmount(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+
+	captureTx := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeCapture,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusCompleted,
+	}
+	require.NoError(t, repo.Create(context.Background(), captureTx), "failed to create transaction")
+
+	require.NoError(t, repo.AddRefundedAmount(context.Background(), captureTx.ID, 3000))
+	require.NoError(t, repo.AddRefundedAmount(context.Background(), captureTx.ID, 2000))
+
+	updated, err := repo.FindByID(context.Background(), captureTx.ID)
+	require.NoError(t, err, "failed to retrieve updated transaction")
+	assert.Equal(t, int64(5000), updated.RefundedAmountCents, "refunded amount mismatch")
+	assert.Equal(t, int64(5000), updated.RefundableAmountCents(), "refundable amount mismatch")
+
+	err = repo.AddRefundedAmount(context.Background(), uuid.New(), 100)
+	assert.Error(t, err, "expected error for non-existent transaction")
+}
+
+func TestTransactionRepository_AddReversedAmount(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+
+	authTx := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+	}
+	require.NoError(t, repo.Create(context.Background(), authTx), "failed to create transaction")
+
+	require.NoError(t, repo.AddCapturedAmount(context.Background(), authTx.ID, 4000))
+	require.NoError(t, repo.AddReversedAmount(context.Background(), authTx.ID, 1500))
+
+	updated, err := repo.FindByID(context.Background(), authTx.ID)
+	require.NoError(t, err, "failed to retrieve updated transaction")
+	assert.Equal(t, int64(1500), updated.ReversedAmountCents, "reversed amount mismatch")
+	assert.Equal(t, int64(4500), updated.RemainingAmountCents(), "remaining amount mismatch")
+
+	err = repo.AddReversedAmount(context.Background(), uuid.New(), 100)
+	assert.Error(t, err, "expected error for non-existent transaction")
+}
+
+func TestTransactionRepository_ListByReferenceID(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+
+	authTx := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+	}
+	require.NoError(t, repo.Create(context.Background(), authTx), "failed to create transaction")
+
+	now := time.Now()
+	children := []*models.Transaction{
+		{Type: models.TransactionTypeCapture, AmountCents: 4000, CreatedAt: now.Add(-2 * time.Minute)},
+		{Type: models.TransactionTypeAuthReversal, AmountCents: 1000, CreatedAt: now.Add(-time.Minute)},
+		{Type: models.TransactionTypeAuthReversal, AmountCents: 500, CreatedAt: now},
+	}
+	for _, child := range children {
+		child.AccountID = account.ID
+		child.Currency = "USD"
+		child.ReferenceID = &authTx.ID
+		child.Status = models.TransactionStatusCompleted
+		require.NoError(t, repo.Create(context.Background(), child), "failed to create child transaction")
+	}
+
+	history, err := repo.ListByReferenceID(context.Background(), authTx.ID)
+	require.NoError(t, err, "failed to list transactions")
+	require.Len(t, history, 3, "expected every child transaction")
+	for i, child := range children {
+		assert.Equal(t, child.ID, history[i].ID, "history should be ordered oldest first")
+	}
+
+	empty, err := repo.ListByReferenceID(context.Background(), uuid.New())
+	require.NoError(t, err, "unexpected error for unknown reference")
+	assert.Empty(t, empty)
+}
+
+func TestTransactionRepository_List(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+	otherAccount, err := accountRepo.FindByAccountNumber(context.Background(), "4242424242424242")
+	require.NoError(t, err, "failed to get account")
+
+	base := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	authTx := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+		CreatedAt:   base,
+	}
+	require.NoError(t, repo.Create(context.Background(), authTx), "failed to create transaction")
+
+	captures := make([]*models.Transaction, 3)
+	for i := range captures {
+		captures[i] = &models.Transaction{
+			AccountID:   account.ID,
+			Type:        models.TransactionTypeCapture,
+			AmountCents: 1000,
+			Currency:    "USD",
+			ReferenceID: &authTx.ID,
+			Status:      models.TransactionStatusCompleted,
+			CreatedAt:   base.Add(time.Duration(i+1) * time.Minute),
+		}
+		require.NoError(t, repo.Create(context.Background(), captures[i]), "failed to create capture")
+	}
+
+	otherTx := &models.Transaction{
+		AccountID:   otherAccount.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 500,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+		Metadata:    map[string]string{"order_id": "ord_123"},
+		CreatedAt:   base.Add(time.Hour),
+	}
+	require.NoError(t, repo.Create(context.Background(), otherTx), "failed to create transaction")
+
+	t.Run("all transactions oldest first", func(t *testing.T) {
+		txns, err := repo.List(context.Background(), TransactionFilter{})
+		require.NoError(t, err)
+		require.Len(t, txns, 5)
+		assert.Equal(t, authTx.ID, txns[0].ID)
+		assert.Equal(t, otherTx.ID, txns[4].ID)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		byAccount, err := repo.List(context.Background(), TransactionFilter{AccountID: otherAccount.ID})
+		require.NoError(t, err)
+		require.Len(t, byAccount, 1)
+		assert.Equal(t, otherTx.ID, byAccount[0].ID)
+
+		byType, err := repo.List(context.Background(), TransactionFilter{
+			Type:        models.TransactionTypeCapture,
+			Status:      models.TransactionStatusCompleted,
+			ReferenceID: authTx.ID,
+		})
+		require.NoError(t, err)
+		assert.Len(t, byType, 3)
+
+		from := base.Add(2 * time.Minute)
+		to := base.Add(time.Hour)
+		byTime, err := repo.List(context.Background(), TransactionFilter{CreatedFrom: &from, CreatedTo: &to})
+		require.NoError(t, err)
+		require.Len(t, byTime, 2, "range should include created_from and exclude created_to")
+		assert.Equal(t, captures[1].ID, byTime[0].ID)
+		assert.Equal(t, captures[2].ID, byTime[1].ID)
+
+		// The same instants with an offset select the same rows
+		plusTwo := time.FixedZone("UTC+2", 2*60*60)
+		fromOffset := from.In(plusTwo)
+		toOffset := to.In(plusTwo)
+		byOffsetTime, err := repo.List(context.Background(), TransactionFilter{CreatedFrom: &fromOffset, CreatedTo: &toOffset})
+		require.NoError(t, err)
+		require.Len(t, byOffsetTime, 2, "a non-UTC offset must not shift the range")
+		assert.Equal(t, captures[1].ID, byOffsetTime[0].ID)
+		assert.Equal(t, captures[2].ID, byOffsetTime[1].ID)
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		byKey, err := repo.List(context.Background(), TransactionFilter{MetadataKey: "order_id"})
+		require.NoError(t, err)
+		require.Len(t, byKey, 1)
+		assert.Equal(t, otherTx.ID, byKey[0].ID)
+		assert.Equal(t, "ord_123", byKey[0].Metadata["order_id"])
+
+		byValue, err := repo.List(context.Background(), TransactionFilter{MetadataKey: "order_id", MetadataValue: "ord_123"})
+		require.NoError(t, err)
+		require.Len(t, byValue, 1)
+
+		noMatch, err := repo.List(context.Background(), TransactionFilter{MetadataKey: "order_id", MetadataValue: "ord_999"})
+		require.NoError(t, err)
+		assert.Empty(t, noMatch)
+	})
+
+	t.Run("keyset pagination", func(t *testing.T) {
+		first, err := repo.List(context.Background(), TransactionFilter{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+
+		last := first[len(first)-1]
+		second, err := repo.List(context.Background(), TransactionFilter{
+			Limit: 2,
+			After: &TransactionCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+		})
+		require.NoError(t, err)
+		require.Len(t, second, 2)
+		assert.Equal(t, captures[1].ID, second[0].ID)
+		assert.Equal(t, captures[2].ID, second[1].ID)
+	})
+}
+
+func TestTransactionRepository_FindExpiredHoldsForUpdate(t *testing.T) {
+	database := setupTestDB(t)
+	defer cleanupTestDB(t, database)
+	truncateTables(t, database)
+
+	repo := NewTransactionRepository(database)
+	accountRepo := NewAccountRepository(database)
+
+	account, err := accountRepo.FindByAccountNumber(context.Background(), "4111111111111111")
+	require.NoError(t, err, "failed to get account")
+
+	expiredHold := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 10000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+		ExpiresAt:   timePtr(time.Now().Add(-time.Hour)),
+	}
+	activeHold := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 5000,
+		Currency:    "USD",
+		Status:      models.TransactionStatusActive,
+		ExpiresAt:   timePtr(time.Now().Add(time.Hour)),
+	}
+	completedHold := &models.Transaction{
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthHold,
+		AmountCents: 2500,
+		Currency:    "USD",
+		Status:      models.TransactionStatusCompleted,
+		ExpiresAt:   timePtr(time.Now().Add(-time.Hour)),
+	}
+	for _, tx := range []*models.Transaction{expiredHold, activeHold, completedHold} {
+		require.NoError(t, repo.Create(context.Background(), tx), "failed to create transaction")
+	}
+
+	holds, err := repo.FindExpiredHoldsForUpdate(context.Background(), time.Now(), 10)
+	require.NoError(t, err, "unexpected error")
+	require.Len(t, holds, 1, "only the active expired hold should be returned")
+	assert.Equal(t, expiredHold.ID, holds[0].ID, "ID mismatch")
+
+	t.Run("skips holds locked by another transaction", func(t *testing.T) {
+		lockingTx, err := database.BeginTx(context.Background(), nil)
+		require.NoError(t, err, "failed to begin transaction")
+		defer func() {
+			_ = lockingTx.Rollback()
+		}()
+
+		locked, err := NewTransactionRepository(lockingTx).FindExpiredHoldsForUpdate(context.Background(), time.Now(), 10)
+		require.NoError(t, err, "unexpected error")
+		require.Len(t, locked, 1, "expected to lock the expired hold")
+
+		holds, err := repo.FindExpiredHoldsForUpdate(context.Background(), time.Now(), 10)
+		require.NoError(t, err, "unexpected error")
+		assert.Empty(t, holds, "locked hold should be skipped")
+	})
+}
+
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
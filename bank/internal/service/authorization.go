@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository"
 	"github.com/google/uuid"
@@ -14,28 +14,37 @@ import (
 
 // AuthorizationService handles payment authorization operations
 type AuthorizationService struct {
-	db              *db.DB
-	authExpiryHours int
+	store      repository.Store
+	clock      clock.Clock
+	authExpiry time.Duration
 }
 
 // NewAuthorizationService creates a new AuthorizationService
 func NewAuthorizationService(
-	database *db.DB,
-	authExpiryHours int,
+	store repository.Store,
+	clk clock.Clock,
+	authExpiry time.Duration,
 ) *AuthorizationService {
 	return &AuthorizationService{
-		db:              database,
-		authExpiryHours: authExpiryHours,
+		store:      store,
+		clock:      clk,
+		authExpiry: authExpiry,
 	}
 }
 
 // Authorize creates an authorization hold on a customer's account
-func (s *AuthorizationService) Authorize(ctx context.Context, cardNumber, cvv string, amount int64) (*models.Transaction, error) {
-	if err := s.validateAuthorizationRequest(cardNumber, cvv, amount); err != nil {
+func (s *AuthorizationService) Authorize(
+	ctx context.Context,
+	cardNumber, cvv string,
+	expiryMonth, expiryYear int,
+	amount int64,
+	metadata map[string]string,
+) (*models.Transaction, error) {
+	if err := s.validateAuthorizationRequest(cardNumber, cvv, expiryMonth, amount, metadata); err != nil {
 		return nil, err
 	}
 
-	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
@@ -46,10 +55,10 @@ func (s *AuthorizationService) Authorize(ctx context.Context, cardNumber, cvv st
 		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
 	}()
 
-	txAccountRepo := repository.NewAccountRepository(tx)
-	txTransactionRepo := repository.NewTransactionRepository(tx)
+	txAccountRepo := tx.Accounts()
+	txTransactionRepo := tx.Transactions()
 
-	authTx, err := s.performAuthorization(ctx, txAccountRepo, txTransactionRepo, cardNumber, cvv, amount)
+	authTx, err := s.performAuthorization(ctx, txAccountRepo, txTransactionRepo, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +79,9 @@ func (s *AuthorizationService) performAuthorization(
 	accountRepo repository.AccountRepository,
 	transactionRepo repository.TransactionRepository,
 	cardNumber, cvv string,
+	expiryMonth, expiryYear int,
 	amount int64,
+	metadata map[string]string,
 ) (*models.Transaction, error) {
 	account, err := accountRepo.FindByAccountNumberForUpdate(ctx, cardNumber)
 	if err != nil {
@@ -87,7 +98,14 @@ func (s *AuthorizationService) performAuthorization(
 		}
 	}
 
-	if err := ValidateExpiry(account.ExpiryMonth, account.ExpiryYear); err != nil {
+	if account.ExpiryMonth != expiryMonth || account.ExpiryYear != expiryYear {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidExpiry,
+			Message: "expiry date does not match",
+		}
+	}
+
+	if err := ValidateExpiry(account.ExpiryMonth, account.ExpiryYear, s.clock.Now()); err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeCardExpired,
 			Message: err.Error(),
@@ -102,8 +120,8 @@ func (s *AuthorizationService) performAuthorization(
 	}
 
 	authID := uuid.New()
-	expiresAt := time.Now().Add(time.Duration(s.authExpiryHours) * time.Hour)
-	createdAt := time.Now()
+	createdAt := s.clock.Now()
+	expiresAt := createdAt.Add(s.authExpiry)
 
 	authTx := &models.Transaction{
 		ID:          authID,
@@ -113,6 +131,7 @@ func (s *AuthorizationService) performAuthorization(
 		Currency:    "USD",
 		Status:      models.TransactionStatusActive,
 		ExpiresAt:   &expiresAt,
+		Metadata:    metadata,
 		CreatedAt:   createdAt,
 	}
 
@@ -133,9 +152,143 @@ func (s *AuthorizationService) performAuthorization(
 	return authTx, nil
 }
 
+// IncrementAuthorization raises the amount held by an active authorization.
+// The increment is recorded as its own ledger row and the hold keeps its original expiry.
+// It returns the increment and the updated authorization hold.
+func (s *AuthorizationService) IncrementAuthorization(
+	ctx context.Context,
+	authID uuid.UUID,
+	amount int64,
+) (*models.Transaction, *models.Transaction, error) {
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to start transaction: %v", err),
+		}
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
+	}()
+
+	txAccountRepo := tx.Accounts()
+	txTransactionRepo := tx.Transactions()
+
+	incrementTx, authTx, err := s.performIncrement(ctx, txAccountRepo, txTransactionRepo, authID, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}
+	}
+
+	return incrementTx, authTx, nil
+}
+
+// performIncrement contains the core incremental authorization business logic
+func (s *AuthorizationService) performIncrement(
+	ctx context.Context,
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+	authID uuid.UUID,
+	amount int64,
+) (*models.Transaction, *models.Transaction, error) {
+	if err := ValidateAmount(amount); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInvalidAmount,
+			Message: err.Error(),
+		}
+	}
+
+	authTx, err := transactionRepo.FindByIDForUpdate(ctx, authID)
+	if err != nil || authTx.Type != models.TransactionTypeAuthHold {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthNotFound,
+			Message: "authorization not found",
+		}
+	}
+
+	if authTx.Status == models.TransactionStatusExpired {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
+	if authTx.Status != models.TransactionStatusActive {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthAlreadyUsed,
+			Message: "authorization has already been completed or cancelled",
+		}
+	}
+
+	if authTx.ExpiresAt != nil && s.clock.Now().After(*authTx.ExpiresAt) {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
+	// Lock order matches capture and void: authorization first, then account
+	account, err := accountRepo.FindByIDForUpdate(ctx, authTx.AccountID)
+	if err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to lock account: %v", err),
+		}
+	}
+
+	if account.AvailableBalanceCents < amount {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInsufficientFunds,
+			Message: "insufficient funds",
+		}
+	}
+
+	incrementTx := &models.Transaction{
+		ID:          uuid.New(),
+		AccountID:   account.ID,
+		Type:        models.TransactionTypeAuthIncrement,
+		AmountCents: amount,
+		Currency:    authTx.Currency,
+		ReferenceID: &authID,
+		Status:      models.TransactionStatusCompleted,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if err := transactionRepo.Create(ctx, incrementTx); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to create increment: %v", err),
+		}
+	}
+
+	if err := transactionRepo.AddAuthorizedAmount(ctx, authID, amount); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to update authorization: %v", err),
+		}
+	}
+
+	if err := accountRepo.AdjustBalances(ctx, account.ID, 0, -amount); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to adjust balance: %v", err),
+		}
+	}
+
+	authTx.AmountCents += amount
+
+	return incrementTx, authTx, nil
+}
+
 // GetAuthorization retrieves an authorization by ID
 func (s *AuthorizationService) GetAuthorization(ctx context.Context, authID uuid.UUID) (*models.Transaction, error) {
-	repo := repository.NewTransactionRepository(s.db)
+	repo := s.store.Transactions()
 	txn, err := repo.FindByID(ctx, authID)
 	if err != nil || txn.Type != models.TransactionTypeAuthHold {
 		return nil, &ServiceError{
@@ -147,7 +300,22 @@ func (s *AuthorizationService) GetAuthorization(ctx context.Context, authID uuid
 	return txn, nil
 }
 
-func (s *AuthorizationService) validateAuthorizationRequest(cardNumber, cvv string, amount int64) error {
+// GetAuthorizationHistory returns the ledger entries recorded against an
+// authorization (increments, captures, reversals and voids), oldest first
+func (s *AuthorizationService) GetAuthorizationHistory(ctx context.Context, authID uuid.UUID) ([]*models.Transaction, error) {
+	repo := s.store.Transactions()
+	txns, err := repo.ListByReferenceID(ctx, authID)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to load authorization history: %v", err),
+		}
+	}
+
+	return txns, nil
+}
+
+func (s *AuthorizationService) validateAuthorizationRequest(cardNumber, cvv string, expiryMonth int, amount int64, metadata map[string]string) error {
 	if err := ValidateLuhn(cardNumber); err != nil {
 		return &ServiceError{
 			Code:    ErrCodeInvalidCard,
@@ -162,6 +330,13 @@ func (s *AuthorizationService) validateAuthorizationRequest(cardNumber, cvv stri
 		}
 	}
 
+	if expiryMonth < 1 || expiryMonth > 12 {
+		return &ServiceError{
+			Code:    ErrCodeInvalidExpiry,
+			Message: "invalid expiry month: must be between 1 and 12",
+		}
+	}
+
 	if err := ValidateAmount(amount); err != nil {
 		return &ServiceError{
 			Code:    ErrCodeInvalidAmount,
@@ -169,5 +344,12 @@ func (s *AuthorizationService) validateAuthorizationRequest(cardNumber, cvv stri
 		}
 	}
 
+	if err := ValidateMetadata(metadata); err != nil {
+		return &ServiceError{
+			Code:    ErrCodeInvalidMetadata,
+			Message: err.Error(),
+		}
+	}
+
 	return nil
 }
@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
 	"github.com/google/uuid"
@@ -16,7 +19,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	t.Run("successful authorization", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		accountID := uuid.New()
@@ -38,7 +41,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(-10000)).Return(nil)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 12, 2030, amount, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -56,7 +59,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	t.Run("account not found", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		cardNumber := "4111111111111111"
@@ -66,7 +69,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 		mockAccountRepo.On("FindByAccountNumberForUpdate", ctx, cardNumber).
 			Return(nil, sql.ErrNoRows)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 12, 2030, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -82,7 +85,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	t.Run("CVV mismatch", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		accountID := uuid.New()
@@ -102,7 +105,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 
 		mockAccountRepo.On("FindByAccountNumberForUpdate", ctx, cardNumber).Return(account, nil)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 12, 2030, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -118,7 +121,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	t.Run("card expired", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		accountID := uuid.New()
@@ -138,7 +141,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 
 		mockAccountRepo.On("FindByAccountNumberForUpdate", ctx, cardNumber).Return(account, nil)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 1, 2020, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -151,10 +154,44 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 		mockAccountRepo.AssertExpectations(t)
 	})
 
+	t.Run("expiry mismatch", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+		ctx := context.Background()
+
+		cardNumber := "4111111111111111"
+		cvv := "123"
+
+		account := &models.Account{
+			ID:                    uuid.New(),
+			AccountNumber:         cardNumber,
+			CVV:                   cvv,
+			ExpiryMonth:           12,
+			ExpiryYear:            2030,
+			BalanceCents:          50000,
+			AvailableBalanceCents: 50000,
+		}
+
+		mockAccountRepo.On("FindByAccountNumberForUpdate", ctx, cardNumber).Return(account, nil)
+
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 11, 2030, 10000, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInvalidExpiry, svcErr.Code)
+		}
+
+		mockTxRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
 	t.Run("insufficient funds", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		accountID := uuid.New()
@@ -174,7 +211,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 
 		mockAccountRepo.On("FindByAccountNumberForUpdate", ctx, cardNumber).Return(account, nil)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 12, 2030, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -190,7 +227,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	t.Run("transaction creation fails", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		accountID := uuid.New()
@@ -212,7 +249,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).
 			Return(models.ErrDuplicateTransaction)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 12, 2030, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -229,7 +266,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	t.Run("balance adjustment fails", func(t *testing.T) {
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
-		service := NewAuthorizationService(nil, 168)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 		ctx := context.Background()
 
 		accountID := uuid.New()
@@ -252,7 +289,7 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(-10000)).
 			Return(assert.AnError)
 
-		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, amount)
+		result, err := service.performAuthorization(ctx, mockAccountRepo, mockTxRepo, cardNumber, cvv, 12, 2030, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -267,13 +304,160 @@ func TestAuthorizationService_PerformAuthorization(t *testing.T) {
 	})
 }
 
+func TestAuthorizationService_PerformIncrement(t *testing.T) {
+	newHold := func(accountID uuid.UUID, expiresAt time.Time) *models.Transaction {
+		return &models.Transaction{
+			ID:          uuid.New(),
+			AccountID:   accountID,
+			Type:        models.TransactionTypeAuthHold,
+			AmountCents: 10000,
+			Currency:    "USD",
+			Status:      models.TransactionStatusActive,
+			ExpiresAt:   &expiresAt,
+		}
+	}
+
+	t.Run("successful increment keeps expiry", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+		ctx := context.Background()
+
+		accountID := uuid.New()
+		expiresAt := time.Now().Add(time.Hour)
+		hold := newHold(accountID, expiresAt)
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+		mockAccountRepo.On("FindByIDForUpdate", ctx, accountID).
+			Return(&models.Account{ID: accountID, AvailableBalanceCents: 5000}, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddAuthorizedAmount", ctx, hold.ID, int64(2500)).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(-2500)).Return(nil)
+
+		increment, auth, err := service.performIncrement(ctx, mockAccountRepo, mockTxRepo, hold.ID, 2500)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.TransactionTypeAuthIncrement, increment.Type)
+		assert.Equal(t, int64(2500), increment.AmountCents)
+		assert.Equal(t, hold.ID, *increment.ReferenceID)
+		assert.NotEqual(t, hold.ID, increment.ID)
+		assert.Equal(t, int64(12500), auth.AmountCents)
+		assert.Equal(t, expiresAt, *auth.ExpiresAt)
+
+		mockAccountRepo.AssertExpectations(t)
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+		ctx := context.Background()
+
+		accountID := uuid.New()
+		hold := newHold(accountID, time.Now().Add(time.Hour))
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+		mockAccountRepo.On("FindByIDForUpdate", ctx, accountID).
+			Return(&models.Account{ID: accountID, AvailableBalanceCents: 1000}, nil)
+
+		increment, auth, err := service.performIncrement(ctx, mockAccountRepo, mockTxRepo, hold.ID, 2500)
+
+		assert.Error(t, err)
+		assert.Nil(t, increment)
+		assert.Nil(t, auth)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInsufficientFunds, svcErr.Code)
+		}
+
+		mockAccountRepo.AssertExpectations(t)
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("authorization already completed", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+		ctx := context.Background()
+
+		hold := newHold(uuid.New(), time.Now().Add(time.Hour))
+		hold.Status = models.TransactionStatusCompleted
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+
+		_, _, err := service.performIncrement(ctx, mockAccountRepo, mockTxRepo, hold.ID, 2500)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeAuthAlreadyUsed, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("authorization past expiry", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+		ctx := context.Background()
+
+		hold := newHold(uuid.New(), time.Now().Add(-time.Hour))
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+
+		_, _, err := service.performIncrement(ctx, mockAccountRepo, mockTxRepo, hold.ID, 2500)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeAuthExpired, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("not an authorization", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+		ctx := context.Background()
+
+		captureID := uuid.New()
+		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).
+			Return(&models.Transaction{ID: captureID, Type: models.TransactionTypeCapture}, nil)
+
+		_, _, err := service.performIncrement(ctx, mockAccountRepo, mockTxRepo, captureID, 2500)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeAuthNotFound, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
+
+		_, _, err := service.performIncrement(context.Background(), mockAccountRepo, mockTxRepo, uuid.New(), 0)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInvalidAmount, svcErr.Code)
+		}
+	})
+}
+
 func TestAuthorizationService_ValidateAuthorizationRequest(t *testing.T) {
-	service := NewAuthorizationService(nil, 168)
+	service := NewAuthorizationService(nil, clock.System, 168*time.Hour)
 
 	// Individual validators are already tested in validators_test.go
 	// This test verifies that validation errors are wrapped in ServiceError with correct codes
 	t.Run("wraps validation errors in ServiceError", func(t *testing.T) {
-		err := service.validateAuthorizationRequest("1234567890123456", "123", 10000)
+		err := service.validateAuthorizationRequest("1234567890123456", "123", 12, 10000, nil)
 		assert.Error(t, err)
 
 		var svcErr *ServiceError
@@ -281,4 +465,24 @@ func TestAuthorizationService_ValidateAuthorizationRequest(t *testing.T) {
 			assert.Equal(t, ErrCodeInvalidCard, svcErr.Code)
 		}
 	})
+
+	t.Run("rejects out of range expiry month", func(t *testing.T) {
+		err := service.validateAuthorizationRequest("4111111111111111", "123", 13, 10000, nil)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInvalidExpiry, svcErr.Code)
+		}
+	})
+
+	t.Run("rejects oversized metadata", func(t *testing.T) {
+		metadata := map[string]string{"order_id": strings.Repeat("x", MaxMetadataValueLength+1)}
+
+		err := service.validateAuthorizationRequest("4111111111111111", "123", 12, 10000, metadata)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInvalidMetadata, svcErr.Code)
+		}
+	})
 }
@@ -3,11 +3,9 @@ package service
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
-	"time"
 
-	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository"
 	"github.com/google/uuid"
@@ -15,19 +13,23 @@ import (
 
 // CaptureService handles payment capture operations
 type CaptureService struct {
-	db *db.DB
+	store repository.Store
+	clock clock.Clock
 }
 
 // NewCaptureService creates a new CaptureService
-func NewCaptureService(database *db.DB) *CaptureService {
+func NewCaptureService(store repository.Store, clk clock.Clock) *CaptureService {
 	return &CaptureService{
-		db: database,
+		store: store,
+		clock: clk,
 	}
 }
 
-// Capture captures an authorized payment
-func (s *CaptureService) Capture(ctx context.Context, authorizationID uuid.UUID, amount int64) (*models.Transaction, error) {
-	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+// Capture captures all or part of an authorized payment.
+// An authorization can be captured several times up to its authorized amount.
+// When finalCapture is set, any hold left after this capture is released.
+func (s *CaptureService) Capture(ctx context.Context, authorizationID uuid.UUID, amount int64, finalCapture bool, metadata map[string]string) (*models.Transaction, error) {
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
@@ -38,10 +40,10 @@ func (s *CaptureService) Capture(ctx context.Context, authorizationID uuid.UUID,
 		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
 	}()
 
-	txTransactionRepo := repository.NewTransactionRepository(tx)
-	txAccountRepo := repository.NewAccountRepository(tx)
+	txTransactionRepo := tx.Transactions()
+	txAccountRepo := tx.Accounts()
 
-	captureTxn, err := s.performCapture(ctx, txTransactionRepo, txAccountRepo, authorizationID, amount)
+	captureTxn, err := s.performCapture(ctx, txTransactionRepo, txAccountRepo, authorizationID, amount, finalCapture, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +65,23 @@ func (s *CaptureService) performCapture(
 	accountRepo repository.AccountRepository,
 	authorizationID uuid.UUID,
 	amount int64,
+	finalCapture bool,
+	metadata map[string]string,
 ) (*models.Transaction, error) {
+	if err := ValidateAmount(amount); err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidAmount,
+			Message: err.Error(),
+		}
+	}
+
+	if err := ValidateMetadata(metadata); err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidMetadata,
+			Message: err.Error(),
+		}
+	}
+
 	authTxn, err := transactionRepo.FindByIDForUpdate(ctx, authorizationID)
 	if err != nil || authTxn.Type != models.TransactionTypeAuthHold {
 		return nil, &ServiceError{
@@ -72,6 +90,13 @@ func (s *CaptureService) performCapture(
 		}
 	}
 
+	if authTxn.Status == models.TransactionStatusExpired {
+		return nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
 	if authTxn.Status != models.TransactionStatusActive {
 		return nil, &ServiceError{
 			Code:    ErrCodeAuthAlreadyUsed,
@@ -79,22 +104,24 @@ func (s *CaptureService) performCapture(
 		}
 	}
 
-	if authTxn.ExpiresAt != nil && time.Now().After(*authTxn.ExpiresAt) {
+	if authTxn.ExpiresAt != nil && s.clock.Now().After(*authTxn.ExpiresAt) {
 		return nil, &ServiceError{
 			Code:    ErrCodeAuthExpired,
 			Message: "authorization has expired",
 		}
 	}
 
-	if amount != authTxn.AmountCents {
+	remaining := authTxn.RemainingAmountCents()
+	if amount > remaining {
 		return nil, &ServiceError{
-			Code:    ErrCodeAmountMismatch,
-			Message: "capture amount does not match authorized amount",
+			Code: ErrCodeCaptureExceedsAuth,
+			Message: fmt.Sprintf("capture amount (%d) exceeds remaining authorized amount (%d)",
+				amount, remaining),
 		}
 	}
 
 	captureID := uuid.New()
-	capturedAt := time.Now()
+	capturedAt := s.clock.Now()
 
 	captureTxn := &models.Transaction{
 		ID:          captureID,
@@ -104,27 +131,35 @@ func (s *CaptureService) performCapture(
 		Currency:    authTxn.Currency,
 		ReferenceID: &authorizationID,
 		Status:      models.TransactionStatusCompleted,
+		Metadata:    metadata,
 		CreatedAt:   capturedAt,
 	}
 
 	if err := transactionRepo.Create(ctx, captureTxn); err != nil {
-		if errors.Is(err, models.ErrDuplicateTransaction) {
-			return nil, &ServiceError{
-				Code:    ErrCodeAlreadyCaptured,
-				Message: "authorization has already been captured",
-			}
-		}
 		return nil, fmt.Errorf("failed to create capture: %w", err)
 	}
 
-	if err := transactionRepo.UpdateStatus(ctx, authorizationID, models.TransactionStatusCompleted); err != nil {
+	if err := transactionRepo.AddCapturedAmount(ctx, authorizationID, amount); err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
 			Message: fmt.Sprintf("failed to update authorization: %v", err),
 		}
 	}
 
-	if err := accountRepo.AdjustBalances(ctx, authTxn.AccountID, -amount, 0); err != nil {
+	// The captured amount was already reserved by the hold, so only the ledger
+	// balance moves. Whatever a final capture leaves behind goes back to available.
+	var released int64
+	if finalCapture || amount == remaining {
+		released = remaining - amount
+		if err := transactionRepo.UpdateStatus(ctx, authorizationID, models.TransactionStatusCompleted); err != nil {
+			return nil, &ServiceError{
+				Code:    ErrCodeInternalError,
+				Message: fmt.Sprintf("failed to update authorization: %v", err),
+			}
+		}
+	}
+
+	if err := accountRepo.AdjustBalances(ctx, authTxn.AccountID, -amount, released); err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
 			Message: fmt.Sprintf("failed to adjust balance: %v", err),
@@ -136,7 +171,7 @@ func (s *CaptureService) performCapture(
 
 // GetCapture retrieves a capture by ID
 func (s *CaptureService) GetCapture(ctx context.Context, captureID uuid.UUID) (*models.Transaction, error) {
-	repo := repository.NewTransactionRepository(s.db)
+	repo := s.store.Transactions()
 	txn, err := repo.FindByID(ctx, captureID)
 	if err != nil || txn.Type != models.TransactionTypeCapture {
 		return nil, &ServiceError{
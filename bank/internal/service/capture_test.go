@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
 	"github.com/google/uuid"
@@ -17,7 +18,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 	t.Run("successful capture", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -37,10 +38,11 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddCapturedAmount", ctx, authID, amount).Return(nil)
 		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).Return(nil)
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(-10000), int64(0)).Return(nil)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -56,7 +58,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 	t.Run("authorization not found", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -64,7 +66,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(nil, sql.ErrNoRows)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -80,7 +82,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 	t.Run("wrong transaction type", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -98,7 +100,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(captureTx, nil)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -114,7 +116,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 	t.Run("authorization already used", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -131,7 +133,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -147,7 +149,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 	t.Run("authorization expired", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -166,7 +168,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -179,79 +181,201 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 		mockTxRepo.AssertExpectations(t)
 	})
 
-	t.Run("amount mismatch", func(t *testing.T) {
+	t.Run("authorization swept to expired", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
-		accountID := uuid.New()
-		var authAmount int64 = 10000
-		var captureAmount int64 = 5000 // Different amount
+		var amount int64 = 10000
 
 		authTx := &models.Transaction{
 			ID:          authID,
-			AccountID:   accountID,
+			AccountID:   uuid.New(),
 			Type:        models.TransactionTypeAuthHold,
-			AmountCents: authAmount,
-			Status:      models.TransactionStatusActive,
+			AmountCents: amount,
+			Status:      models.TransactionStatusExpired,
 		}
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, captureAmount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 
 		var svcErr *ServiceError
 		if assert.ErrorAs(t, err, &svcErr) {
-			assert.Equal(t, ErrCodeAmountMismatch, svcErr.Code)
+			assert.Equal(t, ErrCodeAuthExpired, svcErr.Code)
 		}
 
 		mockTxRepo.AssertExpectations(t)
 	})
 
-	t.Run("already captured - duplicate error", func(t *testing.T) {
+	t.Run("amount exceeds remaining authorization", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
 		accountID := uuid.New()
-		var amount int64 = 10000
+		var captureAmount int64 = 5000
 
 		authTx := &models.Transaction{
-			ID:          authID,
-			AccountID:   accountID,
-			Type:        models.TransactionTypeAuthHold,
-			AmountCents: amount,
-			Status:      models.TransactionStatusActive,
+			ID:                  authID,
+			AccountID:           accountID,
+			Type:                models.TransactionTypeAuthHold,
+			AmountCents:         10000,
+			CapturedAmountCents: 6000, // Only 4000 left to capture
+			Status:              models.TransactionStatusActive,
 		}
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
-		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).
-			Return(models.ErrDuplicateTransaction)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, captureAmount, false, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeCaptureExceedsAuth, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewCaptureService(nil, clock.System)
+		ctx := context.Background()
+
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, uuid.New(), 0, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 
 		var svcErr *ServiceError
 		if assert.ErrorAs(t, err, &svcErr) {
-			assert.Equal(t, ErrCodeAlreadyCaptured, svcErr.Code)
+			assert.Equal(t, ErrCodeInvalidAmount, svcErr.Code)
+		}
+
+		mockTxRepo.AssertNotCalled(t, "FindByIDForUpdate")
+	})
+
+	t.Run("partial capture keeps hold active", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewCaptureService(nil, clock.System)
+		ctx := context.Background()
+
+		authID := uuid.New()
+		accountID := uuid.New()
+		var captureAmount int64 = 4000
+
+		authTx := &models.Transaction{
+			ID:          authID,
+			AccountID:   accountID,
+			Type:        models.TransactionTypeAuthHold,
+			AmountCents: 10000,
+			Currency:    "USD",
+			Status:      models.TransactionStatusActive,
+		}
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddCapturedAmount", ctx, authID, captureAmount).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(-4000), int64(0)).Return(nil)
+
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, captureAmount, false, nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, captureAmount, result.AmountCents)
+
+		mockTxRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("capture of remaining amount completes hold", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewCaptureService(nil, clock.System)
+		ctx := context.Background()
+
+		authID := uuid.New()
+		accountID := uuid.New()
+		var captureAmount int64 = 6000
+
+		authTx := &models.Transaction{
+			ID:                  authID,
+			AccountID:           accountID,
+			Type:                models.TransactionTypeAuthHold,
+			AmountCents:         10000,
+			CapturedAmountCents: 4000,
+			Currency:            "USD",
+			Status:              models.TransactionStatusActive,
+		}
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddCapturedAmount", ctx, authID, captureAmount).Return(nil)
+		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(-6000), int64(0)).Return(nil)
+
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, captureAmount, false, nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
+		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("final capture releases remaining hold", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewCaptureService(nil, clock.System)
+		ctx := context.Background()
+
+		authID := uuid.New()
+		accountID := uuid.New()
+		var captureAmount int64 = 3000
+
+		authTx := &models.Transaction{
+			ID:                  authID,
+			AccountID:           accountID,
+			Type:                models.TransactionTypeAuthHold,
+			AmountCents:         10000,
+			CapturedAmountCents: 4000,
+			Currency:            "USD",
+			Status:              models.TransactionStatusActive,
 		}
 
+		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddCapturedAmount", ctx, authID, captureAmount).Return(nil)
+		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).Return(nil)
+		// 3000 captured, the other 3000 still held goes back to available
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(-3000), int64(3000)).Return(nil)
+
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, captureAmount, true, nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
 		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
 	})
 
 	t.Run("status update fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -268,10 +392,11 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddCapturedAmount", ctx, authID, amount).Return(nil)
 		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).
 			Return(assert.AnError)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -287,7 +412,7 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 	t.Run("balance adjustment fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewCaptureService(nil)
+		service := NewCaptureService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -304,11 +429,12 @@ func TestCaptureService_PerformCapture(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddCapturedAmount", ctx, authID, amount).Return(nil)
 		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).Return(nil)
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(-10000), int64(0)).
 			Return(assert.AnError)
 
-		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount)
+		result, err := service.performCapture(ctx, mockTxRepo, mockAccountRepo, authID, amount, false, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
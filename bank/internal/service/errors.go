@@ -23,19 +23,28 @@ func (e *ServiceError) Unwrap() error {
 
 // Common error codes
 const (
-	ErrCodeInvalidCard       = "invalid_card"
-	ErrCodeInvalidCVV        = "invalid_cvv"
-	ErrCodeInvalidAmount     = "invalid_amount"
-	ErrCodeCardExpired       = "card_expired"
-	ErrCodeInsufficientFunds = "insufficient_funds"
-	ErrCodeAccountNotFound   = "account_not_found"
-	ErrCodeAuthNotFound      = "authorization_not_found"
-	ErrCodeAuthExpired       = "authorization_expired"
-	ErrCodeAuthAlreadyUsed   = "authorization_already_used"
-	ErrCodeAlreadyCaptured   = "already_captured"
-	ErrCodeAlreadyVoided     = "already_voided"
-	ErrCodeAlreadyRefunded   = "already_refunded"
-	ErrCodeAmountMismatch    = "amount_mismatch"
-	ErrCodeCaptureNotFound   = "capture_not_found"
-	ErrCodeInternalError     = "internal_error"
+	ErrCodeInvalidCard            = "invalid_card"
+	ErrCodeInvalidCVV             = "invalid_cvv"
+	ErrCodeInvalidExpiry          = "invalid_expiry"
+	ErrCodeInvalidAmount          = "invalid_amount"
+	ErrCodeCardExpired            = "card_expired"
+	ErrCodeInsufficientFunds      = "insufficient_funds"
+	ErrCodeAccountNotFound        = "account_not_found"
+	ErrCodeAuthNotFound           = "authorization_not_found"
+	ErrCodeAuthExpired            = "authorization_expired"
+	ErrCodeAuthAlreadyUsed        = "authorization_already_used"
+	ErrCodeAlreadyCaptured        = "already_captured"
+	ErrCodeAlreadyVoided          = "already_voided"
+	ErrCodeAlreadyRefunded        = "already_refunded"
+	ErrCodeAmountMismatch         = "amount_mismatch"
+	ErrCodeCaptureExceedsAuth     = "capture_exceeds_authorization"
+	ErrCodeCaptureNotFound        = "capture_not_found"
+	ErrCodeRefundExceedsCapture   = "refund_exceeds_capture"
+	ErrCodeRefundNotFound         = "refund_not_found"
+	ErrCodeVoidNotFound           = "void_not_found"
+	ErrCodeReversalExceedsAuth    = "reversal_exceeds_authorization"
+	ErrCodeInvalidQuery           = "invalid_query"
+	ErrCodeInvalidMetadata        = "invalid_metadata"
+	ErrCodeIdempotencyKeyNotFound = "idempotency_key_not_found"
+	ErrCodeInternalError          = "internal_error"
 )
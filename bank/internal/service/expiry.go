@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+)
+
+// ExpiryService expires authorization holds and releases their funds
+type ExpiryService struct {
+	store     repository.Store
+	clock     clock.Clock
+	batchSize int
+}
+
+// NewExpiryService creates a new ExpiryService
+func NewExpiryService(store repository.Store, clk clock.Clock, batchSize int) *ExpiryService {
+	return &ExpiryService{
+		store:     store,
+		clock:     clk,
+		batchSize: batchSize,
+	}
+}
+
+// ExpireHolds expires one batch of authorization holds past their expires_at
+// and returns the number of holds released.
+// Holds locked by a concurrent sweeper or capture are skipped, so this is safe
+// to run on several replicas at once.
+func (s *ExpiryService) ExpireHolds(ctx context.Context) (int, error) {
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return 0, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to start transaction: %v", err),
+		}
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
+	}()
+
+	txTransactionRepo := tx.Transactions()
+	txAccountRepo := tx.Accounts()
+
+	expired, err := s.performExpiry(ctx, txTransactionRepo, txAccountRepo, s.clock.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}
+	}
+
+	return expired, nil
+}
+
+// performExpiry contains the core expiry business logic
+func (s *ExpiryService) performExpiry(
+	ctx context.Context,
+	transactionRepo repository.TransactionRepository,
+	accountRepo repository.AccountRepository,
+	now time.Time,
+) (int, error) {
+	holds, err := transactionRepo.FindExpiredHoldsForUpdate(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to find expired holds: %v", err),
+		}
+	}
+
+	for _, hold := range holds {
+		released := hold.RemainingAmountCents()
+
+		if err := transactionRepo.UpdateStatus(ctx, hold.ID, models.TransactionStatusExpired); err != nil {
+			return 0, &ServiceError{
+				Code:    ErrCodeInternalError,
+				Message: fmt.Sprintf("failed to expire authorization %s: %v", hold.ID, err),
+			}
+		}
+
+		if err := accountRepo.AdjustBalances(ctx, hold.AccountID, 0, released); err != nil {
+			return 0, &ServiceError{
+				Code:    ErrCodeInternalError,
+				Message: fmt.Sprintf("failed to release hold %s: %v", hold.ID, err),
+			}
+		}
+	}
+
+	return len(holds), nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/clock"
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryService_PerformExpiry(t *testing.T) {
+	t.Run("expires holds and releases funds", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewExpiryService(nil, clock.System, 50)
+		ctx := context.Background()
+		now := time.Now()
+
+		accountID := uuid.New()
+		holds := []*models.Transaction{
+			{
+				ID:          uuid.New(),
+				AccountID:   accountID,
+				Type:        models.TransactionTypeAuthHold,
+				AmountCents: 10000,
+				Status:      models.TransactionStatusActive,
+			},
+			{
+				ID:          uuid.New(),
+				AccountID:   accountID,
+				Type:        models.TransactionTypeAuthHold,
+				AmountCents: 2500,
+				Status:      models.TransactionStatusActive,
+			},
+		}
+
+		mockTxRepo.On("FindExpiredHoldsForUpdate", ctx, now, 50).Return(holds, nil)
+		mockTxRepo.On("UpdateStatus", ctx, holds[0].ID, models.TransactionStatusExpired).Return(nil)
+		mockTxRepo.On("UpdateStatus", ctx, holds[1].ID, models.TransactionStatusExpired).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(10000)).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(2500)).Return(nil)
+
+		expired, err := service.performExpiry(ctx, mockTxRepo, mockAccountRepo, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, expired)
+
+		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("no expired holds", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewExpiryService(nil, clock.System, 50)
+		ctx := context.Background()
+		now := time.Now()
+
+		mockTxRepo.On("FindExpiredHoldsForUpdate", ctx, now, 50).Return(nil, nil)
+
+		expired, err := service.performExpiry(ctx, mockTxRepo, mockAccountRepo, now)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, expired)
+
+		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertNotCalled(t, "AdjustBalances")
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewExpiryService(nil, clock.System, 50)
+		ctx := context.Background()
+		now := time.Now()
+
+		mockTxRepo.On("FindExpiredHoldsForUpdate", ctx, now, 50).Return(nil, errors.New("db error"))
+
+		expired, err := service.performExpiry(ctx, mockTxRepo, mockAccountRepo, now)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, expired)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInternalError, svcErr.Code)
+		}
+	})
+
+	t.Run("balance adjustment fails", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewExpiryService(nil, clock.System, 50)
+		ctx := context.Background()
+		now := time.Now()
+
+		hold := &models.Transaction{
+			ID:          uuid.New(),
+			AccountID:   uuid.New(),
+			Type:        models.TransactionTypeAuthHold,
+			AmountCents: 10000,
+			Status:      models.TransactionStatusActive,
+		}
+
+		mockTxRepo.On("FindExpiredHoldsForUpdate", ctx, now, 50).Return([]*models.Transaction{hold}, nil)
+		mockTxRepo.On("UpdateStatus", ctx, hold.ID, models.TransactionStatusExpired).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, hold.AccountID, int64(0), int64(10000)).Return(errors.New("db error"))
+
+		expired, err := service.performExpiry(ctx, mockTxRepo, mockAccountRepo, now)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, expired)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInternalError, svcErr.Code)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+)
+
+// IdempotencyService lets support engineers inspect and purge stored idempotency keys
+type IdempotencyService struct {
+	store repository.Store
+}
+
+// NewIdempotencyService creates a new IdempotencyService
+func NewIdempotencyService(store repository.Store) *IdempotencyService {
+	return &IdempotencyService{
+		store: store,
+	}
+}
+
+// GetIdempotencyKey returns every stored entry for a key, one per request path
+func (s *IdempotencyService) GetIdempotencyKey(ctx context.Context, key string) ([]*models.IdempotencyKey, error) {
+	return s.performGet(ctx, s.store.IdempotencyKeys(), key)
+}
+
+// PurgeIdempotencyKey deletes a stored key so its next request runs again.
+// An empty requestPath purges the key for every path.
+func (s *IdempotencyService) PurgeIdempotencyKey(ctx context.Context, key, requestPath string) error {
+	return s.performPurge(ctx, s.store.IdempotencyKeys(), key, requestPath)
+}
+
+// performGet contains the core key lookup logic
+func (s *IdempotencyService) performGet(
+	ctx context.Context,
+	idempotencyRepo repository.IdempotencyRepository,
+	key string,
+) ([]*models.IdempotencyKey, error) {
+	keys, err := idempotencyRepo.ListByKey(ctx, key)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to look up idempotency key: %v", err),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, &ServiceError{
+			Code:    ErrCodeIdempotencyKeyNotFound,
+			Message: "idempotency key not found",
+		}
+	}
+
+	return keys, nil
+}
+
+// performPurge contains the core key purge logic
+func (s *IdempotencyService) performPurge(
+	ctx context.Context,
+	idempotencyRepo repository.IdempotencyRepository,
+	key, requestPath string,
+) error {
+	// Keys are stored under the path without a trailing slash, see middleware.Idempotency
+	deleted, err := idempotencyRepo.Delete(ctx, key, strings.TrimSuffix(requestPath, "/"))
+	if err != nil {
+		return &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to purge idempotency key: %v", err),
+		}
+	}
+
+	if deleted == 0 {
+		return &ServiceError{
+			Code:    ErrCodeIdempotencyKeyNotFound,
+			Message: "idempotency key not found",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyService_PerformGet(t *testing.T) {
+	t.Run("returns stored entries", func(t *testing.T) {
+		mockRepo := mocks.NewMockIdempotencyRepository(t)
+		service := NewIdempotencyService(nil)
+		ctx := context.Background()
+
+		keys := []*models.IdempotencyKey{{Key: "support-key", RequestPath: "/api/v1/captures", ResponseStatus: 200}}
+		mockRepo.On("ListByKey", ctx, "support-key").Return(keys, nil)
+
+		result, err := service.performGet(ctx, mockRepo, "support-key")
+
+		require.NoError(t, err)
+		assert.Equal(t, keys, result)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockRepo := mocks.NewMockIdempotencyRepository(t)
+		service := NewIdempotencyService(nil)
+		ctx := context.Background()
+
+		mockRepo.On("ListByKey", ctx, "missing-key").Return(nil, nil)
+
+		result, err := service.performGet(ctx, mockRepo, "missing-key")
+
+		assert.Nil(t, result)
+		var svcErr *ServiceError
+		require.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, ErrCodeIdempotencyKeyNotFound, svcErr.Code)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo := mocks.NewMockIdempotencyRepository(t)
+		service := NewIdempotencyService(nil)
+		ctx := context.Background()
+
+		mockRepo.On("ListByKey", ctx, "support-key").Return(nil, errors.New("connection reset"))
+
+		result, err := service.performGet(ctx, mockRepo, "support-key")
+
+		assert.Nil(t, result)
+		var svcErr *ServiceError
+		require.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, ErrCodeInternalError, svcErr.Code)
+	})
+}
+
+func TestIdempotencyService_PerformPurge(t *testing.T) {
+	tests := []struct {
+		repoErr     error
+		name        string
+		requestPath string
+		storedPath  string
+		wantCode    string
+		deleted     int64
+	}{
+		{name: "all paths", deleted: 2},
+		{name: "one path", requestPath: "/api/v1/captures", storedPath: "/api/v1/captures", deleted: 1},
+		{name: "trailing slash is normalized", requestPath: "/api/v1/captures/", storedPath: "/api/v1/captures", deleted: 1},
+		{name: "unknown key", deleted: 0, wantCode: ErrCodeIdempotencyKeyNotFound},
+		{name: "repository error", repoErr: errors.New("connection reset"), wantCode: ErrCodeInternalError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIdempotencyRepository(t)
+			service := NewIdempotencyService(nil)
+			ctx := context.Background()
+
+			mockRepo.On("Delete", ctx, "purge-key", tt.storedPath).Return(tt.deleted, tt.repoErr)
+
+			err := service.performPurge(ctx, mockRepo, "purge-key", tt.requestPath)
+
+			if tt.wantCode == "" {
+				require.NoError(t, err)
+				return
+			}
+			var svcErr *ServiceError
+			require.ErrorAs(t, err, &svcErr)
+			assert.Equal(t, tt.wantCode, svcErr.Code)
+		})
+	}
+}
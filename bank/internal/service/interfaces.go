@@ -14,31 +14,48 @@ type HealthChecker interface {
 
 // Authorizer handles payment authorization operations
 type Authorizer interface {
-	Authorize(ctx context.Context, cardNumber, cvv string, amount int64) (*models.Transaction, error)
+	Authorize(ctx context.Context, cardNumber, cvv string, expiryMonth, expiryYear int, amount int64, metadata map[string]string) (*models.Transaction, error)
+	IncrementAuthorization(ctx context.Context, authID uuid.UUID, amount int64) (*models.Transaction, *models.Transaction, error)
 	GetAuthorization(ctx context.Context, authID uuid.UUID) (*models.Transaction, error)
+	GetAuthorizationHistory(ctx context.Context, authID uuid.UUID) ([]*models.Transaction, error)
 }
 
 // Capturer handles payment capture operations
 type Capturer interface {
-	Capture(ctx context.Context, authorizationID uuid.UUID, amount int64) (*models.Transaction, error)
+	Capture(ctx context.Context, authorizationID uuid.UUID, amount int64, finalCapture bool, metadata map[string]string) (*models.Transaction, error)
 	GetCapture(ctx context.Context, captureID uuid.UUID) (*models.Transaction, error)
 }
 
 // Voider handles authorization void operations
 type Voider interface {
-	Void(ctx context.Context, authorizationID uuid.UUID) (*models.Transaction, error)
+	Void(ctx context.Context, authorizationID uuid.UUID, metadata map[string]string) (*models.Transaction, error)
+	GetVoid(ctx context.Context, voidID uuid.UUID) (*models.Transaction, error)
+	Reverse(ctx context.Context, authorizationID uuid.UUID, amount int64) (*models.Transaction, *models.Transaction, error)
 }
 
 // Refunder handles refund operations
 type Refunder interface {
-	Refund(ctx context.Context, captureID uuid.UUID, amount int64) (*models.Transaction, error)
+	Refund(ctx context.Context, captureID uuid.UUID, amount int64, metadata map[string]string) (*models.Transaction, error)
 	GetRefund(ctx context.Context, refundID uuid.UUID) (*models.Transaction, error)
 }
 
+// TransactionLister lists ledger transactions for reconciliation
+type TransactionLister interface {
+	ListTransactions(ctx context.Context, query TransactionQuery) (*TransactionPage, error)
+}
+
+// IdempotencyKeyAdmin inspects and purges stored idempotency keys
+type IdempotencyKeyAdmin interface {
+	GetIdempotencyKey(ctx context.Context, key string) ([]*models.IdempotencyKey, error)
+	PurgeIdempotencyKey(ctx context.Context, key, requestPath string) error
+}
+
 // Ensure concrete types implement interfaces
 var (
-	_ Authorizer = (*AuthorizationService)(nil)
-	_ Capturer   = (*CaptureService)(nil)
-	_ Voider     = (*VoidService)(nil)
-	_ Refunder   = (*RefundService)(nil)
+	_ Authorizer          = (*AuthorizationService)(nil)
+	_ Capturer            = (*CaptureService)(nil)
+	_ Voider              = (*VoidService)(nil)
+	_ Refunder            = (*RefundService)(nil)
+	_ TransactionLister   = (*TransactionService)(nil)
+	_ IdempotencyKeyAdmin = (*IdempotencyService)(nil)
 )
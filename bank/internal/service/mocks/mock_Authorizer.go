@@ -24,9 +24,9 @@ func (_m *MockAuthorizer) EXPECT() *MockAuthorizer_Expecter {
 	return &MockAuthorizer_Expecter{mock: &_m.Mock}
 }
 
-// Authorize provides a mock function with given fields: ctx, cardNumber, cvv, amount
-func (_m *MockAuthorizer) Authorize(ctx context.Context, cardNumber string, cvv string, amount int64) (*models.Transaction, error) {
-	ret := _m.Called(ctx, cardNumber, cvv, amount)
+// Authorize provides a mock function with given fields: ctx, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata
+func (_m *MockAuthorizer) Authorize(ctx context.Context, cardNumber string, cvv string, expiryMonth int, expiryYear int, amount int64, metadata map[string]string) (*models.Transaction, error) {
+	ret := _m.Called(ctx, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Authorize")
@@ -34,19 +34,19 @@ func (_m *MockAuthorizer) Authorize(ctx context.Context, cardNumber string, cvv
 
 	var r0 *models.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) (*models.Transaction, error)); ok {
-		return rf(ctx, cardNumber, cvv, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int, int64, map[string]string) (*models.Transaction, error)); ok {
+		return rf(ctx, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) *models.Transaction); ok {
-		r0 = rf(ctx, cardNumber, cvv, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int, int64, map[string]string) *models.Transaction); ok {
+		r0 = rf(ctx, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
-		r1 = rf(ctx, cardNumber, cvv, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int, int64, map[string]string) error); ok {
+		r1 = rf(ctx, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,14 +63,17 @@ type MockAuthorizer_Authorize_Call struct {
 //   - ctx context.Context
 //   - cardNumber string
 //   - cvv string
+//   - expiryMonth int
+//   - expiryYear int
 //   - amount int64
-func (_e *MockAuthorizer_Expecter) Authorize(ctx interface{}, cardNumber interface{}, cvv interface{}, amount interface{}) *MockAuthorizer_Authorize_Call {
-	return &MockAuthorizer_Authorize_Call{Call: _e.mock.On("Authorize", ctx, cardNumber, cvv, amount)}
+//   - metadata map[string]string
+func (_e *MockAuthorizer_Expecter) Authorize(ctx interface{}, cardNumber interface{}, cvv interface{}, expiryMonth interface{}, expiryYear interface{}, amount interface{}, metadata interface{}) *MockAuthorizer_Authorize_Call {
+	return &MockAuthorizer_Authorize_Call{Call: _e.mock.On("Authorize", ctx, cardNumber, cvv, expiryMonth, expiryYear, amount, metadata)}
 }
 
-func (_c *MockAuthorizer_Authorize_Call) Run(run func(ctx context.Context, cardNumber string, cvv string, amount int64)) *MockAuthorizer_Authorize_Call {
+func (_c *MockAuthorizer_Authorize_Call) Run(run func(ctx context.Context, cardNumber string, cvv string, expiryMonth int, expiryYear int, amount int64, metadata map[string]string)) *MockAuthorizer_Authorize_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int64))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(int), args[4].(int), args[5].(int64), args[6].(map[string]string))
 	})
 	return _c
 }
@@ -80,7 +83,7 @@ func (_c *MockAuthorizer_Authorize_Call) Return(_a0 *models.Transaction, _a1 err
 	return _c
 }
 
-func (_c *MockAuthorizer_Authorize_Call) RunAndReturn(run func(context.Context, string, string, int64) (*models.Transaction, error)) *MockAuthorizer_Authorize_Call {
+func (_c *MockAuthorizer_Authorize_Call) RunAndReturn(run func(context.Context, string, string, int, int, int64, map[string]string) (*models.Transaction, error)) *MockAuthorizer_Authorize_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -144,6 +147,134 @@ func (_c *MockAuthorizer_GetAuthorization_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetAuthorizationHistory provides a mock function with given fields: ctx, authID
+func (_m *MockAuthorizer) GetAuthorizationHistory(ctx context.Context, authID uuid.UUID) ([]*models.Transaction, error) {
+	ret := _m.Called(ctx, authID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAuthorizationHistory")
+	}
+
+	var r0 []*models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*models.Transaction, error)); ok {
+		return rf(ctx, authID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*models.Transaction); ok {
+		r0 = rf(ctx, authID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, authID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAuthorizer_GetAuthorizationHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAuthorizationHistory'
+type MockAuthorizer_GetAuthorizationHistory_Call struct {
+	*mock.Call
+}
+
+// GetAuthorizationHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authID uuid.UUID
+func (_e *MockAuthorizer_Expecter) GetAuthorizationHistory(ctx interface{}, authID interface{}) *MockAuthorizer_GetAuthorizationHistory_Call {
+	return &MockAuthorizer_GetAuthorizationHistory_Call{Call: _e.mock.On("GetAuthorizationHistory", ctx, authID)}
+}
+
+func (_c *MockAuthorizer_GetAuthorizationHistory_Call) Run(run func(ctx context.Context, authID uuid.UUID)) *MockAuthorizer_GetAuthorizationHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockAuthorizer_GetAuthorizationHistory_Call) Return(_a0 []*models.Transaction, _a1 error) *MockAuthorizer_GetAuthorizationHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAuthorizer_GetAuthorizationHistory_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*models.Transaction, error)) *MockAuthorizer_GetAuthorizationHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementAuthorization provides a mock function with given fields: ctx, authID, amount
+func (_m *MockAuthorizer) IncrementAuthorization(ctx context.Context, authID uuid.UUID, amount int64) (*models.Transaction, *models.Transaction, error) {
+	ret := _m.Called(ctx, authID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementAuthorization")
+	}
+
+	var r0 *models.Transaction
+	var r1 *models.Transaction
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) (*models.Transaction, *models.Transaction, error)); ok {
+		return rf(ctx, authID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) *models.Transaction); ok {
+		r0 = rf(ctx, authID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) *models.Transaction); ok {
+		r1 = rf(ctx, authID, amount)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int64) error); ok {
+		r2 = rf(ctx, authID, amount)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockAuthorizer_IncrementAuthorization_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementAuthorization'
+type MockAuthorizer_IncrementAuthorization_Call struct {
+	*mock.Call
+}
+
+// IncrementAuthorization is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authID uuid.UUID
+//   - amount int64
+func (_e *MockAuthorizer_Expecter) IncrementAuthorization(ctx interface{}, authID interface{}, amount interface{}) *MockAuthorizer_IncrementAuthorization_Call {
+	return &MockAuthorizer_IncrementAuthorization_Call{Call: _e.mock.On("IncrementAuthorization", ctx, authID, amount)}
+}
+
+func (_c *MockAuthorizer_IncrementAuthorization_Call) Run(run func(ctx context.Context, authID uuid.UUID, amount int64)) *MockAuthorizer_IncrementAuthorization_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockAuthorizer_IncrementAuthorization_Call) Return(_a0 *models.Transaction, _a1 *models.Transaction, _a2 error) *MockAuthorizer_IncrementAuthorization_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockAuthorizer_IncrementAuthorization_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) (*models.Transaction, *models.Transaction, error)) *MockAuthorizer_IncrementAuthorization_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockAuthorizer creates a new instance of MockAuthorizer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAuthorizer(t interface {
@@ -24,9 +24,9 @@ func (_m *MockCapturer) EXPECT() *MockCapturer_Expecter {
 	return &MockCapturer_Expecter{mock: &_m.Mock}
 }
 
-// Capture provides a mock function with given fields: ctx, authorizationID, amount
-func (_m *MockCapturer) Capture(ctx context.Context, authorizationID uuid.UUID, amount int64) (*models.Transaction, error) {
-	ret := _m.Called(ctx, authorizationID, amount)
+// Capture provides a mock function with given fields: ctx, authorizationID, amount, finalCapture, metadata
+func (_m *MockCapturer) Capture(ctx context.Context, authorizationID uuid.UUID, amount int64, finalCapture bool, metadata map[string]string) (*models.Transaction, error) {
+	ret := _m.Called(ctx, authorizationID, amount, finalCapture, metadata)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Capture")
@@ -34,19 +34,19 @@ func (_m *MockCapturer) Capture(ctx context.Context, authorizationID uuid.UUID,
 
 	var r0 *models.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) (*models.Transaction, error)); ok {
-		return rf(ctx, authorizationID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, bool, map[string]string) (*models.Transaction, error)); ok {
+		return rf(ctx, authorizationID, amount, finalCapture, metadata)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) *models.Transaction); ok {
-		r0 = rf(ctx, authorizationID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, bool, map[string]string) *models.Transaction); ok {
+		r0 = rf(ctx, authorizationID, amount, finalCapture, metadata)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) error); ok {
-		r1 = rf(ctx, authorizationID, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64, bool, map[string]string) error); ok {
+		r1 = rf(ctx, authorizationID, amount, finalCapture, metadata)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -63,13 +63,15 @@ type MockCapturer_Capture_Call struct {
 //   - ctx context.Context
 //   - authorizationID uuid.UUID
 //   - amount int64
-func (_e *MockCapturer_Expecter) Capture(ctx interface{}, authorizationID interface{}, amount interface{}) *MockCapturer_Capture_Call {
-	return &MockCapturer_Capture_Call{Call: _e.mock.On("Capture", ctx, authorizationID, amount)}
+//   - finalCapture bool
+//   - metadata map[string]string
+func (_e *MockCapturer_Expecter) Capture(ctx interface{}, authorizationID interface{}, amount interface{}, finalCapture interface{}, metadata interface{}) *MockCapturer_Capture_Call {
+	return &MockCapturer_Capture_Call{Call: _e.mock.On("Capture", ctx, authorizationID, amount, finalCapture, metadata)}
 }
 
-func (_c *MockCapturer_Capture_Call) Run(run func(ctx context.Context, authorizationID uuid.UUID, amount int64)) *MockCapturer_Capture_Call {
+func (_c *MockCapturer_Capture_Call) Run(run func(ctx context.Context, authorizationID uuid.UUID, amount int64, finalCapture bool, metadata map[string]string)) *MockCapturer_Capture_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].(bool), args[4].(map[string]string))
 	})
 	return _c
 }
@@ -79,7 +81,7 @@ func (_c *MockCapturer_Capture_Call) Return(_a0 *models.Transaction, _a1 error)
 	return _c
 }
 
-func (_c *MockCapturer_Capture_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) (*models.Transaction, error)) *MockCapturer_Capture_Call {
+func (_c *MockCapturer_Capture_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64, bool, map[string]string) (*models.Transaction, error)) *MockCapturer_Capture_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,172 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	chaos "github.com/benx421/payment-gateway/bank/internal/chaos"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockChaosAdmin is an autogenerated mock type for the ChaosAdmin type
+type MockChaosAdmin struct {
+	mock.Mock
+}
+
+type MockChaosAdmin_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockChaosAdmin) EXPECT() *MockChaosAdmin_Expecter {
+	return &MockChaosAdmin_Expecter{mock: &_m.Mock}
+}
+
+// Reset provides a mock function with no fields
+func (_m *MockChaosAdmin) Reset() chaos.Settings {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 chaos.Settings
+	if rf, ok := ret.Get(0).(func() chaos.Settings); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(chaos.Settings)
+	}
+
+	return r0
+}
+
+// MockChaosAdmin_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockChaosAdmin_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+func (_e *MockChaosAdmin_Expecter) Reset() *MockChaosAdmin_Reset_Call {
+	return &MockChaosAdmin_Reset_Call{Call: _e.mock.On("Reset")}
+}
+
+func (_c *MockChaosAdmin_Reset_Call) Run(run func()) *MockChaosAdmin_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockChaosAdmin_Reset_Call) Return(_a0 chaos.Settings) *MockChaosAdmin_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChaosAdmin_Reset_Call) RunAndReturn(run func() chaos.Settings) *MockChaosAdmin_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Settings provides a mock function with no fields
+func (_m *MockChaosAdmin) Settings() chaos.Settings {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Settings")
+	}
+
+	var r0 chaos.Settings
+	if rf, ok := ret.Get(0).(func() chaos.Settings); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(chaos.Settings)
+	}
+
+	return r0
+}
+
+// MockChaosAdmin_Settings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Settings'
+type MockChaosAdmin_Settings_Call struct {
+	*mock.Call
+}
+
+// Settings is a helper method to define mock.On call
+func (_e *MockChaosAdmin_Expecter) Settings() *MockChaosAdmin_Settings_Call {
+	return &MockChaosAdmin_Settings_Call{Call: _e.mock.On("Settings")}
+}
+
+func (_c *MockChaosAdmin_Settings_Call) Run(run func()) *MockChaosAdmin_Settings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockChaosAdmin_Settings_Call) Return(_a0 chaos.Settings) *MockChaosAdmin_Settings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChaosAdmin_Settings_Call) RunAndReturn(run func() chaos.Settings) *MockChaosAdmin_Settings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: settings
+func (_m *MockChaosAdmin) Update(settings chaos.Settings) error {
+	ret := _m.Called(settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(chaos.Settings) error); ok {
+		r0 = rf(settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockChaosAdmin_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockChaosAdmin_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - settings chaos.Settings
+func (_e *MockChaosAdmin_Expecter) Update(settings interface{}) *MockChaosAdmin_Update_Call {
+	return &MockChaosAdmin_Update_Call{Call: _e.mock.On("Update", settings)}
+}
+
+func (_c *MockChaosAdmin_Update_Call) Run(run func(settings chaos.Settings)) *MockChaosAdmin_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(chaos.Settings))
+	})
+	return _c
+}
+
+func (_c *MockChaosAdmin_Update_Call) Return(_a0 error) *MockChaosAdmin_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockChaosAdmin_Update_Call) RunAndReturn(run func(chaos.Settings) error) *MockChaosAdmin_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockChaosAdmin creates a new instance of MockChaosAdmin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockChaosAdmin(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockChaosAdmin {
+	mock := &MockChaosAdmin{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
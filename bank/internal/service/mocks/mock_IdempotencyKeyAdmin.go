@@ -0,0 +1,144 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/benx421/payment-gateway/bank/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIdempotencyKeyAdmin is an autogenerated mock type for the IdempotencyKeyAdmin type
+type MockIdempotencyKeyAdmin struct {
+	mock.Mock
+}
+
+type MockIdempotencyKeyAdmin_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyKeyAdmin) EXPECT() *MockIdempotencyKeyAdmin_Expecter {
+	return &MockIdempotencyKeyAdmin_Expecter{mock: &_m.Mock}
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *MockIdempotencyKeyAdmin) GetIdempotencyKey(ctx context.Context, key string) ([]*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 []*models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*models.IdempotencyKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*models.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyKeyAdmin_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type MockIdempotencyKeyAdmin_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIdempotencyKeyAdmin_Expecter) GetIdempotencyKey(ctx interface{}, key interface{}) *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call {
+	return &MockIdempotencyKeyAdmin_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, key)}
+}
+
+func (_c *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call) Run(run func(ctx context.Context, key string)) *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call) Return(_a0 []*models.IdempotencyKey, _a1 error) *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call) RunAndReturn(run func(context.Context, string) ([]*models.IdempotencyKey, error)) *MockIdempotencyKeyAdmin_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeIdempotencyKey provides a mock function with given fields: ctx, key, requestPath
+func (_m *MockIdempotencyKeyAdmin) PurgeIdempotencyKey(ctx context.Context, key string, requestPath string) error {
+	ret := _m.Called(ctx, key, requestPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, key, requestPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeIdempotencyKey'
+type MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// PurgeIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - requestPath string
+func (_e *MockIdempotencyKeyAdmin_Expecter) PurgeIdempotencyKey(ctx interface{}, key interface{}, requestPath interface{}) *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call {
+	return &MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call{Call: _e.mock.On("PurgeIdempotencyKey", ctx, key, requestPath)}
+}
+
+func (_c *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call) Run(run func(ctx context.Context, key string, requestPath string)) *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call) Return(_a0 error) *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call) RunAndReturn(run func(context.Context, string, string) error) *MockIdempotencyKeyAdmin_PurgeIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyKeyAdmin creates a new instance of MockIdempotencyKeyAdmin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyKeyAdmin(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyKeyAdmin {
+	mock := &MockIdempotencyKeyAdmin{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
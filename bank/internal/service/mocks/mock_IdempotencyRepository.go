@@ -8,6 +8,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/benx421/payment-gateway/bank/internal/models"
+
+	time "time"
 )
 
 // MockIdempotencyRepository is an autogenerated mock type for the IdempotencyRepository type
@@ -83,6 +85,113 @@ func (_c *MockIdempotencyRepository_Get_Call) RunAndReturn(run func(context.Cont
 	return _c
 }
 
+// Release provides a mock function with given fields: ctx, key, requestPath, lockToken
+func (_m *MockIdempotencyRepository) Release(ctx context.Context, key string, requestPath string, lockToken string) error {
+	ret := _m.Called(ctx, key, requestPath, lockToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, key, requestPath, lockToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepository_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type MockIdempotencyRepository_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - requestPath string
+//   - lockToken string
+func (_e *MockIdempotencyRepository_Expecter) Release(ctx interface{}, key interface{}, requestPath interface{}, lockToken interface{}) *MockIdempotencyRepository_Release_Call {
+	return &MockIdempotencyRepository_Release_Call{Call: _e.mock.On("Release", ctx, key, requestPath, lockToken)}
+}
+
+func (_c *MockIdempotencyRepository_Release_Call) Run(run func(ctx context.Context, key string, requestPath string, lockToken string)) *MockIdempotencyRepository_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Release_Call) Return(_a0 error) *MockIdempotencyRepository_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Release_Call) RunAndReturn(run func(context.Context, string, string, string) error) *MockIdempotencyRepository_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, idemKey, lockTimeout
+func (_m *MockIdempotencyRepository) Reserve(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration) (bool, error) {
+	ret := _m.Called(ctx, idemKey, lockTimeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey, time.Duration) (bool, error)); ok {
+		return rf(ctx, idemKey, lockTimeout)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey, time.Duration) bool); ok {
+		r0 = rf(ctx, idemKey, lockTimeout)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.IdempotencyKey, time.Duration) error); ok {
+		r1 = rf(ctx, idemKey, lockTimeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepository_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type MockIdempotencyRepository_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idemKey *models.IdempotencyKey
+//   - lockTimeout time.Duration
+func (_e *MockIdempotencyRepository_Expecter) Reserve(ctx interface{}, idemKey interface{}, lockTimeout interface{}) *MockIdempotencyRepository_Reserve_Call {
+	return &MockIdempotencyRepository_Reserve_Call{Call: _e.mock.On("Reserve", ctx, idemKey, lockTimeout)}
+}
+
+func (_c *MockIdempotencyRepository_Reserve_Call) Run(run func(ctx context.Context, idemKey *models.IdempotencyKey, lockTimeout time.Duration)) *MockIdempotencyRepository_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.IdempotencyKey), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Reserve_Call) Return(_a0 bool, _a1 error) *MockIdempotencyRepository_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepository_Reserve_Call) RunAndReturn(run func(context.Context, *models.IdempotencyKey, time.Duration) (bool, error)) *MockIdempotencyRepository_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Store provides a mock function with given fields: ctx, idemKey
 func (_m *MockIdempotencyRepository) Store(ctx context.Context, idemKey *models.IdempotencyKey) error {
 	ret := _m.Called(ctx, idemKey)
@@ -0,0 +1,80 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	outage "github.com/benx421/payment-gateway/bank/internal/outage"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockOutageReporter is an autogenerated mock type for the OutageReporter type
+type MockOutageReporter struct {
+	mock.Mock
+}
+
+type MockOutageReporter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutageReporter) EXPECT() *MockOutageReporter_Expecter {
+	return &MockOutageReporter_Expecter{mock: &_m.Mock}
+}
+
+// Status provides a mock function with no fields
+func (_m *MockOutageReporter) Status() outage.Status {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 outage.Status
+	if rf, ok := ret.Get(0).(func() outage.Status); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(outage.Status)
+	}
+
+	return r0
+}
+
+// MockOutageReporter_Status_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Status'
+type MockOutageReporter_Status_Call struct {
+	*mock.Call
+}
+
+// Status is a helper method to define mock.On call
+func (_e *MockOutageReporter_Expecter) Status() *MockOutageReporter_Status_Call {
+	return &MockOutageReporter_Status_Call{Call: _e.mock.On("Status")}
+}
+
+func (_c *MockOutageReporter_Status_Call) Run(run func()) *MockOutageReporter_Status_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockOutageReporter_Status_Call) Return(_a0 outage.Status) *MockOutageReporter_Status_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockOutageReporter_Status_Call) RunAndReturn(run func() outage.Status) *MockOutageReporter_Status_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutageReporter creates a new instance of MockOutageReporter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutageReporter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutageReporter {
+	mock := &MockOutageReporter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,171 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	ratelimit "github.com/benx421/payment-gateway/bank/internal/ratelimit"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockRateLimitAdmin is an autogenerated mock type for the RateLimitAdmin type
+type MockRateLimitAdmin struct {
+	mock.Mock
+}
+
+type MockRateLimitAdmin_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRateLimitAdmin) EXPECT() *MockRateLimitAdmin_Expecter {
+	return &MockRateLimitAdmin_Expecter{mock: &_m.Mock}
+}
+
+// Reset provides a mock function with no fields
+func (_m *MockRateLimitAdmin) Reset() ratelimit.Settings {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reset")
+	}
+
+	var r0 ratelimit.Settings
+	if rf, ok := ret.Get(0).(func() ratelimit.Settings); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(ratelimit.Settings)
+	}
+
+	return r0
+}
+
+// MockRateLimitAdmin_Reset_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reset'
+type MockRateLimitAdmin_Reset_Call struct {
+	*mock.Call
+}
+
+// Reset is a helper method to define mock.On call
+func (_e *MockRateLimitAdmin_Expecter) Reset() *MockRateLimitAdmin_Reset_Call {
+	return &MockRateLimitAdmin_Reset_Call{Call: _e.mock.On("Reset")}
+}
+
+func (_c *MockRateLimitAdmin_Reset_Call) Run(run func()) *MockRateLimitAdmin_Reset_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRateLimitAdmin_Reset_Call) Return(_a0 ratelimit.Settings) *MockRateLimitAdmin_Reset_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRateLimitAdmin_Reset_Call) RunAndReturn(run func() ratelimit.Settings) *MockRateLimitAdmin_Reset_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Settings provides a mock function with no fields
+func (_m *MockRateLimitAdmin) Settings() ratelimit.Settings {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Settings")
+	}
+
+	var r0 ratelimit.Settings
+	if rf, ok := ret.Get(0).(func() ratelimit.Settings); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(ratelimit.Settings)
+	}
+
+	return r0
+}
+
+// MockRateLimitAdmin_Settings_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Settings'
+type MockRateLimitAdmin_Settings_Call struct {
+	*mock.Call
+}
+
+// Settings is a helper method to define mock.On call
+func (_e *MockRateLimitAdmin_Expecter) Settings() *MockRateLimitAdmin_Settings_Call {
+	return &MockRateLimitAdmin_Settings_Call{Call: _e.mock.On("Settings")}
+}
+
+func (_c *MockRateLimitAdmin_Settings_Call) Run(run func()) *MockRateLimitAdmin_Settings_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRateLimitAdmin_Settings_Call) Return(_a0 ratelimit.Settings) *MockRateLimitAdmin_Settings_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRateLimitAdmin_Settings_Call) RunAndReturn(run func() ratelimit.Settings) *MockRateLimitAdmin_Settings_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: settings
+func (_m *MockRateLimitAdmin) Update(settings ratelimit.Settings) error {
+	ret := _m.Called(settings)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(ratelimit.Settings) error); ok {
+		r0 = rf(settings)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRateLimitAdmin_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRateLimitAdmin_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - settings ratelimit.Settings
+func (_e *MockRateLimitAdmin_Expecter) Update(settings interface{}) *MockRateLimitAdmin_Update_Call {
+	return &MockRateLimitAdmin_Update_Call{Call: _e.mock.On("Update", settings)}
+}
+
+func (_c *MockRateLimitAdmin_Update_Call) Run(run func(settings ratelimit.Settings)) *MockRateLimitAdmin_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(ratelimit.Settings))
+	})
+	return _c
+}
+
+func (_c *MockRateLimitAdmin_Update_Call) Return(_a0 error) *MockRateLimitAdmin_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRateLimitAdmin_Update_Call) RunAndReturn(run func(ratelimit.Settings) error) *MockRateLimitAdmin_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRateLimitAdmin creates a new instance of MockRateLimitAdmin. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRateLimitAdmin(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRateLimitAdmin {
+	mock := &MockRateLimitAdmin{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
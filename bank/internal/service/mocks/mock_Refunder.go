@@ -83,9 +83,9 @@ func (_c *MockRefunder_GetRefund_Call) RunAndReturn(run func(context.Context, uu
 	return _c
 }
 
-// Refund provides a mock function with given fields: ctx, captureID, amount
-func (_m *MockRefunder) Refund(ctx context.Context, captureID uuid.UUID, amount int64) (*models.Transaction, error) {
-	ret := _m.Called(ctx, captureID, amount)
+// Refund provides a mock function with given fields: ctx, captureID, amount, metadata
+func (_m *MockRefunder) Refund(ctx context.Context, captureID uuid.UUID, amount int64, metadata map[string]string) (*models.Transaction, error) {
+	ret := _m.Called(ctx, captureID, amount, metadata)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Refund")
@@ -93,19 +93,19 @@ func (_m *MockRefunder) Refund(ctx context.Context, captureID uuid.UUID, amount
 
 	var r0 *models.Transaction
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) (*models.Transaction, error)); ok {
-		return rf(ctx, captureID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, map[string]string) (*models.Transaction, error)); ok {
+		return rf(ctx, captureID, amount, metadata)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) *models.Transaction); ok {
-		r0 = rf(ctx, captureID, amount)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, map[string]string) *models.Transaction); ok {
+		r0 = rf(ctx, captureID, amount, metadata)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Transaction)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) error); ok {
-		r1 = rf(ctx, captureID, amount)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64, map[string]string) error); ok {
+		r1 = rf(ctx, captureID, amount, metadata)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,13 +122,14 @@ type MockRefunder_Refund_Call struct {
 //   - ctx context.Context
 //   - captureID uuid.UUID
 //   - amount int64
-func (_e *MockRefunder_Expecter) Refund(ctx interface{}, captureID interface{}, amount interface{}) *MockRefunder_Refund_Call {
-	return &MockRefunder_Refund_Call{Call: _e.mock.On("Refund", ctx, captureID, amount)}
+//   - metadata map[string]string
+func (_e *MockRefunder_Expecter) Refund(ctx interface{}, captureID interface{}, amount interface{}, metadata interface{}) *MockRefunder_Refund_Call {
+	return &MockRefunder_Refund_Call{Call: _e.mock.On("Refund", ctx, captureID, amount, metadata)}
 }
 
-func (_c *MockRefunder_Refund_Call) Run(run func(ctx context.Context, captureID uuid.UUID, amount int64)) *MockRefunder_Refund_Call {
+func (_c *MockRefunder_Refund_Call) Run(run func(ctx context.Context, captureID uuid.UUID, amount int64, metadata map[string]string)) *MockRefunder_Refund_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].(map[string]string))
 	})
 	return _c
 }
@@ -138,7 +139,7 @@ func (_c *MockRefunder_Refund_Call) Return(_a0 *models.Transaction, _a1 error) *
 	return _c
 }
 
-func (_c *MockRefunder_Refund_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) (*models.Transaction, error)) *MockRefunder_Refund_Call {
+func (_c *MockRefunder_Refund_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64, map[string]string) (*models.Transaction, error)) *MockRefunder_Refund_Call {
 	_c.Call.Return(run)
 	return _c
 }
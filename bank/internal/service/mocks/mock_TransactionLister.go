@@ -0,0 +1,96 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	service "github.com/benx421/payment-gateway/bank/internal/service"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransactionLister is an autogenerated mock type for the TransactionLister type
+type MockTransactionLister struct {
+	mock.Mock
+}
+
+type MockTransactionLister_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionLister) EXPECT() *MockTransactionLister_Expecter {
+	return &MockTransactionLister_Expecter{mock: &_m.Mock}
+}
+
+// ListTransactions provides a mock function with given fields: ctx, query
+func (_m *MockTransactionLister) ListTransactions(ctx context.Context, query service.TransactionQuery) (*service.TransactionPage, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 *service.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, service.TransactionQuery) (*service.TransactionPage, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, service.TransactionQuery) *service.TransactionPage); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*service.TransactionPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, service.TransactionQuery) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionLister_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type MockTransactionLister_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query service.TransactionQuery
+func (_e *MockTransactionLister_Expecter) ListTransactions(ctx interface{}, query interface{}) *MockTransactionLister_ListTransactions_Call {
+	return &MockTransactionLister_ListTransactions_Call{Call: _e.mock.On("ListTransactions", ctx, query)}
+}
+
+func (_c *MockTransactionLister_ListTransactions_Call) Run(run func(ctx context.Context, query service.TransactionQuery)) *MockTransactionLister_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(service.TransactionQuery))
+	})
+	return _c
+}
+
+func (_c *MockTransactionLister_ListTransactions_Call) Return(_a0 *service.TransactionPage, _a1 error) *MockTransactionLister_ListTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionLister_ListTransactions_Call) RunAndReturn(run func(context.Context, service.TransactionQuery) (*service.TransactionPage, error)) *MockTransactionLister_ListTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactionLister creates a new instance of MockTransactionLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionLister {
+	mock := &MockTransactionLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
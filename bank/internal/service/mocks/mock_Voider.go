@@ -24,21 +24,21 @@ func (_m *MockVoider) EXPECT() *MockVoider_Expecter {
 	return &MockVoider_Expecter{mock: &_m.Mock}
 }
 
-// Void provides a mock function with given fields: ctx, authorizationID
-func (_m *MockVoider) Void(ctx context.Context, authorizationID uuid.UUID) (*models.Transaction, error) {
-	ret := _m.Called(ctx, authorizationID)
+// GetVoid provides a mock function with given fields: ctx, voidID
+func (_m *MockVoider) GetVoid(ctx context.Context, voidID uuid.UUID) (*models.Transaction, error) {
+	ret := _m.Called(ctx, voidID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Void")
+		panic("no return value specified for GetVoid")
 	}
 
 	var r0 *models.Transaction
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*models.Transaction, error)); ok {
-		return rf(ctx, authorizationID)
+		return rf(ctx, voidID)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *models.Transaction); ok {
-		r0 = rf(ctx, authorizationID)
+		r0 = rf(ctx, voidID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Transaction)
@@ -46,7 +46,135 @@ func (_m *MockVoider) Void(ctx context.Context, authorizationID uuid.UUID) (*mod
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, authorizationID)
+		r1 = rf(ctx, voidID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockVoider_GetVoid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVoid'
+type MockVoider_GetVoid_Call struct {
+	*mock.Call
+}
+
+// GetVoid is a helper method to define mock.On call
+//   - ctx context.Context
+//   - voidID uuid.UUID
+func (_e *MockVoider_Expecter) GetVoid(ctx interface{}, voidID interface{}) *MockVoider_GetVoid_Call {
+	return &MockVoider_GetVoid_Call{Call: _e.mock.On("GetVoid", ctx, voidID)}
+}
+
+func (_c *MockVoider_GetVoid_Call) Run(run func(ctx context.Context, voidID uuid.UUID)) *MockVoider_GetVoid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockVoider_GetVoid_Call) Return(_a0 *models.Transaction, _a1 error) *MockVoider_GetVoid_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockVoider_GetVoid_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Transaction, error)) *MockVoider_GetVoid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reverse provides a mock function with given fields: ctx, authorizationID, amount
+func (_m *MockVoider) Reverse(ctx context.Context, authorizationID uuid.UUID, amount int64) (*models.Transaction, *models.Transaction, error) {
+	ret := _m.Called(ctx, authorizationID, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reverse")
+	}
+
+	var r0 *models.Transaction
+	var r1 *models.Transaction
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) (*models.Transaction, *models.Transaction, error)); ok {
+		return rf(ctx, authorizationID, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) *models.Transaction); ok {
+		r0 = rf(ctx, authorizationID, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int64) *models.Transaction); ok {
+		r1 = rf(ctx, authorizationID, amount)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, int64) error); ok {
+		r2 = rf(ctx, authorizationID, amount)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockVoider_Reverse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reverse'
+type MockVoider_Reverse_Call struct {
+	*mock.Call
+}
+
+// Reverse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - authorizationID uuid.UUID
+//   - amount int64
+func (_e *MockVoider_Expecter) Reverse(ctx interface{}, authorizationID interface{}, amount interface{}) *MockVoider_Reverse_Call {
+	return &MockVoider_Reverse_Call{Call: _e.mock.On("Reverse", ctx, authorizationID, amount)}
+}
+
+func (_c *MockVoider_Reverse_Call) Run(run func(ctx context.Context, authorizationID uuid.UUID, amount int64)) *MockVoider_Reverse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockVoider_Reverse_Call) Return(_a0 *models.Transaction, _a1 *models.Transaction, _a2 error) *MockVoider_Reverse_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockVoider_Reverse_Call) RunAndReturn(run func(context.Context, uuid.UUID, int64) (*models.Transaction, *models.Transaction, error)) *MockVoider_Reverse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Void provides a mock function with given fields: ctx, authorizationID, metadata
+func (_m *MockVoider) Void(ctx context.Context, authorizationID uuid.UUID, metadata map[string]string) (*models.Transaction, error) {
+	ret := _m.Called(ctx, authorizationID, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Void")
+	}
+
+	var r0 *models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]string) (*models.Transaction, error)); ok {
+		return rf(ctx, authorizationID, metadata)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]string) *models.Transaction); ok {
+		r0 = rf(ctx, authorizationID, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]string) error); ok {
+		r1 = rf(ctx, authorizationID, metadata)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +190,14 @@ type MockVoider_Void_Call struct {
 // Void is a helper method to define mock.On call
 //   - ctx context.Context
 //   - authorizationID uuid.UUID
-func (_e *MockVoider_Expecter) Void(ctx interface{}, authorizationID interface{}) *MockVoider_Void_Call {
-	return &MockVoider_Void_Call{Call: _e.mock.On("Void", ctx, authorizationID)}
+//   - metadata map[string]string
+func (_e *MockVoider_Expecter) Void(ctx interface{}, authorizationID interface{}, metadata interface{}) *MockVoider_Void_Call {
+	return &MockVoider_Void_Call{Call: _e.mock.On("Void", ctx, authorizationID, metadata)}
 }
 
-func (_c *MockVoider_Void_Call) Run(run func(ctx context.Context, authorizationID uuid.UUID)) *MockVoider_Void_Call {
+func (_c *MockVoider_Void_Call) Run(run func(ctx context.Context, authorizationID uuid.UUID, metadata map[string]string)) *MockVoider_Void_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(map[string]string))
 	})
 	return _c
 }
@@ -78,7 +207,7 @@ func (_c *MockVoider_Void_Call) Return(_a0 *models.Transaction, _a1 error) *Mock
 	return _c
 }
 
-func (_c *MockVoider_Void_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*models.Transaction, error)) *MockVoider_Void_Call {
+func (_c *MockVoider_Void_Call) RunAndReturn(run func(context.Context, uuid.UUID, map[string]string) (*models.Transaction, error)) *MockVoider_Void_Call {
 	_c.Call.Return(run)
 	return _c
 }
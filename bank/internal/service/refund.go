@@ -3,11 +3,9 @@ package service
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
-	"time"
 
-	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository"
 	"github.com/google/uuid"
@@ -15,19 +13,23 @@ import (
 
 // RefundService handles refund operations
 type RefundService struct {
-	db *db.DB
+	store repository.Store
+	clock clock.Clock
 }
 
 // NewRefundService creates a new RefundService
-func NewRefundService(database *db.DB) *RefundService {
+func NewRefundService(store repository.Store, clk clock.Clock) *RefundService {
 	return &RefundService{
-		db: database,
+		store: store,
+		clock: clk,
 	}
 }
 
-// Refund refunds a captured payment
-func (s *RefundService) Refund(ctx context.Context, captureID uuid.UUID, amount int64) (*models.Transaction, error) {
-	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+// Refund refunds all or part of a captured payment.
+// A capture can be refunded several times as long as the refunds add up to no
+// more than the captured amount.
+func (s *RefundService) Refund(ctx context.Context, captureID uuid.UUID, amount int64, metadata map[string]string) (*models.Transaction, error) {
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
@@ -38,10 +40,10 @@ func (s *RefundService) Refund(ctx context.Context, captureID uuid.UUID, amount
 		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
 	}()
 
-	txTransactionRepo := repository.NewTransactionRepository(tx)
-	txAccountRepo := repository.NewAccountRepository(tx)
+	txTransactionRepo := tx.Transactions()
+	txAccountRepo := tx.Accounts()
 
-	refundTxn, err := s.performRefund(ctx, txTransactionRepo, txAccountRepo, captureID, amount)
+	refundTxn, err := s.performRefund(ctx, txTransactionRepo, txAccountRepo, captureID, amount, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +65,22 @@ func (s *RefundService) performRefund(
 	accountRepo repository.AccountRepository,
 	captureID uuid.UUID,
 	amount int64,
+	metadata map[string]string,
 ) (*models.Transaction, error) {
+	if err := ValidateAmount(amount); err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidAmount,
+			Message: err.Error(),
+		}
+	}
+
+	if err := ValidateMetadata(metadata); err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidMetadata,
+			Message: err.Error(),
+		}
+	}
+
 	captureTxn, err := transactionRepo.FindByIDForUpdate(ctx, captureID)
 	if err != nil || captureTxn.Type != models.TransactionTypeCapture {
 		return nil, &ServiceError{
@@ -79,16 +96,24 @@ func (s *RefundService) performRefund(
 		}
 	}
 
-	if amount != captureTxn.AmountCents {
+	refundable := captureTxn.RefundableAmountCents()
+	if refundable <= 0 {
+		return nil, &ServiceError{
+			Code:    ErrCodeAlreadyRefunded,
+			Message: "capture has already been fully refunded",
+		}
+	}
+
+	if amount > refundable {
 		return nil, &ServiceError{
-			Code: ErrCodeAmountMismatch,
-			Message: fmt.Sprintf("refund amount (%d) must equal capture amount (%d)",
-				amount, captureTxn.AmountCents),
+			Code: ErrCodeRefundExceedsCapture,
+			Message: fmt.Sprintf("refund amount (%d) exceeds remaining refundable amount (%d)",
+				amount, refundable),
 		}
 	}
 
 	refundID := uuid.New()
-	refundedAt := time.Now()
+	refundedAt := s.clock.Now()
 
 	refundTxn := &models.Transaction{
 		ID:          refundID,
@@ -98,19 +123,21 @@ func (s *RefundService) performRefund(
 		Currency:    captureTxn.Currency,
 		ReferenceID: &captureID,
 		Status:      models.TransactionStatusCompleted,
+		Metadata:    metadata,
 		CreatedAt:   refundedAt,
 	}
 
 	if err := transactionRepo.Create(ctx, refundTxn); err != nil {
-		if errors.Is(err, models.ErrDuplicateTransaction) {
-			return nil, &ServiceError{
-				Code:    ErrCodeAlreadyRefunded,
-				Message: "capture has already been refunded",
-			}
-		}
 		return nil, fmt.Errorf("failed to create refund: %w", err)
 	}
 
+	if err := transactionRepo.AddRefundedAmount(ctx, captureID, amount); err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to update capture: %v", err),
+		}
+	}
+
 	if err := accountRepo.AdjustBalances(ctx, captureTxn.AccountID, amount, amount); err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
@@ -123,11 +150,11 @@ func (s *RefundService) performRefund(
 
 // GetRefund retrieves a refund by ID
 func (s *RefundService) GetRefund(ctx context.Context, refundID uuid.UUID) (*models.Transaction, error) {
-	repo := repository.NewTransactionRepository(s.db)
+	repo := s.store.Transactions()
 	txn, err := repo.FindByID(ctx, refundID)
 	if err != nil || txn.Type != models.TransactionTypeRefund {
 		return nil, &ServiceError{
-			Code:    "refund_not_found",
+			Code:    ErrCodeRefundNotFound,
 			Message: "refund not found",
 		}
 	}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
 	"github.com/google/uuid"
@@ -16,7 +17,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 	t.Run("successful refund", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -34,9 +35,10 @@ func TestRefundService_PerformRefund(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddRefundedAmount", ctx, captureID, int64(10000)).Return(nil)
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(10000), int64(10000)).Return(nil)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -52,7 +54,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 	t.Run("capture not found", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -60,7 +62,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(nil, sql.ErrNoRows)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -76,7 +78,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 	t.Run("wrong transaction type", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -94,7 +96,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(authTx, nil)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -110,7 +112,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 	t.Run("capture not completed", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -127,7 +129,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -140,44 +142,129 @@ func TestRefundService_PerformRefund(t *testing.T) {
 		mockTxRepo.AssertExpectations(t)
 	})
 
-	t.Run("amount mismatch", func(t *testing.T) {
+	t.Run("refund exceeds remaining refundable amount", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
 		accountID := uuid.New()
-		var captureAmount int64 = 10000
-		var refundAmount int64 = 5000 // Different amount
 
 		captureTx := &models.Transaction{
-			ID:          captureID,
-			AccountID:   accountID,
-			Type:        models.TransactionTypeCapture,
-			AmountCents: captureAmount,
-			Status:      models.TransactionStatusCompleted,
+			ID:                  captureID,
+			AccountID:           accountID,
+			Type:                models.TransactionTypeCapture,
+			AmountCents:         10000,
+			RefundedAmountCents: 6000,
+			Status:              models.TransactionStatusCompleted,
+		}
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
+
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, 5000, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeRefundExceedsCapture, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewRefundService(nil, clock.System)
+		ctx := context.Background()
+
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, uuid.New(), 0, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInvalidAmount, svcErr.Code)
+		}
+	})
+
+	t.Run("already fully refunded", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewRefundService(nil, clock.System)
+		ctx := context.Background()
+
+		captureID := uuid.New()
+		accountID := uuid.New()
+		var amount int64 = 10000
+
+		captureTx := &models.Transaction{
+			ID:                  captureID,
+			AccountID:           accountID,
+			Type:                models.TransactionTypeCapture,
+			AmountCents:         amount,
+			RefundedAmountCents: amount,
+			Status:              models.TransactionStatusCompleted,
 		}
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, refundAmount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, 1000, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 
 		var svcErr *ServiceError
 		if assert.ErrorAs(t, err, &svcErr) {
-			assert.Equal(t, ErrCodeAmountMismatch, svcErr.Code)
+			assert.Equal(t, ErrCodeAlreadyRefunded, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("partial refund of a partly refunded capture", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewRefundService(nil, clock.System)
+		ctx := context.Background()
+
+		captureID := uuid.New()
+		accountID := uuid.New()
+
+		captureTx := &models.Transaction{
+			ID:                  captureID,
+			AccountID:           accountID,
+			Type:                models.TransactionTypeCapture,
+			AmountCents:         10000,
+			RefundedAmountCents: 6000,
+			Currency:            "USD",
+			Status:              models.TransactionStatusCompleted,
 		}
 
+		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddRefundedAmount", ctx, captureID, int64(4000)).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(4000), int64(4000)).Return(nil)
+
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, 4000, nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, int64(4000), result.AmountCents)
+		assert.Equal(t, captureID, *result.ReferenceID)
+
 		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
 	})
 
-	t.Run("already refunded - duplicate error", func(t *testing.T) {
+	t.Run("updating refunded total fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -193,17 +280,17 @@ func TestRefundService_PerformRefund(t *testing.T) {
 		}
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
-		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).
-			Return(models.ErrDuplicateTransaction)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddRefundedAmount", ctx, captureID, amount).Return(assert.AnError)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 
 		var svcErr *ServiceError
 		if assert.ErrorAs(t, err, &svcErr) {
-			assert.Equal(t, ErrCodeAlreadyRefunded, svcErr.Code)
+			assert.Equal(t, ErrCodeInternalError, svcErr.Code)
 		}
 
 		mockTxRepo.AssertExpectations(t)
@@ -212,7 +299,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 	t.Run("transaction creation fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -231,7 +318,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).
 			Return(assert.AnError)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -243,7 +330,7 @@ func TestRefundService_PerformRefund(t *testing.T) {
 	t.Run("balance adjustment fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewRefundService(nil)
+		service := NewRefundService(nil, clock.System)
 		ctx := context.Background()
 
 		captureID := uuid.New()
@@ -260,10 +347,11 @@ func TestRefundService_PerformRefund(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, captureID).Return(captureTx, nil)
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddRefundedAmount", ctx, captureID, int64(10000)).Return(nil)
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(10000), int64(10000)).
 			Return(assert.AnError)
 
-		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount)
+		result, err := service.performRefund(ctx, mockTxRepo, mockAccountRepo, captureID, amount, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
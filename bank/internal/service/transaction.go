@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultTransactionPageSize is used when a listing does not ask for a page size
+	DefaultTransactionPageSize = 50
+	// MaxTransactionPageSize caps how many transactions a single page can return
+	MaxTransactionPageSize = 100
+)
+
+// TransactionQuery describes which transactions to list and which page to return.
+// Zero-valued filters are not applied.
+type TransactionQuery struct {
+	CreatedFrom   *time.Time // inclusive
+	CreatedTo     *time.Time // exclusive
+	AccountNumber string
+	MetadataKey   string
+	MetadataValue string
+	Type          models.TransactionType
+	Status        models.TransactionStatus
+	Cursor        string
+	Limit         int
+	ReferenceID   uuid.UUID
+}
+
+// TransactionPage is one page of a transaction listing.
+// NextCursor is empty when there are no more results.
+type TransactionPage struct {
+	NextCursor   string
+	Transactions []*models.Transaction
+}
+
+// TransactionService handles read-only queries over the transaction ledger
+type TransactionService struct {
+	store repository.Store
+}
+
+// NewTransactionService creates a new TransactionService
+func NewTransactionService(store repository.Store) *TransactionService {
+	return &TransactionService{
+		store: store,
+	}
+}
+
+// ListTransactions returns one page of transactions matching the query, oldest first
+func (s *TransactionService) ListTransactions(ctx context.Context, query TransactionQuery) (*TransactionPage, error) {
+	return s.performList(ctx, s.store.Accounts(), s.store.Transactions(), query)
+}
+
+// performList contains the core listing logic
+func (s *TransactionService) performList(
+	ctx context.Context,
+	accountRepo repository.AccountRepository,
+	transactionRepo repository.TransactionRepository,
+	query TransactionQuery,
+) (*TransactionPage, error) {
+	limit := query.Limit
+	if limit == 0 {
+		limit = DefaultTransactionPageSize
+	}
+	if limit < 1 || limit > MaxTransactionPageSize {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidQuery,
+			Message: fmt.Sprintf("limit must be between 1 and %d", MaxTransactionPageSize),
+		}
+	}
+
+	if query.CreatedFrom != nil && query.CreatedTo != nil && !query.CreatedFrom.Before(*query.CreatedTo) {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidQuery,
+			Message: "created_from must be before created_to",
+		}
+	}
+
+	if query.MetadataValue != "" && query.MetadataKey == "" {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidQuery,
+			Message: "metadata_value requires metadata_key",
+		}
+	}
+
+	filter := repository.TransactionFilter{
+		CreatedFrom:   query.CreatedFrom,
+		CreatedTo:     query.CreatedTo,
+		ReferenceID:   query.ReferenceID,
+		MetadataKey:   query.MetadataKey,
+		MetadataValue: query.MetadataValue,
+		Type:          query.Type,
+		Status:        query.Status,
+		Limit:         limit + 1,
+	}
+
+	if query.Cursor != "" {
+		cursor, err := DecodeTransactionCursor(query.Cursor)
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    ErrCodeInvalidQuery,
+				Message: "invalid cursor",
+				Err:     err,
+			}
+		}
+		filter.After = cursor
+	}
+
+	if query.AccountNumber != "" {
+		account, err := accountRepo.FindByAccountNumber(ctx, query.AccountNumber)
+		if errors.Is(err, sql.ErrNoRows) {
+			return &TransactionPage{Transactions: []*models.Transaction{}}, nil
+		}
+		if err != nil {
+			return nil, &ServiceError{
+				Code:    ErrCodeInternalError,
+				Message: fmt.Sprintf("failed to find account: %v", err),
+			}
+		}
+		filter.AccountID = account.ID
+	}
+
+	txns, err := transactionRepo.List(ctx, filter)
+	if err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to list transactions: %v", err),
+		}
+	}
+
+	page := &TransactionPage{Transactions: txns}
+	if page.Transactions == nil {
+		page.Transactions = []*models.Transaction{}
+	}
+	if len(txns) > limit {
+		page.Transactions = txns[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = EncodeTransactionCursor(repository.TransactionCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+	}
+
+	return page, nil
+}
+
+// EncodeTransactionCursor turns a page position into an opaque cursor string
+func EncodeTransactionCursor(cursor repository.TransactionCursor) string {
+	raw := cursor.CreatedAt.Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeTransactionCursor parses a cursor produced by EncodeTransactionCursor
+func DecodeTransactionCursor(cursor string) (*repository.TransactionCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, errors.New("malformed cursor")
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor time: %w", err)
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor id: %w", err)
+	}
+
+	return &repository.TransactionCursor{CreatedAt: parsedTime, ID: parsedID}, nil
+}
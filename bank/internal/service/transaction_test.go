@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benx421/payment-gateway/bank/internal/models"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionService_PerformList(t *testing.T) {
+	newTxns := func(n int) []*models.Transaction {
+		base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		txns := make([]*models.Transaction, n)
+		for i := range txns {
+			txns[i] = &models.Transaction{
+				ID:        uuid.New(),
+				Type:      models.TransactionTypeAuthHold,
+				CreatedAt: base.Add(time.Duration(i) * time.Minute),
+			}
+		}
+		return txns
+	}
+
+	t.Run("default page size and no next cursor on last page", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewTransactionService(nil)
+		ctx := context.Background()
+
+		txns := newTxns(3)
+		mockTxRepo.On("List", ctx, repository.TransactionFilter{Limit: DefaultTransactionPageSize + 1}).Return(txns, nil)
+
+		page, err := service.performList(ctx, mockAccountRepo, mockTxRepo, TransactionQuery{})
+
+		require.NoError(t, err)
+		assert.Equal(t, txns, page.Transactions)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("next cursor points at last row of a full page", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewTransactionService(nil)
+		ctx := context.Background()
+
+		txns := newTxns(3)
+		mockTxRepo.On("List", ctx, repository.TransactionFilter{Limit: 3}).Return(txns, nil)
+
+		page, err := service.performList(ctx, mockAccountRepo, mockTxRepo, TransactionQuery{Limit: 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, txns[:2], page.Transactions)
+		require.NotEmpty(t, page.NextCursor)
+
+		cursor, err := DecodeTransactionCursor(page.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, txns[1].ID, cursor.ID)
+		assert.True(t, txns[1].CreatedAt.Equal(cursor.CreatedAt))
+	})
+
+	t.Run("cursor and filters are passed to the repository", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewTransactionService(nil)
+		ctx := context.Background()
+
+		accountID := uuid.New()
+		refID := uuid.New()
+		from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := from.Add(24 * time.Hour)
+		after := repository.TransactionCursor{CreatedAt: from.Add(time.Hour), ID: uuid.New()}
+
+		mockAccountRepo.On("FindByAccountNumber", ctx, "4111111111111111").
+			Return(&models.Account{ID: accountID}, nil)
+		mockTxRepo.On("List", ctx, repository.TransactionFilter{
+			CreatedFrom: &from,
+			CreatedTo:   &to,
+			After:       &after,
+			AccountID:   accountID,
+			ReferenceID: refID,
+			Type:        models.TransactionTypeCapture,
+			Status:      models.TransactionStatusCompleted,
+			Limit:       11,
+		}).Return([]*models.Transaction{}, nil)
+
+		page, err := service.performList(ctx, mockAccountRepo, mockTxRepo, TransactionQuery{
+			CreatedFrom:   &from,
+			CreatedTo:     &to,
+			AccountNumber: "4111111111111111",
+			ReferenceID:   refID,
+			Type:          models.TransactionTypeCapture,
+			Status:        models.TransactionStatusCompleted,
+			Cursor:        EncodeTransactionCursor(after),
+			Limit:         10,
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, page.Transactions)
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("unknown account returns empty page", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewTransactionService(nil)
+		ctx := context.Background()
+
+		mockAccountRepo.On("FindByAccountNumber", ctx, "4000000000000002").
+			Return(nil, fmt.Errorf("account not found: %w", sql.ErrNoRows))
+
+		page, err := service.performList(ctx, mockAccountRepo, mockTxRepo, TransactionQuery{AccountNumber: "4000000000000002"})
+
+		require.NoError(t, err)
+		assert.NotNil(t, page.Transactions)
+		assert.Empty(t, page.Transactions)
+		mockTxRepo.AssertNotCalled(t, "List")
+	})
+
+	t.Run("invalid queries", func(t *testing.T) {
+		from := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+		to := from.Add(-time.Hour)
+
+		tests := []struct {
+			name  string
+			query TransactionQuery
+		}{
+			{name: "limit too large", query: TransactionQuery{Limit: MaxTransactionPageSize + 1}},
+			{name: "negative limit", query: TransactionQuery{Limit: -1}},
+			{name: "empty time range", query: TransactionQuery{CreatedFrom: &from, CreatedTo: &to}},
+			{name: "malformed cursor", query: TransactionQuery{Cursor: "not a cursor!"}},
+			{name: "metadata value without key", query: TransactionQuery{MetadataValue: "ord_123"}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				service := NewTransactionService(nil)
+
+				page, err := service.performList(context.Background(),
+					mocks.NewMockAccountRepository(t), mocks.NewMockTransactionRepository(t), tt.query)
+
+				assert.Nil(t, page)
+				var svcErr *ServiceError
+				require.ErrorAs(t, err, &svcErr)
+				assert.Equal(t, ErrCodeInvalidQuery, svcErr.Code)
+			})
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewTransactionService(nil)
+		ctx := context.Background()
+
+		mockTxRepo.On("List", ctx, repository.TransactionFilter{Limit: DefaultTransactionPageSize + 1}).
+			Return(nil, errors.New("connection reset"))
+
+		page, err := service.performList(ctx, mockAccountRepo, mockTxRepo, TransactionQuery{})
+
+		assert.Nil(t, page)
+		var svcErr *ServiceError
+		require.ErrorAs(t, err, &svcErr)
+		assert.Equal(t, ErrCodeInternalError, svcErr.Code)
+	})
+}
+
+func TestTransactionCursor_RoundTrip(t *testing.T) {
+	cursor := repository.TransactionCursor{
+		CreatedAt: time.Date(2026, 3, 4, 5, 6, 7, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+
+	decoded, err := DecodeTransactionCursor(EncodeTransactionCursor(cursor))
+
+	require.NoError(t, err)
+	assert.Equal(t, cursor.ID, decoded.ID)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+}
@@ -42,13 +42,12 @@ func ValidateLuhn(cardNumber string) error {
 	return nil
 }
 
-// ValidateExpiry checks if a card has expired
-func ValidateExpiry(expiryMonth, expiryYear int) error {
+// ValidateExpiry checks if a card has expired as of now
+func ValidateExpiry(expiryMonth, expiryYear int, now time.Time) error {
 	if expiryMonth < 1 || expiryMonth > 12 {
 		return fmt.Errorf("invalid month: must be between 1 and 12")
 	}
 
-	now := time.Now()
 	currentYear := now.Year()
 	currentMonth := int(now.Month())
 
@@ -86,3 +85,28 @@ func ValidateAmount(amount int64) error {
 
 	return nil
 }
+
+// Metadata limits keep merchant-supplied key/value pairs small enough to index and return with every response
+const (
+	MaxMetadataKeys        = 20
+	MaxMetadataKeyLength   = 40
+	MaxMetadataValueLength = 500
+)
+
+// ValidateMetadata checks that metadata stays within the size limits
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataKeys {
+		return fmt.Errorf("invalid metadata: at most %d keys allowed", MaxMetadataKeys)
+	}
+
+	for key, value := range metadata {
+		if key == "" || len(key) > MaxMetadataKeyLength {
+			return fmt.Errorf("invalid metadata: keys must be 1-%d characters", MaxMetadataKeyLength)
+		}
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("invalid metadata: value for %q exceeds %d characters", key, MaxMetadataValueLength)
+		}
+	}
+
+	return nil
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -132,11 +135,24 @@ func TestValidateExpiry(t *testing.T) {
 			expiryYear:  2020,
 			wantErr:     true,
 		},
+		{
+			name:        "expires this month",
+			expiryMonth: 6,
+			expiryYear:  2026,
+			wantErr:     false,
+		},
+		{
+			name:        "expired last month",
+			expiryMonth: 5,
+			expiryYear:  2026,
+			wantErr:     true,
+		},
 	}
 
+	now := time.Date(2026, time.June, 15, 12, 0, 0, 0, time.UTC)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateExpiry(tt.expiryMonth, tt.expiryYear)
+			err := ValidateExpiry(tt.expiryMonth, tt.expiryYear, now)
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -185,3 +201,58 @@ func TestValidateAmount(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateMetadata(t *testing.T) {
+	tooManyKeys := make(map[string]string, MaxMetadataKeys+1)
+	for i := 0; i <= MaxMetadataKeys; i++ {
+		tooManyKeys[fmt.Sprintf("key_%d", i)] = "value"
+	}
+
+	tests := []struct {
+		metadata map[string]string
+		name     string
+		wantErr  bool
+	}{
+		{
+			name:     "nil metadata",
+			metadata: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "valid metadata",
+			metadata: map[string]string{"order_id": "ord_123", "customer_id": "cus_456"},
+			wantErr:  false,
+		},
+		{
+			name:     "too many keys",
+			metadata: tooManyKeys,
+			wantErr:  true,
+		},
+		{
+			name:     "empty key",
+			metadata: map[string]string{"": "value"},
+			wantErr:  true,
+		},
+		{
+			name:     "key too long",
+			metadata: map[string]string{strings.Repeat("k", MaxMetadataKeyLength+1): "value"},
+			wantErr:  true,
+		},
+		{
+			name:     "value too long",
+			metadata: map[string]string{"order_id": strings.Repeat("v", MaxMetadataValueLength+1)},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMetadata(tt.metadata)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
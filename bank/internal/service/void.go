@@ -5,9 +5,8 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"time"
 
-	"github.com/benx421/payment-gateway/bank/internal/db"
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository"
 	"github.com/google/uuid"
@@ -15,19 +14,21 @@ import (
 
 // VoidService handles authorization void operations
 type VoidService struct {
-	db *db.DB
+	store repository.Store
+	clock clock.Clock
 }
 
 // NewVoidService creates a new VoidService
-func NewVoidService(database *db.DB) *VoidService {
+func NewVoidService(store repository.Store, clk clock.Clock) *VoidService {
 	return &VoidService{
-		db: database,
+		store: store,
+		clock: clk,
 	}
 }
 
 // Void cancels an authorization before it's captured
-func (s *VoidService) Void(ctx context.Context, authorizationID uuid.UUID) (*models.Transaction, error) {
-	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+func (s *VoidService) Void(ctx context.Context, authorizationID uuid.UUID, metadata map[string]string) (*models.Transaction, error) {
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
@@ -38,10 +39,10 @@ func (s *VoidService) Void(ctx context.Context, authorizationID uuid.UUID) (*mod
 		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
 	}()
 
-	txTransactionRepo := repository.NewTransactionRepository(tx)
-	txAccountRepo := repository.NewAccountRepository(tx)
+	txTransactionRepo := tx.Transactions()
+	txAccountRepo := tx.Accounts()
 
-	voidTxn, err := s.performVoid(ctx, txTransactionRepo, txAccountRepo, authorizationID)
+	voidTxn, err := s.performVoid(ctx, txTransactionRepo, txAccountRepo, authorizationID, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +63,15 @@ func (s *VoidService) performVoid(
 	transactionRepo repository.TransactionRepository,
 	accountRepo repository.AccountRepository,
 	authorizationID uuid.UUID,
+	metadata map[string]string,
 ) (*models.Transaction, error) {
+	if err := ValidateMetadata(metadata); err != nil {
+		return nil, &ServiceError{
+			Code:    ErrCodeInvalidMetadata,
+			Message: err.Error(),
+		}
+	}
+
 	authTxn, err := transactionRepo.FindByIDForUpdate(ctx, authorizationID)
 	if err != nil || authTxn.Type != models.TransactionTypeAuthHold {
 		return nil, &ServiceError{
@@ -71,6 +80,13 @@ func (s *VoidService) performVoid(
 		}
 	}
 
+	if authTxn.Status == models.TransactionStatusExpired {
+		return nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
 	if authTxn.Status != models.TransactionStatusActive {
 		return nil, &ServiceError{
 			Code:    ErrCodeAuthAlreadyUsed,
@@ -78,6 +94,13 @@ func (s *VoidService) performVoid(
 		}
 	}
 
+	if authTxn.ExpiresAt != nil && s.clock.Now().After(*authTxn.ExpiresAt) {
+		return nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
 	existingCapture, err := transactionRepo.FindByReferenceID(ctx, authorizationID, models.TransactionTypeCapture)
 	if err != nil {
 		return nil, &ServiceError{
@@ -92,17 +115,21 @@ func (s *VoidService) performVoid(
 		}
 	}
 
+	// Partial reversals may already have released part of the hold
+	released := authTxn.RemainingAmountCents()
+
 	voidID := uuid.New()
-	voidedAt := time.Now()
+	voidedAt := s.clock.Now()
 
 	voidTxn := &models.Transaction{
 		ID:          voidID,
 		AccountID:   authTxn.AccountID,
 		Type:        models.TransactionTypeVoid,
-		AmountCents: authTxn.AmountCents,
+		AmountCents: released,
 		Currency:    authTxn.Currency,
 		ReferenceID: &authorizationID,
 		Status:      models.TransactionStatusCompleted,
+		Metadata:    metadata,
 		CreatedAt:   voidedAt,
 	}
 
@@ -123,7 +150,7 @@ func (s *VoidService) performVoid(
 		}
 	}
 
-	if err := accountRepo.AdjustBalances(ctx, authTxn.AccountID, 0, authTxn.AmountCents); err != nil {
+	if err := accountRepo.AdjustBalances(ctx, authTxn.AccountID, 0, released); err != nil {
 		return nil, &ServiceError{
 			Code:    ErrCodeInternalError,
 			Message: fmt.Sprintf("failed to adjust balance: %v", err),
@@ -132,3 +159,152 @@ func (s *VoidService) performVoid(
 
 	return voidTxn, nil
 }
+
+// GetVoid retrieves a void by ID
+func (s *VoidService) GetVoid(ctx context.Context, voidID uuid.UUID) (*models.Transaction, error) {
+	repo := s.store.Transactions()
+	txn, err := repo.FindByID(ctx, voidID)
+	if err != nil || txn.Type != models.TransactionTypeVoid {
+		return nil, &ServiceError{
+			Code:    ErrCodeVoidNotFound,
+			Message: "void not found",
+		}
+	}
+
+	return txn, nil
+}
+
+// Reverse releases part of an active authorization hold back to the account's
+// available balance. The rest of the hold stays capturable; reversing everything
+// that is left completes the authorization like a void.
+// It returns the reversal and the updated authorization hold.
+func (s *VoidService) Reverse(
+	ctx context.Context,
+	authorizationID uuid.UUID,
+	amount int64,
+) (*models.Transaction, *models.Transaction, error) {
+	tx, err := s.store.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to start transaction: %v", err),
+		}
+	}
+	defer func() {
+		_ = tx.Rollback() //nolint:errcheck // rollback error is not critical in defer
+	}()
+
+	txTransactionRepo := tx.Transactions()
+	txAccountRepo := tx.Accounts()
+
+	reversalTxn, authTxn, err := s.performReversal(ctx, txTransactionRepo, txAccountRepo, authorizationID, amount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to commit transaction: %v", err),
+		}
+	}
+
+	return reversalTxn, authTxn, nil
+}
+
+// performReversal contains the core partial reversal business logic
+func (s *VoidService) performReversal(
+	ctx context.Context,
+	transactionRepo repository.TransactionRepository,
+	accountRepo repository.AccountRepository,
+	authorizationID uuid.UUID,
+	amount int64,
+) (*models.Transaction, *models.Transaction, error) {
+	if err := ValidateAmount(amount); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInvalidAmount,
+			Message: err.Error(),
+		}
+	}
+
+	authTxn, err := transactionRepo.FindByIDForUpdate(ctx, authorizationID)
+	if err != nil || authTxn.Type != models.TransactionTypeAuthHold {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthNotFound,
+			Message: "authorization not found",
+		}
+	}
+
+	if authTxn.Status == models.TransactionStatusExpired {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
+	if authTxn.Status != models.TransactionStatusActive {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthAlreadyUsed,
+			Message: "authorization has already been completed or cancelled",
+		}
+	}
+
+	if authTxn.ExpiresAt != nil && s.clock.Now().After(*authTxn.ExpiresAt) {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeAuthExpired,
+			Message: "authorization has expired",
+		}
+	}
+
+	remaining := authTxn.RemainingAmountCents()
+	if amount > remaining {
+		return nil, nil, &ServiceError{
+			Code: ErrCodeReversalExceedsAuth,
+			Message: fmt.Sprintf("reversal amount (%d) exceeds remaining authorized amount (%d)",
+				amount, remaining),
+		}
+	}
+
+	reversalTxn := &models.Transaction{
+		ID:          uuid.New(),
+		AccountID:   authTxn.AccountID,
+		Type:        models.TransactionTypeAuthReversal,
+		AmountCents: amount,
+		Currency:    authTxn.Currency,
+		ReferenceID: &authorizationID,
+		Status:      models.TransactionStatusCompleted,
+		CreatedAt:   s.clock.Now(),
+	}
+
+	if err := transactionRepo.Create(ctx, reversalTxn); err != nil {
+		return nil, nil, fmt.Errorf("failed to create reversal: %w", err)
+	}
+
+	if err := transactionRepo.AddReversedAmount(ctx, authorizationID, amount); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to update authorization: %v", err),
+		}
+	}
+
+	authTxn.ReversedAmountCents += amount
+
+	if amount == remaining {
+		if err := transactionRepo.UpdateStatus(ctx, authorizationID, models.TransactionStatusCompleted); err != nil {
+			return nil, nil, &ServiceError{
+				Code:    ErrCodeInternalError,
+				Message: fmt.Sprintf("failed to update authorization: %v", err),
+			}
+		}
+		authTxn.Status = models.TransactionStatusCompleted
+	}
+
+	if err := accountRepo.AdjustBalances(ctx, authTxn.AccountID, 0, amount); err != nil {
+		return nil, nil, &ServiceError{
+			Code:    ErrCodeInternalError,
+			Message: fmt.Sprintf("failed to adjust balance: %v", err),
+		}
+	}
+
+	return reversalTxn, authTxn, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/models"
 	"github.com/benx421/payment-gateway/bank/internal/repository/mocks"
 	"github.com/google/uuid"
@@ -16,7 +18,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("successful void", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -38,7 +40,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).Return(nil)
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(10000)).Return(nil)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -54,14 +56,14 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("authorization not found", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(nil, sql.ErrNoRows)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -77,7 +79,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("wrong transaction type", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -95,7 +97,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(captureTx, nil)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -111,7 +113,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("authorization already used", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -128,7 +130,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -144,7 +146,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("authorization already captured", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -172,7 +174,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 		mockTxRepo.On("FindByReferenceID", ctx, authID, models.TransactionTypeCapture).Return(existingCapture, nil)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -188,7 +190,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("check existing capture fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -206,7 +208,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
 		mockTxRepo.On("FindByReferenceID", ctx, authID, models.TransactionTypeCapture).Return(nil, assert.AnError)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -222,7 +224,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("already voided - duplicate error", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -242,7 +244,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).
 			Return(models.ErrDuplicateTransaction)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -258,7 +260,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("status update fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -279,7 +281,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).
 			Return(assert.AnError)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -295,7 +297,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 	t.Run("balance adjustment fails", func(t *testing.T) {
 		mockTxRepo := mocks.NewMockTransactionRepository(t)
 		mockAccountRepo := mocks.NewMockAccountRepository(t)
-		service := NewVoidService(nil)
+		service := NewVoidService(nil, clock.System)
 		ctx := context.Background()
 
 		authID := uuid.New()
@@ -317,7 +319,7 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(10000)).
 			Return(assert.AnError)
 
-		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID)
+		result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -331,3 +333,223 @@ func TestVoidService_PerformVoid(t *testing.T) {
 		mockAccountRepo.AssertExpectations(t)
 	})
 }
+
+func TestVoidService_PerformVoid_AfterPartialReversal(t *testing.T) {
+	mockTxRepo := mocks.NewMockTransactionRepository(t)
+	mockAccountRepo := mocks.NewMockAccountRepository(t)
+	service := NewVoidService(nil, clock.System)
+	ctx := context.Background()
+
+	authID := uuid.New()
+	accountID := uuid.New()
+
+	authTx := &models.Transaction{
+		ID:                  authID,
+		AccountID:           accountID,
+		Type:                models.TransactionTypeAuthHold,
+		AmountCents:         10000,
+		ReversedAmountCents: 3000,
+		Status:              models.TransactionStatusActive,
+	}
+
+	mockTxRepo.On("FindByIDForUpdate", ctx, authID).Return(authTx, nil)
+	mockTxRepo.On("FindByReferenceID", ctx, authID, models.TransactionTypeCapture).Return(nil, nil)
+	mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+	mockTxRepo.On("UpdateStatus", ctx, authID, models.TransactionStatusCompleted).Return(nil)
+	mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(7000)).Return(nil)
+
+	result, err := service.performVoid(ctx, mockTxRepo, mockAccountRepo, authID, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7000), result.AmountCents)
+
+	mockTxRepo.AssertExpectations(t)
+	mockAccountRepo.AssertExpectations(t)
+}
+
+func TestVoidService_PerformReversal(t *testing.T) {
+	newHold := func(accountID uuid.UUID) *models.Transaction {
+		return &models.Transaction{
+			ID:                  uuid.New(),
+			AccountID:           accountID,
+			Type:                models.TransactionTypeAuthHold,
+			AmountCents:         10000,
+			CapturedAmountCents: 2000,
+			Currency:            "USD",
+			Status:              models.TransactionStatusActive,
+		}
+	}
+
+	t.Run("partial reversal keeps hold active", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewVoidService(nil, clock.System)
+		ctx := context.Background()
+
+		accountID := uuid.New()
+		hold := newHold(accountID)
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddReversedAmount", ctx, hold.ID, int64(3000)).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(3000)).Return(nil)
+
+		reversal, auth, err := service.performReversal(ctx, mockTxRepo, mockAccountRepo, hold.ID, 3000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.TransactionTypeAuthReversal, reversal.Type)
+		assert.Equal(t, int64(3000), reversal.AmountCents)
+		assert.Equal(t, hold.ID, *reversal.ReferenceID)
+		assert.Equal(t, models.TransactionStatusActive, auth.Status)
+		assert.Equal(t, int64(5000), auth.RemainingAmountCents())
+
+		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("reversing the remainder completes the hold", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewVoidService(nil, clock.System)
+		ctx := context.Background()
+
+		accountID := uuid.New()
+		hold := newHold(accountID)
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddReversedAmount", ctx, hold.ID, int64(8000)).Return(nil)
+		mockTxRepo.On("UpdateStatus", ctx, hold.ID, models.TransactionStatusCompleted).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, accountID, int64(0), int64(8000)).Return(nil)
+
+		_, auth, err := service.performReversal(ctx, mockTxRepo, mockAccountRepo, hold.ID, 8000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.TransactionStatusCompleted, auth.Status)
+
+		mockTxRepo.AssertExpectations(t)
+		mockAccountRepo.AssertExpectations(t)
+	})
+
+	t.Run("reversal exceeds remaining amount", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewVoidService(nil, clock.System)
+		ctx := context.Background()
+
+		hold := newHold(uuid.New())
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+
+		reversal, auth, err := service.performReversal(ctx, mockTxRepo, mockAccountRepo, hold.ID, 8001)
+
+		assert.Error(t, err)
+		assert.Nil(t, reversal)
+		assert.Nil(t, auth)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeReversalExceedsAuth, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("authorization expired", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewVoidService(nil, clock.System)
+		ctx := context.Background()
+
+		hold := newHold(uuid.New())
+		hold.Status = models.TransactionStatusExpired
+
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+
+		_, _, err := service.performReversal(ctx, mockTxRepo, mockAccountRepo, hold.ID, 1000)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeAuthExpired, svcErr.Code)
+		}
+
+		mockTxRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		service := NewVoidService(nil, clock.System)
+
+		_, _, err := service.performReversal(context.Background(), mockTxRepo, mockAccountRepo, uuid.New(), -5)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeInvalidAmount, svcErr.Code)
+		}
+	})
+}
+
+// frozenClock is a clock.Clock stopped at one instant
+type frozenClock time.Time
+
+func (c frozenClock) Now() time.Time { return time.Time(c) }
+
+func TestVoidService_ExpiredBeforeSweep(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	expiresAt := now.Add(-time.Second)
+	newHold := func() *models.Transaction {
+		// Past its expiry, but the sweeper has not marked it expired yet
+		return &models.Transaction{
+			ID:          uuid.New(),
+			AccountID:   uuid.New(),
+			Type:        models.TransactionTypeAuthHold,
+			AmountCents: 10000,
+			Currency:    "USD",
+			Status:      models.TransactionStatusActive,
+			ExpiresAt:   &expiresAt,
+		}
+	}
+	service := NewVoidService(nil, frozenClock(now))
+	ctx := context.Background()
+
+	t.Run("void", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		hold := newHold()
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+
+		_, err := service.performVoid(ctx, mockTxRepo, mocks.NewMockAccountRepository(t), hold.ID, nil)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeAuthExpired, svcErr.Code)
+		}
+	})
+
+	t.Run("reversal", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		hold := newHold()
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+
+		_, _, err := service.performReversal(ctx, mockTxRepo, mocks.NewMockAccountRepository(t), hold.ID, 3000)
+
+		var svcErr *ServiceError
+		if assert.ErrorAs(t, err, &svcErr) {
+			assert.Equal(t, ErrCodeAuthExpired, svcErr.Code)
+		}
+	})
+
+	t.Run("reversal just before expiry", func(t *testing.T) {
+		mockTxRepo := mocks.NewMockTransactionRepository(t)
+		mockAccountRepo := mocks.NewMockAccountRepository(t)
+		hold := newHold()
+		mockTxRepo.On("FindByIDForUpdate", ctx, hold.ID).Return(hold, nil)
+		mockTxRepo.On("Create", ctx, mock.AnythingOfType("*models.Transaction")).Return(nil)
+		mockTxRepo.On("AddReversedAmount", ctx, hold.ID, int64(3000)).Return(nil)
+		mockAccountRepo.On("AdjustBalances", ctx, hold.AccountID, int64(0), int64(3000)).Return(nil)
+
+		_, _, err := NewVoidService(nil, frozenClock(expiresAt)).performReversal(ctx, mockTxRepo, mockAccountRepo, hold.ID, 3000)
+
+		assert.NoError(t, err)
+	})
+}
@@ -2,9 +2,14 @@
 package tests
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"sync"
 	"testing"
 
@@ -63,6 +68,45 @@ func TestAuthorizeAndVoid(t *testing.T) {
 	assert.Equal(t, authID, voidBody["authorization_id"])
 }
 
+func TestGetVoid(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 20000, "get-void-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	voidResp := ts.Void(t, authID, "get-void-1")
+	require.Equal(t, http.StatusOK, voidResp.StatusCode)
+
+	var voidBody map[string]any
+	require.NoError(t, json.NewDecoder(voidResp.Body).Decode(&voidBody))
+	voidResp.Body.Close()
+	voidID := voidBody["void_id"].(string)
+
+	resp, err := http.Get(ts.URL("/api/v1/voids/" + voidID))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var getBody map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&getBody))
+	resp.Body.Close()
+
+	assert.Equal(t, voidID, getBody["void_id"])
+	assert.Equal(t, authID, getBody["authorization_id"])
+	assert.Equal(t, float64(20000), getBody["amount"])
+
+	// An authorization ID is not a void
+	resp, err = http.Get(ts.URL("/api/v1/voids/void_" + authID[len("auth_"):]))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
 func TestFullFlow_AuthorizeCaptureRefund(t *testing.T) {
 	ts := SetupTest(t)
 	defer ts.Close()
@@ -137,6 +181,33 @@ func TestAuthorization_ExpiredCard(t *testing.T) {
 	assert.Equal(t, "card_expired", body["error"])
 }
 
+func TestAuthorization_InvalidExpiry(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	body, _ := json.Marshal(map[string]any{
+		"card_number":  "4111111111111111",
+		"cvv":          "123",
+		"expiry_month": 11, // Card on file expires 12/2030
+		"expiry_year":  2030,
+		"amount":       10000,
+	})
+	req, err := http.NewRequest(http.MethodPost, ts.URL("/api/v1/authorizations"), bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "invalid-expiry-key")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var errBody map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errBody))
+	resp.Body.Close()
+
+	assert.Equal(t, "invalid_expiry", errBody["error"])
+}
+
 func TestAuthorization_InsufficientFunds(t *testing.T) {
 	ts := SetupTest(t)
 	defer ts.Close()
@@ -177,6 +248,193 @@ func TestCapture_AuthorizationAlreadyUsed(t *testing.T) {
 	assert.Equal(t, "authorization_already_used", body["error"])
 }
 
+func TestCapture_MultiplePartialCaptures(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 10000, "partial-cap-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	cap1 := ts.Capture(t, authID, 4000, "partial-cap-1")
+	require.Equal(t, http.StatusOK, cap1.StatusCode)
+	cap1.Body.Close()
+
+	resp, err := http.Get(ts.URL("/api/v1/authorizations/" + authID))
+	require.NoError(t, err)
+	var getBody map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&getBody))
+	resp.Body.Close()
+
+	assert.Equal(t, float64(4000), getBody["captured_amount"])
+	assert.Equal(t, float64(6000), getBody["remaining_amount"])
+
+	cap2 := ts.Capture(t, authID, 7000, "partial-cap-2")
+	require.Equal(t, http.StatusBadRequest, cap2.StatusCode)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(cap2.Body).Decode(&body))
+	cap2.Body.Close()
+	assert.Equal(t, "capture_exceeds_authorization", body["error"])
+
+	cap3 := ts.Capture(t, authID, 6000, "partial-cap-3")
+	require.Equal(t, http.StatusOK, cap3.StatusCode)
+	cap3.Body.Close()
+
+	cap4 := ts.Capture(t, authID, 1, "partial-cap-4")
+	require.Equal(t, http.StatusBadRequest, cap4.StatusCode)
+	cap4.Body.Close()
+}
+
+func TestRefund_MultiplePartialRefunds(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 10000, "partial-ref-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	captureResp := ts.Capture(t, authID, 10000, "partial-ref-cap")
+	require.Equal(t, http.StatusOK, captureResp.StatusCode)
+
+	var captureBody map[string]any
+	require.NoError(t, json.NewDecoder(captureResp.Body).Decode(&captureBody))
+	captureResp.Body.Close()
+	captureID := captureBody["capture_id"].(string)
+
+	ref1 := ts.Refund(t, captureID, 3000, "partial-ref-1")
+	require.Equal(t, http.StatusOK, ref1.StatusCode)
+	var ref1Body map[string]any
+	require.NoError(t, json.NewDecoder(ref1.Body).Decode(&ref1Body))
+	ref1.Body.Close()
+
+	ref2 := ts.Refund(t, captureID, 2000, "partial-ref-2")
+	require.Equal(t, http.StatusOK, ref2.StatusCode)
+	var ref2Body map[string]any
+	require.NoError(t, json.NewDecoder(ref2.Body).Decode(&ref2Body))
+	ref2.Body.Close()
+
+	assert.Contains(t, ref1Body["refund_id"].(string), "ref_")
+	assert.NotEqual(t, ref1Body["refund_id"], ref2Body["refund_id"])
+
+	resp, err := http.Get(ts.URL("/api/v1/captures/" + captureID))
+	require.NoError(t, err)
+	var getBody map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&getBody))
+	resp.Body.Close()
+
+	assert.Equal(t, float64(5000), getBody["refunded_amount"])
+	assert.Equal(t, float64(5000), getBody["refundable_amount"])
+
+	ref3 := ts.Refund(t, captureID, 6000, "partial-ref-3")
+	require.Equal(t, http.StatusBadRequest, ref3.StatusCode)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(ref3.Body).Decode(&body))
+	ref3.Body.Close()
+	assert.Equal(t, "refund_exceeds_capture", body["error"])
+}
+
+func TestAuthorization_Increment(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4242424242424242", "456", 10000, "increment-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	resp, err := http.Get(ts.URL("/api/v1/authorizations/" + authID))
+	require.NoError(t, err)
+	var before map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&before))
+	resp.Body.Close()
+
+	incResp := ts.Increment(t, authID, 2500, "increment-1")
+	require.Equal(t, http.StatusOK, incResp.StatusCode)
+
+	var incBody map[string]any
+	require.NoError(t, json.NewDecoder(incResp.Body).Decode(&incBody))
+	incResp.Body.Close()
+
+	assert.Contains(t, incBody["increment_id"].(string), "inc_")
+	assert.Equal(t, "incremented", incBody["status"])
+	assert.Equal(t, float64(12500), incBody["authorized_amount"])
+	assert.Equal(t, before["expires_at"], incBody["expires_at"])
+
+	// Account 4242... holds $500, so another $400 exceeds what is still available
+	overResp := ts.Increment(t, authID, 40000, "increment-2")
+	require.Equal(t, http.StatusPaymentRequired, overResp.StatusCode)
+	overResp.Body.Close()
+
+	captureResp := ts.Capture(t, authID, 12500, "increment-cap")
+	require.Equal(t, http.StatusOK, captureResp.StatusCode)
+	captureResp.Body.Close()
+}
+
+func TestAuthorization_PartialReversals(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 10000, "reversal-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	rev1 := ts.Reverse(t, authID, 2000, "reversal-1")
+	require.Equal(t, http.StatusOK, rev1.StatusCode)
+	var rev1Body map[string]any
+	require.NoError(t, json.NewDecoder(rev1.Body).Decode(&rev1Body))
+	rev1.Body.Close()
+
+	assert.Contains(t, rev1Body["reversal_id"].(string), "rev_")
+	assert.Equal(t, float64(8000), rev1Body["remaining_amount"])
+
+	rev2 := ts.Reverse(t, authID, 1000, "reversal-2")
+	require.Equal(t, http.StatusOK, rev2.StatusCode)
+	rev2.Body.Close()
+
+	over := ts.Reverse(t, authID, 7001, "reversal-3")
+	require.Equal(t, http.StatusBadRequest, over.StatusCode)
+	var overBody map[string]any
+	require.NoError(t, json.NewDecoder(over.Body).Decode(&overBody))
+	over.Body.Close()
+	assert.Equal(t, "reversal_exceeds_authorization", overBody["error"])
+
+	captureResp := ts.Capture(t, authID, 7000, "reversal-cap")
+	require.Equal(t, http.StatusOK, captureResp.StatusCode)
+	captureResp.Body.Close()
+
+	resp, err := http.Get(ts.URL("/api/v1/authorizations/" + authID))
+	require.NoError(t, err)
+	var getBody map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&getBody))
+	resp.Body.Close()
+
+	assert.Equal(t, float64(3000), getBody["reversed_amount"])
+	assert.Equal(t, float64(0), getBody["remaining_amount"])
+
+	history := getBody["history"].([]any)
+	require.Len(t, history, 3)
+	assert.Equal(t, "reversal", history[0].(map[string]any)["type"])
+	assert.Equal(t, "reversal", history[1].(map[string]any)["type"])
+	assert.Equal(t, "capture", history[2].(map[string]any)["type"])
+}
+
 func TestVoid_AfterCapture(t *testing.T) {
 	ts := SetupTest(t)
 	defer ts.Close()
@@ -224,6 +482,80 @@ func TestIdempotency_ReplaysSameResponse(t *testing.T) {
 	assert.Equal(t, "true", resp2.Header.Get("X-Idempotent-Replayed"))
 }
 
+func TestIdempotency_ReplaysDecline(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	resp1 := ts.Authorize(t, "5555555555554444", "789", 100, "decline-replay-key") // Balance: $0
+	require.Equal(t, http.StatusPaymentRequired, resp1.StatusCode)
+	body1, _ := io.ReadAll(resp1.Body)
+	resp1.Body.Close()
+
+	// Top up the account: re-running the authorization would now succeed
+	_, err := ts.Database.ExecContext(context.Background(),
+		"UPDATE accounts SET balance_cents = 10000, available_balance_cents = 10000 WHERE account_number = '5555555555554444'")
+	require.NoError(t, err)
+
+	resp2 := ts.Authorize(t, "5555555555554444", "789", 100, "decline-replay-key")
+	require.Equal(t, http.StatusPaymentRequired, resp2.StatusCode)
+	body2, _ := io.ReadAll(resp2.Body)
+	resp2.Body.Close()
+
+	assert.Equal(t, string(body1), string(body2))
+	assert.Equal(t, "true", resp2.Header.Get("X-Idempotent-Replayed"))
+}
+
+func TestIdempotency_AdminInspectAndPurge(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	resp := ts.Authorize(t, "4111111111111111", "123", 10000, "admin-key")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	authBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	getResp, err := http.Get(ts.URL("/admin/idempotency-keys/admin-key"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, getResp.StatusCode)
+
+	var record struct {
+		Data []struct {
+			RequestPath    string `json:"request_path"`
+			RequestHash    string `json:"request_hash"`
+			ResponseBody   string `json:"response_body"`
+			ResponseStatus int    `json:"response_status"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(getResp.Body).Decode(&record))
+	getResp.Body.Close()
+
+	require.Len(t, record.Data, 1)
+	assert.Equal(t, "/api/v1/authorizations", record.Data[0].RequestPath)
+	assert.Equal(t, http.StatusOK, record.Data[0].ResponseStatus)
+	assert.Equal(t, string(authBody), record.Data[0].ResponseBody)
+	assert.NotEmpty(t, record.Data[0].RequestHash)
+
+	req, err := http.NewRequest(http.MethodDelete, ts.URL("/admin/idempotency-keys/admin-key"), nil)
+	require.NoError(t, err)
+	delResp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	delResp.Body.Close()
+	require.Equal(t, http.StatusNoContent, delResp.StatusCode)
+
+	// After a purge the same key runs again instead of replaying
+	retry := ts.Authorize(t, "4111111111111111", "123", 10000, "admin-key")
+	require.Equal(t, http.StatusOK, retry.StatusCode)
+	retryBody, _ := io.ReadAll(retry.Body)
+	retry.Body.Close()
+	assert.Empty(t, retry.Header.Get("X-Idempotent-Replayed"))
+	assert.NotEqual(t, string(authBody), string(retryBody))
+
+	missing, err := http.Get(ts.URL("/admin/idempotency-keys/never-used"))
+	require.NoError(t, err)
+	missing.Body.Close()
+	assert.Equal(t, http.StatusNotFound, missing.StatusCode)
+}
+
 func TestIdempotency_DifferentKeysCreateDifferentAuthorizations(t *testing.T) {
 	ts := SetupTest(t)
 	defer ts.Close()
@@ -289,6 +621,52 @@ func TestConcurrentCaptures_OnlyOneSucceeds(t *testing.T) {
 	assert.Equal(t, numGoroutines-1, failCount, "all others should fail")
 }
 
+func TestConcurrentCaptures_SameIdempotencyKey(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 10000, "same-key-cap-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	const numGoroutines = 10
+	var wg sync.WaitGroup
+	captureIDs := make(chan string, numGoroutines)
+
+	for range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := ts.Capture(t, authID, 10000, "same-key-cap")
+			defer resp.Body.Close()
+
+			// Duplicates either wait for the original response or are told to retry
+			if resp.StatusCode == http.StatusConflict {
+				assert.NotEmpty(t, resp.Header.Get("Retry-After"))
+				return
+			}
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var body map[string]any
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			captureIDs <- body["capture_id"].(string)
+		}()
+	}
+
+	wg.Wait()
+	close(captureIDs)
+
+	seen := map[string]bool{}
+	for id := range captureIDs {
+		seen[id] = true
+	}
+	assert.Len(t, seen, 1, "every successful response should describe the same capture")
+}
+
 func TestGetAuthorization(t *testing.T) {
 	ts := SetupTest(t)
 	defer ts.Close()
@@ -311,6 +689,57 @@ func TestGetAuthorization(t *testing.T) {
 
 	assert.Equal(t, authID, getBody["authorization_id"])
 	assert.Equal(t, float64(10000), getBody["amount"])
+	assert.Equal(t, "active", getBody["status"])
+}
+
+func TestGetAuthorization_LifecycleState(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	getAuth := func(authID string) map[string]any {
+		resp, err := http.Get(ts.URL("/api/v1/authorizations/" + authID))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		resp.Body.Close()
+		return body
+	}
+
+	authorize := func(key string) string {
+		resp := ts.Authorize(t, "4111111111111111", "123", 10000, key)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		resp.Body.Close()
+		return body["authorization_id"].(string)
+	}
+
+	capturedID := authorize("lifecycle-auth-captured")
+	captureResp := ts.Capture(t, capturedID, 10000, "lifecycle-cap")
+	require.Equal(t, http.StatusOK, captureResp.StatusCode)
+	var captureBody map[string]any
+	require.NoError(t, json.NewDecoder(captureResp.Body).Decode(&captureBody))
+	captureResp.Body.Close()
+
+	captured := getAuth(capturedID)
+	assert.Equal(t, "captured", captured["status"])
+	assert.Equal(t, []any{captureBody["capture_id"]}, captured["capture_ids"])
+	assert.NotContains(t, captured, "void_id")
+
+	voidedID := authorize("lifecycle-auth-voided")
+	voidResp := ts.Void(t, voidedID, "lifecycle-void")
+	require.Equal(t, http.StatusOK, voidResp.StatusCode)
+	var voidBody map[string]any
+	require.NoError(t, json.NewDecoder(voidResp.Body).Decode(&voidBody))
+	voidResp.Body.Close()
+
+	voided := getAuth(voidedID)
+	assert.Equal(t, "voided", voided["status"])
+	assert.Equal(t, voidBody["void_id"], voided["void_id"])
+	assert.Empty(t, voided["capture_ids"])
 }
 
 func TestGetAuthorization_NotFound(t *testing.T) {
@@ -322,3 +751,114 @@ func TestGetAuthorization_NotFound(t *testing.T) {
 	require.Equal(t, http.StatusNotFound, resp.StatusCode)
 	resp.Body.Close()
 }
+
+func TestListTransactions_Pagination(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 10000, "list-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	for i, amount := range []int64{1000, 2000, 3000} {
+		captureResp := ts.Capture(t, authID, amount, fmt.Sprintf("list-cap-%d", i))
+		require.Equal(t, http.StatusOK, captureResp.StatusCode)
+		captureResp.Body.Close()
+	}
+
+	var ids []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 10, "pagination did not terminate")
+
+		query := url.Values{"reference_id": {authID}, "type": {"capture"}, "limit": {"2"}}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+		resp, err := http.Get(ts.URL("/api/v1/transactions?" + query.Encode()))
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var page map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+		resp.Body.Close()
+
+		for _, item := range page["data"].([]any) {
+			txn := item.(map[string]any)
+			assert.Equal(t, "capture", txn["type"])
+			assert.Equal(t, authID, txn["reference_id"])
+			ids = append(ids, txn["id"].(string))
+		}
+
+		if page["has_more"] != true {
+			break
+		}
+		cursor = page["next_cursor"].(string)
+	}
+
+	require.Len(t, ids, 3, "every capture should be listed exactly once")
+	for _, id := range ids {
+		assert.Contains(t, id, "cap_")
+	}
+
+	resp, err := http.Get(ts.URL("/api/v1/transactions?cursor=bogus"))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var body map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	resp.Body.Close()
+	assert.Equal(t, "invalid_query", body["error"])
+}
+
+func TestAuthorization_MetadataStoredAndSearchable(t *testing.T) {
+	ts := SetupTest(t)
+	defer ts.Close()
+
+	metadata := map[string]string{"order_id": "ord_meta_1", "customer_id": "cus_42"}
+	authResp := ts.AuthorizeWithMetadata(t, "4111111111111111", "123", 10000, metadata, "meta-auth")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+	assert.Equal(t, "ord_meta_1", authBody["metadata"].(map[string]any)["order_id"])
+
+	other := ts.Authorize(t, "4111111111111111", "123", 500, "meta-other")
+	require.Equal(t, http.StatusOK, other.StatusCode)
+	other.Body.Close()
+
+	resp, err := http.Get(ts.URL("/api/v1/authorizations/" + authID))
+	require.NoError(t, err)
+	var getBody map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&getBody))
+	resp.Body.Close()
+	assert.Equal(t, "cus_42", getBody["metadata"].(map[string]any)["customer_id"])
+
+	query := url.Values{"metadata_key": {"order_id"}, "metadata_value": {"ord_meta_1"}}
+	resp, err = http.Get(ts.URL("/api/v1/transactions?" + query.Encode()))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var page map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	resp.Body.Close()
+
+	data := page["data"].([]any)
+	require.Len(t, data, 1)
+	assert.Equal(t, authID, data[0].(map[string]any)["id"])
+
+	tooLarge := map[string]string{"order_id": strings.Repeat("x", 501)}
+	badResp := ts.AuthorizeWithMetadata(t, "4111111111111111", "123", 10000, tooLarge, "meta-too-large")
+	require.Equal(t, http.StatusBadRequest, badResp.StatusCode)
+
+	var badBody map[string]any
+	require.NoError(t, json.NewDecoder(badResp.Body).Decode(&badBody))
+	badResp.Body.Close()
+	assert.Equal(t, "invalid_metadata", badBody["error"])
+}
@@ -0,0 +1,82 @@
+//nolint:errcheck // unchecked errors are acceptable in test files
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryBackend_AuthorizeCaptureRefund(t *testing.T) {
+	ts := SetupMemoryTest(t)
+	defer ts.Close()
+
+	authResp := ts.Authorize(t, "4111111111111111", "123", 15000, "memory-auth-1")
+	require.Equal(t, http.StatusOK, authResp.StatusCode)
+
+	var authBody map[string]any
+	require.NoError(t, json.NewDecoder(authResp.Body).Decode(&authBody))
+	authResp.Body.Close()
+	authID := authBody["authorization_id"].(string)
+
+	captureResp := ts.Capture(t, authID, 15000, "memory-cap-1")
+	require.Equal(t, http.StatusOK, captureResp.StatusCode)
+	captureBody, _ := io.ReadAll(captureResp.Body)
+	captureResp.Body.Close()
+
+	replayResp := ts.Capture(t, authID, 15000, "memory-cap-1")
+	require.Equal(t, http.StatusOK, replayResp.StatusCode)
+	replayBody, _ := io.ReadAll(replayResp.Body)
+	replayResp.Body.Close()
+
+	assert.Equal(t, "true", replayResp.Header.Get("X-Idempotent-Replayed"))
+	assert.Equal(t, string(captureBody), string(replayBody))
+
+	var capture map[string]any
+	require.NoError(t, json.Unmarshal(captureBody, &capture))
+	captureID := capture["capture_id"].(string)
+
+	refundResp := ts.Refund(t, captureID, 15000, "memory-refund-1")
+	require.Equal(t, http.StatusOK, refundResp.StatusCode)
+
+	var refundBody map[string]any
+	require.NoError(t, json.NewDecoder(refundResp.Body).Decode(&refundBody))
+	refundResp.Body.Close()
+
+	assert.Equal(t, "refunded", refundBody["status"])
+	assert.Equal(t, captureID, refundBody["capture_id"])
+}
+
+func TestMemoryBackend_ConcurrentAuthorizationsRespectBalance(t *testing.T) {
+	ts := SetupMemoryTest(t)
+	defer ts.Close()
+
+	// $500 balance: only five $100 authorizations fit
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		statuses = map[int]int{}
+	)
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := ts.Authorize(t, "4242424242424242", "456", 10000, fmt.Sprintf("memory-concurrent-%d", i))
+			resp.Body.Close()
+
+			mu.Lock()
+			statuses[resp.StatusCode]++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 5, statuses[http.StatusOK])
+	assert.Equal(t, 3, statuses[http.StatusPaymentRequired])
+}
@@ -11,9 +11,12 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/benx421/payment-gateway/bank/internal/clock"
 	"github.com/benx421/payment-gateway/bank/internal/config"
 	"github.com/benx421/payment-gateway/bank/internal/db"
 	"github.com/benx421/payment-gateway/bank/internal/handlers"
+	"github.com/benx421/payment-gateway/bank/internal/repository"
+	"github.com/benx421/payment-gateway/bank/internal/repository/memory"
 	"github.com/stretchr/testify/require"
 )
 
@@ -43,7 +46,7 @@ func SetupTest(t *testing.T) *TestServer {
 
 	resetTestData(t, database)
 
-	router := handlers.NewRouter(database, cfg, logger)
+	router := handlers.NewRouter(repository.NewPostgresStore(database), clock.System, cfg, logger)
 	server := httptest.NewServer(router)
 
 	return &TestServer{
@@ -53,10 +56,34 @@ func SetupTest(t *testing.T) *TestServer {
 	}
 }
 
+// SetupMemoryTest creates a test server on the in-memory storage backend, seeded
+// with the test accounts. Database is nil; no PostgreSQL is needed.
+func SetupMemoryTest(t *testing.T) *TestServer {
+	t.Helper()
+
+	cfg, err := config.Load()
+	require.NoError(t, err, "failed to load config")
+
+	cfg.Storage.Backend = config.StorageBackendMemory
+	cfg.App.FailureRate = 0
+	cfg.App.MinLatencyMS = 0
+	cfg.App.MaxLatencyMS = 0
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	router := handlers.NewRouter(memory.NewStore(memory.TestAccounts()...), clock.System, cfg, logger)
+
+	return &TestServer{
+		Server: httptest.NewServer(router),
+		t:      t,
+	}
+}
+
 // Close shuts down the test server and database connection.
 func (ts *TestServer) Close() {
 	ts.Server.Close()
-	_ = ts.Database.Close()
+	if ts.Database != nil {
+		_ = ts.Database.Close()
+	}
 }
 
 // URL returns the full URL for a given path.
@@ -80,14 +107,39 @@ func resetTestData(t *testing.T, database *db.DB) {
 	require.NoError(t, err, "failed to reset test data")
 }
 
-// Authorize sends a POST request to create an authorization.
+// testCardExpiry holds the expiry on file for each seeded test card, as {month, year}.
+var testCardExpiry = map[string][2]int{
+	"4111111111111111": {12, 2030},
+	"4242424242424242": {6, 2030},
+	"5555555555554444": {9, 2030},
+	"5105105105105100": {3, 2020},
+}
+
+// Authorize sends a POST request to create an authorization with the card's expiry on file.
 func (ts *TestServer) Authorize(t *testing.T, cardNumber, cvv string, amount int64, idempotencyKey string) *http.Response {
 	t.Helper()
 
+	return ts.AuthorizeWithMetadata(t, cardNumber, cvv, amount, nil, idempotencyKey)
+}
+
+// AuthorizeWithMetadata sends a POST request to create an authorization carrying merchant metadata.
+func (ts *TestServer) AuthorizeWithMetadata(t *testing.T, cardNumber, cvv string, amount int64, metadata map[string]string, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	expiry, ok := testCardExpiry[cardNumber]
+	if !ok {
+		expiry = [2]int{12, 2030}
+	}
+
 	body := map[string]any{
-		"card_number": cardNumber,
-		"cvv":         cvv,
-		"amount":      amount,
+		"card_number":  cardNumber,
+		"cvv":          cvv,
+		"expiry_month": expiry[0],
+		"expiry_year":  expiry[1],
+		"amount":       amount,
+	}
+	if metadata != nil {
+		body["metadata"] = metadata
 	}
 	jsonBody, _ := json.Marshal(body)
 
@@ -103,6 +155,48 @@ func (ts *TestServer) Authorize(t *testing.T, cardNumber, cvv string, amount int
 	return resp
 }
 
+// Increment sends a POST request to raise an authorization hold.
+func (ts *TestServer) Increment(t *testing.T, authID string, amount int64, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	body := map[string]any{
+		"amount": amount,
+	}
+	jsonBody, _ := json.Marshal(body)
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL("/api/v1/authorizations/"+authID+"/increments"), bytes.NewReader(jsonBody))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
+// Reverse sends a POST request to release part of an authorization hold.
+func (ts *TestServer) Reverse(t *testing.T, authID string, amount int64, idempotencyKey string) *http.Response {
+	t.Helper()
+
+	body := map[string]any{
+		"amount": amount,
+	}
+	jsonBody, _ := json.Marshal(body)
+
+	req, err := http.NewRequest(http.MethodPost, ts.URL("/api/v1/authorizations/"+authID+"/reversals"), bytes.NewReader(jsonBody))
+	require.NoError(t, err)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	return resp
+}
+
 // Capture sends a POST request to capture an authorization.
 func (ts *TestServer) Capture(t *testing.T, authID string, amount int64, idempotencyKey string) *http.Response {
 	t.Helper()